@@ -6,13 +6,18 @@ import (
 	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/common/hexutil"
 	"github.com/uworldao/UWORLD/common/keystore"
+	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
 	"github.com/uworldao/UWORLD/p2p"
 	"github.com/uworldao/UWORLD/rpc"
 	"github.com/uworldao/UWORLD/rpc/rpctypes"
 	"github.com/uworldao/UWORLD/ut"
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -20,6 +25,8 @@ func init() {
 	accountCmds := []*cobra.Command{
 		CreateAccountCmd,
 		GetAccountCmd,
+		GetAccountAtHeightCmd,
+		GetAccountProofCmd,
 		ShowAccountCmd,
 		DecryptAccountCmd,
 		MnemonicToAccountCmd,
@@ -61,6 +68,131 @@ func GetAccount(cmd *cobra.Command, args []string) {
 		outputRespError(cmd.Use, resp)
 	}
 }
+
+var GetAccountAtHeightCmd = &cobra.Command{
+	Use:     "GetAccountAtHeight {address} {height};Get account status after the block of the height;",
+	Aliases: []string{"getaccountatheight", "gaah", "GAAH"},
+	Short:   "GetAccountAtHeight {address} {height};Get account status after the block of the height;",
+	Example: `
+	GetAccountAtHeight 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 100
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  GetAccountAtHeight,
+}
+
+func GetAccountAtHeight(cmd *cobra.Command, args []string) {
+	height, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong height"))
+		return
+	}
+	client, err := NewRpcClient()
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetAccountAtHeight(ctx, &rpc.AddressHeight{Address: args[0], Height: height})
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if resp.Code == 0 {
+		account := &rpctypes.Account{}
+		json.Unmarshal(resp.Result, account)
+		if account.Address != args[0] {
+			account.Address = args[0]
+		}
+		bytes, _ := json.Marshal(account)
+		output(string(bytes))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
+var GetAccountProofCmd = &cobra.Command{
+	Use:     "GetAccountProof {address} {height} {stateroot}; Get the account proven against the state root of the header at the height;",
+	Aliases: []string{"getaccountproof", "gap", "GAP"},
+	Short:   "GetAccountProof {address} {height} {stateroot}; Get the account proven against the state root of the header at the height;",
+	Example: `
+	GetAccountProof 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 100
+		OR
+	GetAccountProof 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 100 0x0b5ad7ac8a0b9f6f1c5a6c1c43b8e8e0d8fb2e0c17b1ed4e5f8a2f9c1b0f5c3d
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  GetAccountProof,
+}
+
+// Without a trusted state root the proof is only checked against
+// the root returned by the node
+func GetAccountProof(cmd *cobra.Command, args []string) {
+	height, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong height"))
+		return
+	}
+	client, err := NewRpcClient()
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetAccountProof(ctx, &rpc.AddressHeight{Address: args[0], Height: height})
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if resp.Code != 0 {
+		outputRespError(cmd.Use, resp)
+		return
+	}
+	accountProof := &rpctypes.AccountProof{}
+	if err := json.Unmarshal(resp.Result, accountProof); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	stateRoot := accountProof.StateRoot
+	if len(args) > 2 {
+		stateRoot = args[2]
+	}
+	root, err := hasharry.StringToHash(stateRoot)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong state root"))
+		return
+	}
+	accountBytes, err := hexutil.Decode(accountProof.Account)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong account"))
+		return
+	}
+	proof, err := rpctypes.DecodeProof(accountProof.Proof)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong proof"))
+		return
+	}
+	if err := ut.VerifyAccountProof(root, args[0], accountBytes, proof); err != nil {
+		outputError(cmd.Use, fmt.Errorf("verify proof failed! %s", err.Error()))
+		return
+	}
+	account := types.NewAccount(hasharry.StringToAddress(args[0]))
+	if len(accountBytes) != 0 {
+		if err := rlp.DecodeBytes(accountBytes, account); err != nil {
+			outputError(cmd.Use, err)
+			return
+		}
+	}
+	bytes, _ := json.Marshal(rpctypes.TranslateAccountToRpcAccount(account))
+	output(string(bytes))
+}
+
 func GetAccountRpc(addr string) (string, error) {
 	resp, err := GetAccountByRpc(addr)
 	if err != nil {
@@ -0,0 +1,216 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/rpc"
+	"github.com/uworldao/UWORLD/ut/transaction"
+	"strconv"
+	"time"
+)
+
+func init() {
+	candidateCmds := []*cobra.Command{
+		LoginCandidateCmd,
+		VoteToCandidateCmd,
+		LogoutCandidateCmd,
+		SendDoubleSignCmd,
+		GetCandidatesCmd,
+	}
+	RootCmd.AddCommand(candidateCmds...)
+	RootSubCmdGroups["candidate"] = candidateCmds
+}
+
+var LoginCandidateCmd = &cobra.Command{
+	Use:     "LoginCandidate {from} {peerid} {password} {nonce}; Become a candidate with the peer id of the node, the candidate bond is locked until the unbonding period after the logout;",
+	Aliases: []string{"logincandidate", "lic", "LIC"},
+	Short:   "LoginCandidate {from} {peerid} {password} {nonce}; Become a candidate;",
+	Example: `
+	LoginCandidate UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 16Uiu2HAm8dGc2gAuQG9WAdPNeXRDG1GU8wQtv4f2jdGEGkrX9Ln1 123456
+		OR
+	LoginCandidate UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 16Uiu2HAm8dGc2gAuQG9WAdPNeXRDG1GU8wQtv4f2jdGEGkrX9Ln1 123456 1
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  LoginCandidate,
+}
+
+func LoginCandidate(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 2, parseLICParams)
+}
+
+func parseLICParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	peerId := args[1]
+	if len(peerId) != types.PeerIdLength {
+		return nil, errors.New("wrong peer id")
+	}
+	if len(args) > 3 {
+		nonce, err = strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewLoginCandidate(from, peerId, nonce, ""), nil
+}
+
+var VoteToCandidateCmd = &cobra.Command{
+	Use:     "VoteToCandidate {from} {candidate} {password} {nonce}; Vote for a candidate with the balance of the address, a new vote replaces the previous one;",
+	Aliases: []string{"votetocandidate", "vtc", "VTC"},
+	Short:   "VoteToCandidate {from} {candidate} {password} {nonce}; Vote for a candidate;",
+	Example: `
+	VoteToCandidate UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 123456
+		OR
+	VoteToCandidate UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 123456 1
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  VoteToCandidate,
+}
+
+func VoteToCandidate(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 2, parseVTCParams)
+}
+
+func parseVTCParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	to := args[1]
+	if len(args) > 3 {
+		nonce, err = strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewVoteToCandidate(from, to, nonce, ""), nil
+}
+
+var LogoutCandidateCmd = &cobra.Command{
+	Use:     "LogoutCandidate {from} {password} {nonce}; Stop being a candidate, the bond is released after the unbonding period. It is refused while the candidates are not more than the super nodes;",
+	Aliases: []string{"logoutcandidate", "loc", "LOC"},
+	Short:   "LogoutCandidate {from} {password} {nonce}; Stop being a candidate;",
+	Example: `
+	LogoutCandidate UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 123456
+		OR
+	LogoutCandidate UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 123456 1
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  LogoutCandidate,
+}
+
+func LogoutCandidate(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 1, parseLOCParams)
+}
+
+func parseLOCParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	if len(args) > 2 {
+		nonce, err = strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewLogoutCandidate(from, nonce, ""), nil
+}
+
+var SendDoubleSignCmd = &cobra.Command{
+	Use:     "SendDoubleSign {from} {blockhash1} {blockhash2} {password} {nonce}; Send the evidence of two blocks signed by the same winner at the same height, the winner is jailed and part of its bond is burned;",
+	Aliases: []string{"senddoublesign", "sds", "SDS"},
+	Short:   "SendDoubleSign {from} {blockhash1} {blockhash2} {password} {nonce}; Send the evidence of a double signing;",
+	Example: `
+	SendDoubleSign UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 0x6a1e8c28ca3ce7d8c4ee17dfaf31a4c6b47e21c5ba0b3fbb9ff3a1c3a0e5f90a 0x0c2f2c5e4d8b0f4e1cf5c6a4b9d6a9a5d0f0f2b8c36d6e5b7a8c9d0e1f2a3b4c 123456
+		OR
+	SendDoubleSign UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 0x6a1e8c28ca3ce7d8c4ee17dfaf31a4c6b47e21c5ba0b3fbb9ff3a1c3a0e5f90a 0x0c2f2c5e4d8b0f4e1cf5c6a4b9d6a9a5d0f0f2b8c36d6e5b7a8c9d0e1f2a3b4c 123456 1
+	`,
+	Args: cobra.MinimumNArgs(3),
+	Run:  SendDoubleSign,
+}
+
+func SendDoubleSign(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 3, parseSDSParams)
+}
+
+func parseSDSParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	header1, err := getHeaderByRpc(args[1])
+	if err != nil {
+		return nil, err
+	}
+	header2, err := getHeaderByRpc(args[2])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 4 {
+		nonce, err = strconv.ParseUint(args[4], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewDoubleSign(from, header1, header2, nonce, ""), nil
+}
+
+// The node keeps the blocks of the side chains, so both
+// blocks can be read from it by their hashes
+func getHeaderByRpc(hash string) (*types.Header, error) {
+	client, err := NewRpcClient()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetBlockByHash(ctx, &rpc.Hash{Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("get block %s failed! %s", hash, resp.Err)
+	}
+	var block *types.RpcBlock
+	if err := json.Unmarshal(resp.Result, &block); err != nil {
+		return nil, err
+	}
+	return types.TranslateRpcHeaderToHeader(block.RpcHeader)
+}
+
+var GetCandidatesCmd = &cobra.Command{
+	Use:     "GetCandidates; Get the candidates with their live votes, sorted by the votes;",
+	Aliases: []string{"getcandidates", "gcd", "GCD"},
+	Short:   "GetCandidates; Get the candidates;",
+	Example: `
+	GetCandidates
+	`,
+	Args: cobra.MinimumNArgs(0),
+	Run:  GetCandidates,
+}
+
+func GetCandidates(cmd *cobra.Command, args []string) {
+	client, err := NewRpcClient()
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetCandidates(ctx, &rpc.Null{})
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
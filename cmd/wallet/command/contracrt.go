@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/uworldao/UWORLD/common/codec"
 	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/common/hexutil"
 	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2"
 	"github.com/uworldao/UWORLD/rpc"
 	"github.com/uworldao/UWORLD/rpc/rpctypes"
 	"github.com/uworldao/UWORLD/ut"
@@ -19,6 +22,8 @@ import (
 func init() {
 	contractCmds := []*cobra.Command{
 		GetContractCmd,
+		GetContractAtHeightCmd,
+		GetContractProofCmd,
 		SendContractCmd,
 	}
 	RootCmd.AddCommand(contractCmds...)
@@ -165,6 +170,135 @@ func GetContract(cmd *cobra.Command, args []string) {
 	}
 }
 
+var GetContractAtHeightCmd = &cobra.Command{
+	Use:     "GetContractAtHeight {contract address} {height}; Get a contract after the block of the height;",
+	Aliases: []string{"getcontractatheight", "gcah", "GCAH"},
+	Short:   "GetContractAtHeight {contract address} {height}; Get a contract after the block of the height;",
+	Example: `
+	GetContractAtHeight 2KwjygFUZ8oWbWAzY7mT5tvpHC8ohtG9h3h3xjxmtqYD 100
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  GetContractAtHeight,
+}
+
+func GetContractAtHeight(cmd *cobra.Command, args []string) {
+	height, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong height"))
+		return
+	}
+	client, err := NewRpcClient()
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetContractAtHeight(ctx, &rpc.AddressHeight{Address: args[0], Height: height})
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
+var GetContractProofCmd = &cobra.Command{
+	Use:     "GetContractProof {contract address} {height} {contractroot}; Get the contract proven against the contract root of the header at the height;",
+	Aliases: []string{"getcontractproof", "gcp", "GCP"},
+	Short:   "GetContractProof {contract address} {height} {contractroot}; Get the contract proven against the contract root of the header at the height;",
+	Example: `
+	GetContractProof 2KwjygFUZ8oWbWAzY7mT5tvpHC8ohtG9h3h3xjxmtqYD 100
+		OR
+	GetContractProof 2KwjygFUZ8oWbWAzY7mT5tvpHC8ohtG9h3h3xjxmtqYD 100 0x0b5ad7ac8a0b9f6f1c5a6c1c43b8e8e0d8fb2e0c17b1ed4e5f8a2f9c1b0f5c3d
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  GetContractProof,
+}
+
+func GetContractProof(cmd *cobra.Command, args []string) {
+	height, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong height"))
+		return
+	}
+	client, err := NewRpcClient()
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetContractProof(ctx, &rpc.AddressHeight{Address: args[0], Height: height})
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if resp.Code != 0 {
+		outputRespError(cmd.Use, resp)
+		return
+	}
+	contractProof := &rpctypes.ContractProof{}
+	if err := json.Unmarshal(resp.Result, contractProof); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	contractRoot := contractProof.ContractRoot
+	if len(args) > 2 {
+		contractRoot = args[2]
+	}
+	root, err := hasharry.StringToHash(contractRoot)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong contract root"))
+		return
+	}
+	contractBytes, err := hexutil.Decode(contractProof.Contract)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong contract"))
+		return
+	}
+	proof, err := rpctypes.DecodeProof(contractProof.Proof)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong proof"))
+		return
+	}
+	if err := ut.VerifyContractProof(root, args[0], contractProof.KeyVersion, contractBytes, proof); err != nil {
+		outputError(cmd.Use, fmt.Errorf("verify proof failed! %s", err.Error()))
+		return
+	}
+	if len(contractBytes) == 0 {
+		outputError(cmd.Use, fmt.Errorf("contract address %s is not exist", args[0]))
+		return
+	}
+	var bytes []byte
+	switch contractProof.KeyVersion {
+	case ut.ContractKeyV1:
+		contract := types.NewContract()
+		if err := codec.FromBytes(contractBytes, &contract); err != nil {
+			outputError(cmd.Use, err)
+			return
+		}
+		bytes, _ = json.Marshal(types.TranslateContractToRpcContract(contract))
+	case ut.ContractKeyV2:
+		contract, err := contractv2.DecodeContractV2(contractBytes)
+		if err != nil {
+			outputError(cmd.Use, err)
+			return
+		}
+		bytes, _ = json.Marshal(contract)
+	}
+	output(string(bytes))
+}
+
 func GetContractByRpc(contractAddr string) (*rpc.Response, error) {
 	client, err := NewRpcClient()
 	if err != nil {
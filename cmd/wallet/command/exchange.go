@@ -26,6 +26,7 @@ func init() {
 		SwapExactInCmd,
 		SwapExactOutCmd,
 		GetAllPairsCmd,
+		GetAllPairsAtHeightCmd,
 	}
 	RootCmd.AddCommand(exchangeCmds...)
 	RootSubCmdGroups["exchange"] = exchangeCmds
@@ -767,6 +768,45 @@ func GetAllPairs(cmd *cobra.Command, args []string) {
 	outputRespError(cmd.Use, resp)
 }
 
+var GetAllPairsAtHeightCmd = &cobra.Command{
+	Use:     "GetAllPairsAtHeight {exchange} {height}; Get all pairs of the exchange after the block of the height;",
+	Aliases: []string{"getallpairsatheight", "gapah", "GAPAH"},
+	Short:   "GetAllPairsAtHeight {exchange} {height}; Get all pairs of the exchange after the block of the height;",
+	Example: `
+	GetAllPairsAtHeight UWTfBGxDMZX19vjnacXVkP51min9EjhYq43W 100
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  GetAllPairsAtHeight,
+}
+
+func GetAllPairsAtHeight(cmd *cobra.Command, args []string) {
+	height, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong height"))
+		return
+	}
+	client, err := NewRpcClient()
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetExchangePairsAtHeight(ctx, &rpc.AddressHeight{Address: args[0], Height: height})
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
 func GetAllPairByRpc(addr string) ([]*types.RpcPair, error) {
 	client, err := NewRpcClient()
 	if err != nil {
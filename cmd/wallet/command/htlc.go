@@ -0,0 +1,207 @@
+package command
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/rpc"
+	"github.com/uworldao/UWORLD/ut/transaction"
+	"strconv"
+	"time"
+)
+
+func init() {
+	htlcCmds := []*cobra.Command{
+		NewHTLCSecretCmd,
+		CreateHTLCCmd,
+		ClaimHTLCCmd,
+		RefundHTLCCmd,
+		GetHTLCByHashLockCmd,
+	}
+	RootCmd.AddCommand(htlcCmds...)
+	RootSubCmdGroups["htlc"] = htlcCmds
+}
+
+var NewHTLCSecretCmd = &cobra.Command{
+	Use:     "NewHTLCSecret; Generate a random preimage and its sha256 hash lock;",
+	Aliases: []string{"newhtlcsecret", "nhs", "NHS"},
+	Short:   "NewHTLCSecret; Generate a preimage and a hash lock;",
+	Example: `
+	NewHTLCSecret
+	`,
+	Args: cobra.MinimumNArgs(0),
+	Run:  NewHTLCSecret,
+}
+
+func NewHTLCSecret(cmd *cobra.Command, args []string) {
+	preimage := make([]byte, htlc.PreimageSize)
+	if _, err := rand.Read(preimage); err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	hashLock := sha256.Sum256(preimage)
+	bytes, _ := json.Marshal(map[string]string{
+		"preimage": hex.EncodeToString(preimage),
+		"hashlock": hasharry.BytesToHash(hashLock[:]).String(),
+	})
+	output(string(bytes))
+}
+
+var CreateHTLCCmd = &cobra.Command{
+	Use:     "CreateHTLC {from} {recipient} {token} {amount} {hashlock} {timelock} {password} {nonce}; Lock coins to the recipient, they can be claimed with the preimage of the hash lock before the time lock height and refunded after it;",
+	Aliases: []string{"createhtlc", "chtlc", "CHTLC"},
+	Short:   "CreateHTLC {from} {recipient} {token} {amount} {hashlock} {timelock} {password} {nonce}; Create a hashed time locked swap;",
+	Example: `
+	CreateHTLC UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh UWD 10 0x9c56cc51b374c3ba189210d5b6d4bf57790d351c96c47c02190ecf1e430635ab 20000 123456
+		OR
+	CreateHTLC UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh UWD 10 0x9c56cc51b374c3ba189210d5b6d4bf57790d351c96c47c02190ecf1e430635ab 20000 123456 1
+	`,
+	Args: cobra.MinimumNArgs(6),
+	Run:  CreateHTLC,
+}
+
+func CreateHTLC(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 6, parseCHTLCParams)
+}
+
+func parseCHTLCParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	recipient := args[1]
+	token := args[2]
+	amountf, err := strconv.ParseFloat(args[3], 64)
+	if err != nil {
+		return nil, errors.New("wrong amount")
+	}
+	amount, _ := types.NewAmount(amountf)
+	hashLock, err := hasharry.StringToHash(args[4])
+	if err != nil {
+		return nil, errors.New("wrong hash lock")
+	}
+	timeLock, err := strconv.ParseUint(args[5], 10, 64)
+	if err != nil {
+		return nil, errors.New("wrong time lock")
+	}
+	if len(args) > 7 {
+		nonce, err = strconv.ParseUint(args[7], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	tx, err := transaction.NewHTLC(Net, from, recipient, token, amount, hashLock, timeLock, nonce, "")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("htlc:", tx.GetTxBody().GetContract().String())
+	return tx, nil
+}
+
+var ClaimHTLCCmd = &cobra.Command{
+	Use:     "ClaimHTLC {from} {htlc} {preimage} {password} {nonce}; Send the coins of a swap to its recipient with the hex preimage of the hash lock, anyone can claim before the time lock height;",
+	Aliases: []string{"claimhtlc", "clhtlc", "CLHTLC"},
+	Short:   "ClaimHTLC {from} {htlc} {preimage} {password} {nonce}; Claim a swap with the preimage;",
+	Example: `
+	ClaimHTLC UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 8d1b3c5fa6e2e3c2b0b7d4c1b3a5d0b2f1e9c7a3d5b6c8e0f2a4b6c8d0e2f4a6 123456
+		OR
+	ClaimHTLC UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 8d1b3c5fa6e2e3c2b0b7d4c1b3a5d0b2f1e9c7a3d5b6c8e0f2a4b6c8d0e2f4a6 123456 1
+	`,
+	Args: cobra.MinimumNArgs(3),
+	Run:  ClaimHTLC,
+}
+
+func ClaimHTLC(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 3, parseCLHTLCParams)
+}
+
+func parseCLHTLCParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	swap := args[1]
+	preimage, err := hex.DecodeString(args[2])
+	if err != nil {
+		return nil, errors.New("wrong preimage")
+	}
+	if len(preimage) != htlc.PreimageSize {
+		return nil, fmt.Errorf("the preimage must be %d bytes", htlc.PreimageSize)
+	}
+	if len(args) > 4 {
+		nonce, err = strconv.ParseUint(args[4], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewHTLCClaim(from, swap, preimage, nonce, "")
+}
+
+var RefundHTLCCmd = &cobra.Command{
+	Use:     "RefundHTLC {from} {htlc} {password} {nonce}; Send the coins of an expired swap back to its sender, anyone can refund from the time lock height;",
+	Aliases: []string{"refundhtlc", "rhtlc", "RHTLC"},
+	Short:   "RefundHTLC {from} {htlc} {password} {nonce}; Refund an expired swap;",
+	Example: `
+	RefundHTLC UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 123456
+		OR
+	RefundHTLC UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 123456 1
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  RefundHTLC,
+}
+
+func RefundHTLC(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 2, parseRHTLCParams)
+}
+
+func parseRHTLCParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	swap := args[1]
+	if len(args) > 3 {
+		nonce, err = strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewHTLCRefund(from, swap, nonce, "")
+}
+
+var GetHTLCByHashLockCmd = &cobra.Command{
+	Use:     "GetHTLCByHashLock {hashlock}; Get the swaps locked by the hash, with their state and the preimage once claimed;",
+	Aliases: []string{"gethtlcbyhashlock", "ghtlc", "GHTLC"},
+	Short:   "GetHTLCByHashLock {hashlock}; Get the swaps of a hash lock;",
+	Example: `
+	GetHTLCByHashLock 0x9c56cc51b374c3ba189210d5b6d4bf57790d351c96c47c02190ecf1e430635ab
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  GetHTLCByHashLock,
+}
+
+func GetHTLCByHashLock(cmd *cobra.Command, args []string) {
+	client, err := NewRpcClient()
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetHTLCByHashLock(ctx, &rpc.Hash{Hash: args[0]})
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
@@ -0,0 +1,572 @@
+package command
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/crypto/ecc/schnorr"
+	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
+	"github.com/uworldao/UWORLD/rpc/rpctypes"
+	"github.com/uworldao/UWORLD/ut"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	multiSigCmds := []*cobra.Command{
+		GetPubKeyCmd,
+		CreateMultiSigAddressCmd,
+		CreateMultiSigTransactionCmd,
+		SignMultiSigTransactionCmd,
+		SendMultiSigTransactionCmd,
+	}
+	RootCmd.AddCommand(multiSigCmds...)
+	RootSubCmdGroups["multisig"] = multiSigCmds
+}
+
+// Account of a multi-signature address, it is saved in the multisig
+// directory of the keystore
+type multiSigAccount struct {
+	Address string             `json:"address"`
+	Type    types.MultiSigType `json:"type"`
+	M       uint32             `json:"m"`
+	PubKeys []string           `json:"pubkeys"`
+}
+
+// A multi-signature transaction passed between the signers. The
+// schnorr signers first add their public nonces, then every signer
+// that added a nonce adds the partial signature.
+type multiSigTx struct {
+	Transaction *types.RpcTransaction    `json:"transaction"`
+	Nonces      map[uint32]*schnorrNonce `json:"nonces,omitempty"`
+	Partials    map[uint32]string        `json:"partials,omitempty"`
+}
+
+// The private nonce is derived from the private key, the transaction
+// hash and the salt, so only the salt and the public nonce are shared
+type schnorrNonce struct {
+	Salt     string `json:"salt"`
+	PubNonce string `json:"pubnonce"`
+}
+
+var GetPubKeyCmd = &cobra.Command{
+	Use:     "GetPubKey {address} {password}; Get the public key of an account to create a multi-signature address;",
+	Aliases: []string{"getpubkey", "gpk", "GPK"},
+	Short:   "GetPubKey {address} {password}; Get the public key of an account to create a multi-signature address;",
+	Example: `
+	GetPubKey 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ
+		OR
+	GetPubKey 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 123456
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  GetPubKey,
+}
+
+func GetPubKey(cmd *cobra.Command, args []string) {
+	priv, err := readMultiSigSigner(args[0], args[1:])
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	output(fmt.Sprintf(`{"address":"%s","pubkey":"%s"}`, args[0], hex.EncodeToString(priv.PubKey().SerializeCompressed())))
+}
+
+var CreateMultiSigAddressCmd = &cobra.Command{
+	Use:     "CreateMultiSigAddress {m} {pubkey,pubkey} {type}; Create an address that needs m signatures of the public keys, the type is script or schnorr;",
+	Aliases: []string{"createmultisigaddress", "cma", "CMA"},
+	Short:   "CreateMultiSigAddress {m} {pubkey,pubkey} {type}; Create an address that needs m signatures of the public keys, the type is script or schnorr;",
+	Example: `
+	CreateMultiSigAddress 2 033fe6c5248f281ceb62f1346efacbf4dcd4c395f87883ccea23074ccc1c18e195,03db361258dd9b0de5d69cfd02ae6259e9fa376c2fa719523ef67c8ad5893de99b script
+		OR
+	CreateMultiSigAddress 2 033fe6c5248f281ceb62f1346efacbf4dcd4c395f87883ccea23074ccc1c18e195,03db361258dd9b0de5d69cfd02ae6259e9fa376c2fa719523ef67c8ad5893de99b schnorr
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  CreateMultiSigAddress,
+}
+
+func CreateMultiSigAddress(cmd *cobra.Command, args []string) {
+	m, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		outputError(cmd.Use, errors.New("wrong m"))
+		return
+	}
+	sigType := types.MultiSig_Script
+	if len(args) > 2 {
+		switch strings.ToLower(args[2]) {
+		case "script":
+		case "schnorr":
+			sigType = types.MultiSig_Schnorr
+		default:
+			outputError(cmd.Use, errors.New("wrong type, must be script or schnorr"))
+			return
+		}
+	}
+	if sigType == types.MultiSig_Schnorr && m < 2 {
+		outputError(cmd.Use, errors.New("a schnorr address needs at least 2 signatures"))
+		return
+	}
+	keyStrs := strings.Split(args[1], ",")
+	if len(keyStrs) > types.MaxMultiSigKeys {
+		outputError(cmd.Use, fmt.Errorf("no more than %d public keys", types.MaxMultiSigKeys))
+		return
+	}
+	// The keys are sorted, so every signer gets the same address
+	// whatever order the keys are given in
+	pubKeys := make([][]byte, 0, len(keyStrs))
+	for _, keyStr := range keyStrs {
+		pubKey, err := hex.DecodeString(keyStr)
+		if err != nil {
+			outputError(cmd.Use, fmt.Errorf("wrong public key %s", keyStr))
+			return
+		}
+		if _, err := secp256k1.ParsePubKey(pubKey); err != nil {
+			outputError(cmd.Use, fmt.Errorf("wrong public key %s", keyStr))
+			return
+		}
+		pubKeys = append(pubKeys, pubKey)
+	}
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i], pubKeys[j]) < 0
+	})
+	for i := 1; i < len(pubKeys); i++ {
+		if bytes.Equal(pubKeys[i-1], pubKeys[i]) {
+			outputError(cmd.Use, errors.New("repeated public key"))
+			return
+		}
+	}
+	address, err := ut.GenerateMultiSigAddress(Net, uint8(sigType), uint32(m), pubKeys)
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	account := &multiSigAccount{
+		Address: address,
+		Type:    sigType,
+		M:       uint32(m),
+		PubKeys: make([]string, len(pubKeys)),
+	}
+	for i, pubKey := range pubKeys {
+		account.PubKeys[i] = hex.EncodeToString(pubKey)
+	}
+	accountBytes, _ := json.Marshal(account)
+	if err := os.MkdirAll(getMultiSigDir(), 0700); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if err := ioutil.WriteFile(getMultiSigJsonPath(address), accountBytes, 0644); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	output(string(accountBytes))
+}
+
+var CreateMultiSigTransactionCmd = &cobra.Command{
+	Use:     "CreateMultiSigTransaction {from} {to} {contract} {amount} {note} {file} {nonce}; Create a transaction of a multi-signature address and write it to the file for the signers;",
+	Aliases: []string{"createmultisigtransaction", "cmt", "CMT"},
+	Short:   "CreateMultiSigTransaction {from} {to} {contract} {amount} {note} {file} {nonce}; Create a transaction of a multi-signature address and write it to the file for the signers;",
+	Example: `
+	CreateMultiSigTransaction 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 3ajKPvYpncZ8YtmCXogJFkKSQJb2FeXYceBf UWD 10 "transaction note" tx.json
+		OR
+	CreateMultiSigTransaction 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 3ajKPvYpncZ8YtmCXogJFkKSQJb2FeXYceBf UWD 10 "transaction note" tx.json 1
+	`,
+	Args: cobra.MinimumNArgs(6),
+	Run:  CreateMultiSigTransaction,
+}
+
+func CreateMultiSigTransaction(cmd *cobra.Command, args []string) {
+	account, err := readMultiSigAccount(args[0])
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	params := []string{args[0], args[1], args[2], args[3], args[4], ""}
+	if len(args) > 6 {
+		params = append(params, args[6])
+	}
+	tx, err := parseParams(params)
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if tx.TxHead.Nonce == 0 {
+		resp, err := GetAccountByRpc(tx.From().String())
+		if err != nil {
+			outputError(cmd.Use, err)
+			return
+		}
+		if resp.Code != 0 {
+			outputRespError(cmd.Use, resp)
+			return
+		}
+		var rpcAccount *rpctypes.Account
+		if err := json.Unmarshal(resp.Result, &rpcAccount); err != nil {
+			outputError(cmd.Use, err)
+			return
+		}
+		tx.TxHead.Nonce = rpcAccount.Nonce + 1
+	}
+	tx.SetValidUntilHeight(Cfg.ValidUntil)
+	if err := tx.SetHash(); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	script := &types.MultiSigScript{
+		Type:       account.Type,
+		M:          account.M,
+		PubKeys:    make([][]byte, len(account.PubKeys)),
+		Signers:    []uint32{},
+		Signatures: [][]byte{},
+	}
+	for i, pubKey := range account.PubKeys {
+		script.PubKeys[i], _ = hex.DecodeString(pubKey)
+	}
+	tx.TxHead.SignScript = &types.SignScript{MultiSig: []*types.MultiSigScript{script}}
+	rpcTx, err := types.TranslateTxToRpcTx(tx)
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	mTx := &multiSigTx{Transaction: rpcTx}
+	if err := writeMultiSigTx(args[5], mTx); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	bytes, _ := json.Marshal(mTx)
+	output(string(bytes))
+}
+
+var SignMultiSigTransactionCmd = &cobra.Command{
+	Use:     "SignMultiSigTransaction {file} {address} {password}; Sign the multi-signature transaction in the file, a schnorr signer runs it twice, once to add the nonce and once to sign after enough nonces are added;",
+	Aliases: []string{"signmultisigtransaction", "smt", "SMT"},
+	Short:   "SignMultiSigTransaction {file} {address} {password}; Sign the multi-signature transaction in the file, a schnorr signer runs it twice, once to add the nonce and once to sign after enough nonces are added;",
+	Example: `
+	SignMultiSigTransaction tx.json 3ajKPvYpncZ8YtmCXogJFkKSQJb2FeXYceBf
+		OR
+	SignMultiSigTransaction tx.json 3ajKPvYpncZ8YtmCXogJFkKSQJb2FeXYceBf 123456
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  SignMultiSigTransaction,
+}
+
+func SignMultiSigTransaction(cmd *cobra.Command, args []string) {
+	mTx, tx, err := readMultiSigTx(args[0])
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	priv, err := readMultiSigSigner(args[1], args[2:])
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	script := tx.GetSignScript().MultiSig[0]
+	index := script.IndexOf(priv.PubKey().SerializeCompressed())
+	if index < 0 {
+		outputError(cmd.Use, fmt.Errorf("%s is not a signer of %s", args[1], tx.From().String()))
+		return
+	}
+	switch script.Type {
+	case types.MultiSig_Script:
+		signature, err := priv.Sign(tx.Hash().Bytes())
+		if err != nil {
+			outputError(cmd.Use, errors.New("sign failed"))
+			return
+		}
+		script.AddSignature(uint32(index), signature.Serialize())
+	case types.MultiSig_Schnorr:
+		if err := signMultiSigSchnorr(mTx, tx, priv, uint32(index)); err != nil {
+			outputError(cmd.Use, err)
+			return
+		}
+	}
+	if mTx.Transaction, err = types.TranslateTxToRpcTx(tx); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if err := writeMultiSigTx(args[0], mTx); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	bytes, _ := json.Marshal(mTx)
+	output(string(bytes))
+}
+
+// Add the nonce of the signer if it has not been added, otherwise add
+// the partial signature. The signers are the signers that added the
+// nonces, no nonce can be added after the first partial signature.
+func signMultiSigSchnorr(mTx *multiSigTx, tx *types.Transaction, priv *secp256k1.PrivateKey, index uint32) error {
+	if _, ok := mTx.Partials[index]; ok {
+		return errors.New("already signed")
+	}
+	curve := secp256k1.S256()
+	nonce, ok := mTx.Nonces[index]
+	if !ok {
+		if len(mTx.Partials) != 0 {
+			return errors.New("signing has started, no nonce can be added")
+		}
+		salt := make([]byte, 32)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+		_, pubNonce, err := schnorr.GenerateNoncePair(curve, tx.Hash().Bytes(), priv, salt, schnorr.BlakeVersionStringRFC6979)
+		if err != nil {
+			return err
+		}
+		if mTx.Nonces == nil {
+			mTx.Nonces = make(map[uint32]*schnorrNonce)
+		}
+		mTx.Nonces[index] = &schnorrNonce{
+			Salt:     hex.EncodeToString(salt),
+			PubNonce: hex.EncodeToString(pubNonce.SerializeCompressed()),
+		}
+		return nil
+	}
+
+	script := tx.GetSignScript().MultiSig[0]
+	if len(mTx.Nonces) < int(script.M) {
+		return fmt.Errorf("%d nonces are required before signing, %d added", script.M, len(mTx.Nonces))
+	}
+	salt, err := hex.DecodeString(nonce.Salt)
+	if err != nil {
+		return err
+	}
+	privNonce, pubNonce, err := schnorr.GenerateNoncePair(curve, tx.Hash().Bytes(), priv, salt, schnorr.BlakeVersionStringRFC6979)
+	if err != nil {
+		return err
+	}
+	if hex.EncodeToString(pubNonce.SerializeCompressed()) != nonce.PubNonce {
+		return errors.New("the nonce is not added by this signer")
+	}
+	others := make([]*secp256k1.PublicKey, 0)
+	for i, other := range mTx.Nonces {
+		if i == index {
+			continue
+		}
+		if int(i) >= len(script.PubKeys) {
+			return fmt.Errorf("signer %d does not exist", i)
+		}
+		otherBytes, err := hex.DecodeString(other.PubNonce)
+		if err != nil {
+			return err
+		}
+		otherNonce, err := secp256k1.ParsePubKey(otherBytes)
+		if err != nil {
+			return err
+		}
+		others = append(others, otherNonce)
+	}
+	// A nonce signs once, signing again with other nonces would
+	// reveal the private key
+	if err := useNonce(nonce.Salt); err != nil {
+		return err
+	}
+	musigPriv := schnorr.MuSigPrivkey(script.PubKeys, priv, script.PubKeys[index])
+	partial, err := schnorr.PartialSign(curve, tx.Hash().Bytes(), musigPriv, privNonce, schnorr.CombinePubkeys(others))
+	if err != nil {
+		return err
+	}
+	if mTx.Partials == nil {
+		mTx.Partials = make(map[uint32]string)
+	}
+	mTx.Partials[index] = hex.EncodeToString(partial.Serialize())
+	return nil
+}
+
+var SendMultiSigTransactionCmd = &cobra.Command{
+	Use:     "SendMultiSigTransaction {file}; Send the multi-signature transaction in the file after it is signed;",
+	Aliases: []string{"sendmultisigtransaction", "smst", "SMST"},
+	Short:   "SendMultiSigTransaction {file}; Send the multi-signature transaction in the file after it is signed;",
+	Example: `
+	SendMultiSigTransaction tx.json
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  SendMultiSigTransaction,
+}
+
+func SendMultiSigTransaction(cmd *cobra.Command, args []string) {
+	mTx, tx, err := readMultiSigTx(args[0])
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	script := tx.GetSignScript().MultiSig[0]
+	if script.Type == types.MultiSig_Schnorr {
+		if err := combineMultiSigSchnorr(mTx, script); err != nil {
+			outputError(cmd.Use, err)
+			return
+		}
+	}
+	if err := types.VerifyMultiSig(Net, tx.Hash(), tx.From(), script); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	rs, err := sendTx(cmd, tx)
+	if err != nil {
+		outputError(cmd.Use, err)
+	} else if rs.Code != 0 {
+		outputRespError(cmd.Use, rs)
+	} else {
+		fmt.Println()
+		fmt.Println(string(rs.Result))
+	}
+}
+
+// Combine the partial signatures, every signer that added a nonce
+// must have signed
+func combineMultiSigSchnorr(mTx *multiSigTx, script *types.MultiSigScript) error {
+	if len(mTx.Partials) != len(mTx.Nonces) {
+		return fmt.Errorf("%d of %d signers have signed", len(mTx.Partials), len(mTx.Nonces))
+	}
+	signers := make([]uint32, 0, len(mTx.Partials))
+	for index := range mTx.Partials {
+		if _, ok := mTx.Nonces[index]; !ok {
+			return fmt.Errorf("signer %d has no nonce", index)
+		}
+		signers = append(signers, index)
+	}
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i] < signers[j]
+	})
+	partials := make([]*schnorr.Signature, 0, len(signers))
+	for _, index := range signers {
+		sigBytes, err := hex.DecodeString(mTx.Partials[index])
+		if err != nil {
+			return err
+		}
+		partial, err := schnorr.ParseSignature(sigBytes)
+		if err != nil {
+			return err
+		}
+		partials = append(partials, partial)
+	}
+	signature, err := schnorr.CombineSigs(secp256k1.S256(), partials)
+	if err != nil {
+		return err
+	}
+	script.Signers = signers
+	script.Signatures = [][]byte{signature.Serialize()}
+	return nil
+}
+
+func readMultiSigSigner(address string, args []string) (*secp256k1.PrivateKey, error) {
+	var passwd []byte
+	var err error
+	if len(args) > 0 {
+		passwd = []byte(args[0])
+	} else {
+		fmt.Println("please input password：")
+		passwd, err = readPassWd()
+		if err != nil {
+			return nil, fmt.Errorf("read password failed! %s", err.Error())
+		}
+	}
+	privKey, err := ReadAddrPrivate(getAddJsonPath(address), passwd)
+	if err != nil {
+		return nil, fmt.Errorf("wrong password")
+	}
+	priv, err := secp256k1.ParseStringToPrivate(privKey.Private)
+	if err != nil {
+		return nil, errors.New("[key] wrong")
+	}
+	return priv, nil
+}
+
+func readMultiSigAccount(address string) (*multiSigAccount, error) {
+	bytes, err := ioutil.ReadFile(getMultiSigJsonPath(address))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a multi-signature address of the wallet, create it first", address)
+	}
+	var account *multiSigAccount
+	if err := json.Unmarshal(bytes, &account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// Read the transaction of the file, the hash is computed again so
+// that the signers never sign a hash other than the transaction's
+func readMultiSigTx(file string) (*multiSigTx, *types.Transaction, error) {
+	bytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	var mTx *multiSigTx
+	if err := json.Unmarshal(bytes, &mTx); err != nil {
+		return nil, nil, err
+	}
+	if mTx.Transaction == nil || mTx.Transaction.TxHead == nil {
+		return nil, nil, errors.New("no transaction")
+	}
+	tx, err := types.TranslateRpcTxToTx(mTx.Transaction)
+	if err != nil {
+		return nil, nil, err
+	}
+	signScript := tx.GetSignScript()
+	if !signScript.IsMultiSig() {
+		return nil, nil, errors.New("not a multi-signature transaction")
+	}
+	txHash := tx.Hash()
+	if err := tx.SetHash(); err != nil {
+		return nil, nil, err
+	}
+	if !tx.Hash().IsEqual(txHash) {
+		return nil, nil, types.ErrTxHash
+	}
+	tx.TxHead.SignScript = signScript
+	address, err := signScript.MultiSig[0].Address(Net)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !address.IsEqual(tx.From()) {
+		return nil, nil, types.ErrSigner
+	}
+	return mTx, tx, nil
+}
+
+func writeMultiSigTx(file string, mTx *multiSigTx) error {
+	bytes, err := json.MarshalIndent(mTx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, bytes, 0644)
+}
+
+// Record the salt of a nonce before it signs, a used nonce is refused
+func useNonce(salt string) error {
+	path := getMultiSigDir() + "/nonces"
+	used, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, line := range strings.Split(string(used), "\n") {
+		if line == salt {
+			return errors.New("the nonce has been used")
+		}
+	}
+	if err := os.MkdirAll(getMultiSigDir(), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(salt + "\n")
+	return err
+}
+
+func getMultiSigDir() string {
+	return Cfg.KeyStoreDir + "/multisig"
+}
+
+func getMultiSigJsonPath(addr string) string {
+	return getMultiSigDir() + "/" + addr + ".json"
+}
@@ -0,0 +1,343 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/rpc"
+	"github.com/uworldao/UWORLD/ut/transaction"
+	"strconv"
+	"time"
+)
+
+func init() {
+	nftCmds := []*cobra.Command{
+		CreateNFTCollectionCmd,
+		MintNFTCmd,
+		TransferNFTCmd,
+		ApproveNFTCmd,
+		BurnNFTCmd,
+		GetNFTCollectionCmd,
+		GetNFTsByCollectionCmd,
+		GetNFTsByOwnerCmd,
+	}
+	RootCmd.AddCommand(nftCmds...)
+	RootSubCmdGroups["nft"] = nftCmds
+}
+
+var CreateNFTCollectionCmd = &cobra.Command{
+	Use:     "CreateNFTCollection {from} {name} {symbol} {password} {nonce}; Create a collection of non-fungible tokens, only the creator can mint;",
+	Aliases: []string{"createnftcollection", "cnc", "CNC"},
+	Short:   "CreateNFTCollection {from} {name} {symbol} {password} {nonce}; Create a collection of non-fungible tokens;",
+	Example: `
+	CreateNFTCollection UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw "Test collection" TC 123456
+		OR
+	CreateNFTCollection UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw "Test collection" TC 123456 1
+	`,
+	Args: cobra.MinimumNArgs(3),
+	Run:  CreateNFTCollection,
+}
+
+func CreateNFTCollection(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 3, parseCNCParams)
+}
+
+func parseCNCParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	name := args[1]
+	symbol := args[2]
+	if len(args) > 4 {
+		nonce, err = strconv.ParseUint(args[4], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	tx, err := transaction.NewNFTCollection(Net, from, name, symbol, nonce, "")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("nft collection:", tx.GetTxBody().GetContract().String())
+	return tx, nil
+}
+
+var MintNFTCmd = &cobra.Command{
+	Use:     "MintNFT {from} {collection} {to} {uri} {metadatahash} {password} {nonce}; Mint a token of the collection to the receiver, the uri or the metadata hash can be empty but not both;",
+	Aliases: []string{"mintnft", "mnft", "MNFT"},
+	Short:   "MintNFT {from} {collection} {to} {uri} {metadatahash} {password} {nonce}; Mint a token of the collection;",
+	Example: `
+	MintNFT UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw ipfs://QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG "" 123456
+		OR
+	MintNFT UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw ipfs://QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG "" 123456 1
+	`,
+	Args: cobra.MinimumNArgs(5),
+	Run:  MintNFT,
+}
+
+func MintNFT(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 5, parseMNFTParams)
+}
+
+func parseMNFTParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	collection := args[1]
+	to := args[2]
+	uri := args[3]
+	var metadataHash hasharry.Hash
+	if args[4] != "" {
+		metadataHash, err = hasharry.StringToHash(args[4])
+		if err != nil {
+			return nil, errors.New("wrong metadata hash")
+		}
+	}
+	if len(args) > 6 {
+		nonce, err = strconv.ParseUint(args[6], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewNFTMint(from, collection, to, uri, metadataHash, nonce, "")
+}
+
+var TransferNFTCmd = &cobra.Command{
+	Use:     "TransferNFT {from} {collection} {id} {to} {password} {nonce}; Transfer a token owned by or approved to the sender;",
+	Aliases: []string{"transfernft", "tnft", "TNFT"},
+	Short:   "TransferNFT {from} {collection} {id} {to} {password} {nonce}; Transfer a token;",
+	Example: `
+	TransferNFT UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 1 UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 123456
+		OR
+	TransferNFT UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 1 UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 123456 1
+	`,
+	Args: cobra.MinimumNArgs(4),
+	Run:  TransferNFT,
+}
+
+func TransferNFT(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 4, parseTNFTParams)
+}
+
+func parseTNFTParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	collection := args[1]
+	id, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return nil, errors.New("wrong id")
+	}
+	to := args[3]
+	if len(args) > 5 {
+		nonce, err = strconv.ParseUint(args[5], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewNFTTransfer(from, collection, id, to, nonce, "")
+}
+
+var ApproveNFTCmd = &cobra.Command{
+	Use:     "ApproveNFT {from} {collection} {id} {spender} {password} {nonce}; Allow the spender to transfer or burn a token of the sender, an empty spender removes the approval;",
+	Aliases: []string{"approvenft", "anft", "ANFT"},
+	Short:   "ApproveNFT {from} {collection} {id} {spender} {password} {nonce}; Allow the spender to transfer a token of the sender;",
+	Example: `
+	ApproveNFT UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 1 UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 123456
+		OR
+	ApproveNFT UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 1 UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 123456 1
+	`,
+	Args: cobra.MinimumNArgs(4),
+	Run:  ApproveNFT,
+}
+
+func ApproveNFT(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 4, parseANFTParams)
+}
+
+func parseANFTParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	collection := args[1]
+	id, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return nil, errors.New("wrong id")
+	}
+	spender := args[3]
+	if len(args) > 5 {
+		nonce, err = strconv.ParseUint(args[5], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewNFTApprove(from, collection, id, spender, nonce, "")
+}
+
+var BurnNFTCmd = &cobra.Command{
+	Use:     "BurnNFT {from} {collection} {id} {password} {nonce}; Burn a token owned by or approved to the sender;",
+	Aliases: []string{"burnnft", "bnft", "BNFT"},
+	Short:   "BurnNFT {from} {collection} {id} {password} {nonce}; Burn a token;",
+	Example: `
+	BurnNFT UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 1 123456
+		OR
+	BurnNFT UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 1 123456 1
+	`,
+	Args: cobra.MinimumNArgs(3),
+	Run:  BurnNFT,
+}
+
+func BurnNFT(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 3, parseBNFTParams)
+}
+
+func parseBNFTParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	collection := args[1]
+	id, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return nil, errors.New("wrong id")
+	}
+	if len(args) > 4 {
+		nonce, err = strconv.ParseUint(args[4], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewNFTBurn(from, collection, id, nonce, "")
+}
+
+var GetNFTCollectionCmd = &cobra.Command{
+	Use:     "GetNFTCollection {collection}; Get the name, symbol, creator and supply of a collection;",
+	Aliases: []string{"getnftcollection", "gnc", "GNC"},
+	Short:   "GetNFTCollection {collection}; Get a collection of non-fungible tokens;",
+	Example: `
+	GetNFTCollection UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  GetNFTCollection,
+}
+
+func GetNFTCollection(cmd *cobra.Command, args []string) {
+	client, err := NewRpcClient()
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetNFTCollection(ctx, &rpc.Address{Address: args[0]})
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
+var GetNFTsByCollectionCmd = &cobra.Command{
+	Use:     "GetNFTsByCollection {collection} {start} {count}; Get the tokens of a collection whose ids are greater than start, at most 100 at a time;",
+	Aliases: []string{"getnftsbycollection", "gnbc", "GNBC"},
+	Short:   "GetNFTsByCollection {collection} {start} {count}; Get the tokens of a collection;",
+	Example: `
+	GetNFTsByCollection UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL
+		OR
+	GetNFTsByCollection UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 100 100
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  GetNFTsByCollection,
+}
+
+func GetNFTsByCollection(cmd *cobra.Command, args []string) {
+	req, err := parseNFTPageParams(args)
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	client, err := NewRpcClient()
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetNFTsByCollection(ctx, req)
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
+var GetNFTsByOwnerCmd = &cobra.Command{
+	Use:     "GetNFTsByOwner {owner} {start} {count}; Get the tokens of an owner from the index start, at most 100 at a time;",
+	Aliases: []string{"getnftsbyowner", "gnbo", "GNBO"},
+	Short:   "GetNFTsByOwner {owner} {start} {count}; Get the tokens of an owner;",
+	Example: `
+	GetNFTsByOwner UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw
+		OR
+	GetNFTsByOwner UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 0 10
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  GetNFTsByOwner,
+}
+
+func GetNFTsByOwner(cmd *cobra.Command, args []string) {
+	req, err := parseNFTPageParams(args)
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	client, err := NewRpcClient()
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetNFTsByOwner(ctx, req)
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
+func parseNFTPageParams(args []string) (*rpc.AddressPage, error) {
+	var err error
+	req := &rpc.AddressPage{Address: args[0]}
+	if len(args) > 1 {
+		req.Start, err = strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong start")
+		}
+	}
+	if len(args) > 2 {
+		req.Count, err = strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong count")
+		}
+	}
+	return req, nil
+}
@@ -13,6 +13,7 @@ func init() {
 		GetTxPoolTxs,
 		GetPeersCmd,
 		NodeInfoCmd,
+		SealBlockCmd,
 	}
 	RootCmd.AddCommand(nodeCmds...)
 	RootSubCmdGroups["node"] = nodeCmds
@@ -151,3 +152,36 @@ func NodeInfo(cmd *cobra.Command, args []string) {
 	}
 	outputRespError(cmd.Use, resp)
 }
+
+var SealBlockCmd = &cobra.Command{
+	Use:     "SealBlock",
+	Short:   "SealBlock; Seal the next block of a devseal node;",
+	Aliases: []string{"sealblock", "sb", "SB"},
+	Example: `
+	SealBlock
+	`,
+	Args: cobra.MinimumNArgs(0),
+	Run:  SealBlock,
+}
+
+func SealBlock(cmd *cobra.Command, args []string) {
+	client, err := NewRpcClient()
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+	resp, err := client.Gc.SealBlock(ctx, &rpc.Null{})
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
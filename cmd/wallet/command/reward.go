@@ -0,0 +1,136 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/rpc"
+	"github.com/uworldao/UWORLD/ut/transaction"
+	"strconv"
+	"time"
+)
+
+func init() {
+	rewardCmds := []*cobra.Command{
+		SetCommissionCmd,
+		GetRewardCmd,
+		ClaimRewardCmd,
+	}
+	RootCmd.AddCommand(rewardCmds...)
+	RootSubCmdGroups["reward"] = rewardCmds
+}
+
+var SetCommissionCmd = &cobra.Command{
+	Use:     "SetCommission {from} {rate} {password} {nonce}; Set the percentage of the block rewards kept by the candidate from the next term, the rest is shared by its voters;",
+	Aliases: []string{"setcommission", "scm", "SCM"},
+	Short:   "SetCommission {from} {rate} {password} {nonce}; Set the commission rate of the candidate;",
+	Example: `
+	SetCommission UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 20 123456
+		OR
+	SetCommission UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 20 123456 1
+	`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  SetCommission,
+}
+
+func SetCommission(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 2, parseSCMParams)
+}
+
+func parseSCMParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	rate, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil || rate > types.MaxCommission {
+		return nil, errors.New("wrong rate, it is a percentage")
+	}
+	if len(args) > 3 {
+		nonce, err = strconv.ParseUint(args[3], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewSetCommission(from, rate, nonce, ""), nil
+}
+
+var GetRewardCmd = &cobra.Command{
+	Use:     "GetReward {address}; Get the rewards accrued to the voter and not claimed;",
+	Aliases: []string{"getreward", "grw", "GRW"},
+	Short:   "GetReward {address}; Get the rewards of the voter;",
+	Example: `
+	GetReward UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  GetReward,
+}
+
+func GetReward(cmd *cobra.Command, args []string) {
+	resp, err := GetRewardByRpc(args[0])
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
+func GetRewardByRpc(addr string) (*rpc.Response, error) {
+	client, err := NewRpcClient()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+	return client.Gc.GetReward(ctx, &rpc.Address{Address: addr})
+}
+
+var ClaimRewardCmd = &cobra.Command{
+	Use:     "ClaimReward {from} {password} {nonce}; Claim all rewards accrued to the voter to its balance;",
+	Aliases: []string{"claimreward", "crw", "CRW"},
+	Short:   "ClaimReward {from} {password} {nonce}; Claim the rewards of the voter;",
+	Example: `
+	ClaimReward UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 123456
+		OR
+	ClaimReward UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 123456 1
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  ClaimReward,
+}
+
+func ClaimReward(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 1, parseCRWParams)
+}
+
+func parseCRWParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	resp, err := GetRewardByRpc(from)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != 0 {
+		return nil, fmt.Errorf("get the rewards failed! %s", resp.Err)
+	}
+	var reward *types.RpcReward
+	if err := json.Unmarshal(resp.Result, &reward); err != nil {
+		return nil, err
+	}
+	if reward.Reward == 0 {
+		return nil, errors.New("no reward to claim")
+	}
+	if len(args) > 2 {
+		nonce, err = strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewClaimReward(from, reward.Reward, nonce, ""), nil
+}
@@ -0,0 +1,349 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/rpc"
+	"github.com/uworldao/UWORLD/rpc/rpctypes"
+	"github.com/uworldao/UWORLD/ut/transaction"
+	"strconv"
+	"time"
+)
+
+func init() {
+	tokenCmds := []*cobra.Command{
+		CreateTokenCmd,
+		ApproveTokenCmd,
+		TransferTokenFromCmd,
+		BurnTokenCmd,
+		MintTokenCmd,
+		GetTokenCmd,
+		GetTokenAllowanceCmd,
+	}
+	RootCmd.AddCommand(tokenCmds...)
+	RootSubCmdGroups["token"] = tokenCmds
+}
+
+var CreateTokenCmd = &cobra.Command{
+	Use:     "CreateToken {from} {name} {symbol} {decimals} {amount} {receiver} {mintable} {password} {nonce}; Create a token contract, the owner can mint more tokens if it is mintable;",
+	Aliases: []string{"createtoken", "ctk", "CTK"},
+	Short:   "CreateToken {from} {name} {symbol} {decimals} {amount} {receiver} {mintable} {password} {nonce}; Create a token contract;",
+	Example: `
+	CreateToken UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw "Test token" TT 8 10000 UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw true 123456
+		OR
+	CreateToken UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw "Test token" TT 8 10000 UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw true 123456 1
+	`,
+	Args: cobra.MinimumNArgs(7),
+	Run:  CreateToken,
+}
+
+func CreateToken(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 7, parseCTKParams)
+}
+
+func parseCTKParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	name := args[1]
+	symbol := args[2]
+	decimals, err := strconv.ParseUint(args[3], 10, 8)
+	if err != nil {
+		return nil, errors.New("wrong decimals")
+	}
+	amountf, err := strconv.ParseFloat(args[4], 64)
+	if err != nil {
+		return nil, errors.New("wrong amount")
+	}
+	amount, _ := types.NewAmount(amountf)
+	receiver := args[5]
+	mintable, err := strconv.ParseBool(args[6])
+	if err != nil {
+		return nil, errors.New("wrong mintable, it should be true or false")
+	}
+	if len(args) > 8 {
+		nonce, err = strconv.ParseUint(args[8], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	tx, err := transaction.NewToken(Net, from, name, symbol, uint8(decimals), amount, receiver, mintable, nonce, "")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("token contract:", tx.GetTxBody().GetContract().String())
+	return tx, nil
+}
+
+var ApproveTokenCmd = &cobra.Command{
+	Use:     "ApproveToken {from} {token} {spender} {amount} {password} {nonce}; Allow the spender to transfer at most amount tokens from the sender, 0 removes the allowance;",
+	Aliases: []string{"approvetoken", "atk", "ATK"},
+	Short:   "ApproveToken {from} {token} {spender} {amount} {password} {nonce}; Allow the spender to transfer the tokens of the sender;",
+	Example: `
+	ApproveToken UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 100 123456
+		OR
+	ApproveToken UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 100 123456 1
+	`,
+	Args: cobra.MinimumNArgs(4),
+	Run:  ApproveToken,
+}
+
+func ApproveToken(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 4, parseATKParams)
+}
+
+func parseATKParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	token := args[1]
+	spender := args[2]
+	amountf, err := strconv.ParseFloat(args[3], 64)
+	if err != nil {
+		return nil, errors.New("wrong amount")
+	}
+	amount, _ := types.NewAmount(amountf)
+	if len(args) > 5 {
+		nonce, err = strconv.ParseUint(args[5], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewTokenApprove(from, token, spender, amount, nonce, "")
+}
+
+var TransferTokenFromCmd = &cobra.Command{
+	Use:     "TransferTokenFrom {from} {token} {owner} {to} {amount} {password} {nonce}; Transfer the tokens of the owner with the allowance of the sender;",
+	Aliases: []string{"transfertokenfrom", "ttf", "TTF"},
+	Short:   "TransferTokenFrom {from} {token} {owner} {to} {amount} {password} {nonce}; Transfer the tokens of the owner with the allowance of the sender;",
+	Example: `
+	TransferTokenFrom UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 10 123456
+		OR
+	TransferTokenFrom UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh 10 123456 1
+	`,
+	Args: cobra.MinimumNArgs(5),
+	Run:  TransferTokenFrom,
+}
+
+func TransferTokenFrom(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 5, parseTTFParams)
+}
+
+func parseTTFParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	token := args[1]
+	owner := args[2]
+	to := args[3]
+	amountf, err := strconv.ParseFloat(args[4], 64)
+	if err != nil {
+		return nil, errors.New("wrong amount")
+	}
+	amount, _ := types.NewAmount(amountf)
+	if len(args) > 6 {
+		nonce, err = strconv.ParseUint(args[6], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewTokenTransferFrom(from, token, owner, to, amount, nonce, "")
+}
+
+var BurnTokenCmd = &cobra.Command{
+	Use:     "BurnToken {from} {token} {amount} {password} {nonce}; Burn the tokens of the sender;",
+	Aliases: []string{"burntoken", "btk", "BTK"},
+	Short:   "BurnToken {from} {token} {amount} {password} {nonce}; Burn the tokens of the sender;",
+	Example: `
+	BurnToken UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 10 123456
+		OR
+	BurnToken UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL 10 123456 1
+	`,
+	Args: cobra.MinimumNArgs(3),
+	Run:  BurnToken,
+}
+
+func BurnToken(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 3, parseBTKParams)
+}
+
+func parseBTKParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	token := args[1]
+	amountf, err := strconv.ParseFloat(args[2], 64)
+	if err != nil {
+		return nil, errors.New("wrong amount")
+	}
+	amount, _ := types.NewAmount(amountf)
+	if len(args) > 4 {
+		nonce, err = strconv.ParseUint(args[4], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewTokenBurn(from, token, amount, nonce, "")
+}
+
+var MintTokenCmd = &cobra.Command{
+	Use:     "MintToken {from} {token} {to} {amount} {password} {nonce}; Mint tokens of a mintable token, only the owner can mint;",
+	Aliases: []string{"minttoken", "mtk", "MTK"},
+	Short:   "MintToken {from} {token} {to} {amount} {password} {nonce}; Mint tokens of a mintable token;",
+	Example: `
+	MintToken UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 1000 123456
+		OR
+	MintToken UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw 1000 123456 1
+	`,
+	Args: cobra.MinimumNArgs(4),
+	Run:  MintToken,
+}
+
+func MintToken(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 4, parseMTKParams)
+}
+
+func parseMTKParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	token := args[1]
+	to := args[2]
+	amountf, err := strconv.ParseFloat(args[3], 64)
+	if err != nil {
+		return nil, errors.New("wrong amount")
+	}
+	amount, _ := types.NewAmount(amountf)
+	if len(args) > 5 {
+		nonce, err = strconv.ParseUint(args[5], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewTokenMint(from, token, to, amount, nonce, "")
+}
+
+// Sign the token transaction built by parse with the key of args[0]
+// and send it, the password is args[passwdIndex] or read from stdin
+func sendTokenTx(cmd *cobra.Command, args []string, passwdIndex int, parse func([]string, uint64) (*types.Transaction, error)) {
+	var passwd []byte
+	var err error
+	if len(args) > passwdIndex {
+		passwd = []byte(args[passwdIndex])
+	} else {
+		fmt.Println("please input password：")
+		passwd, err = readPassWd()
+		if err != nil {
+			outputError(cmd.Use, fmt.Errorf("read password failed! %s", err.Error()))
+			return
+		}
+	}
+	privKey, err := ReadAddrPrivate(getAddJsonPath(args[0]), passwd)
+	if err != nil {
+		outputError(cmd.Use, fmt.Errorf("wrong password"))
+		return
+	}
+	resp, err := GetAccountByRpc(args[0])
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if resp.Code != 0 {
+		outputRespError(cmd.Use, resp)
+		return
+	}
+	var account *rpctypes.Account
+	if err := json.Unmarshal(resp.Result, &account); err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+
+	tx, err := parse(args, account.Nonce+1)
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+
+	if !signTx(cmd, tx, privKey.Private) {
+		outputError(cmd.Use, errors.New("signature failure"))
+		return
+	}
+
+	rs, err := sendTx(cmd, tx)
+	if err != nil {
+		outputError(cmd.Use, err)
+	} else if rs.Code != 0 {
+		outputRespError(cmd.Use, rs)
+	} else {
+		fmt.Println()
+		fmt.Println(string(rs.Result))
+	}
+}
+
+var GetTokenCmd = &cobra.Command{
+	Use:     "GetToken {token}; Get the name, symbol, decimals and total supply of a token contract;",
+	Aliases: []string{"gettoken", "gtk", "GTK"},
+	Short:   "GetToken {token}; Get a token contract;",
+	Example: `
+	GetToken UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  GetToken,
+}
+
+func GetToken(cmd *cobra.Command, args []string) {
+	client, err := NewRpcClient()
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetToken(ctx, &rpc.Address{Address: args[0]})
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
+var GetTokenAllowanceCmd = &cobra.Command{
+	Use:     "GetTokenAllowance {token} {owner} {spender}; Get the amount the spender may still transfer from the owner;",
+	Aliases: []string{"gettokenallowance", "gta", "GTA"},
+	Short:   "GetTokenAllowance {token} {owner} {spender}; Get the allowance of the spender;",
+	Example: `
+	GetTokenAllowance UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh
+	`,
+	Args: cobra.MinimumNArgs(3),
+	Run:  GetTokenAllowance,
+}
+
+func GetTokenAllowance(cmd *cobra.Command, args []string) {
+	client, err := NewRpcClient()
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetTokenAllowance(ctx, &rpc.TokenAllowance{Token: args[0], Owner: args[1], Spender: args[2]})
+	if err != nil {
+		log.Error(cmd.Use+" err: ", err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
@@ -20,8 +20,10 @@ import (
 func init() {
 	txCmds := []*cobra.Command{
 		GetTransactionCmd,
+		GetAddressTransactionsCmd,
 		SendTransactionCmd,
 		SendTransactionV2Cmd,
+		SendLockedTransactionCmd,
 	}
 	RootCmd.AddCommand(txCmds...)
 	RootSubCmdGroups["transaction"] = txCmds
@@ -292,6 +294,7 @@ func parseReceiver(toStr string) ([]map[string]uint64, error) {
 }
 
 func signTx(cmd *cobra.Command, tx *types.Transaction, key string) bool {
+	tx.SetValidUntilHeight(Cfg.ValidUntil)
 	tx.SetHash()
 	priv, err := secp256k1.ParseStringToPrivate(key)
 	if err != nil {
@@ -335,6 +338,9 @@ func sendTx(cmd *cobra.Command, tx *types.Transaction) (*rpc.Response, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
 	defer cancel()
 
+	if Cfg.Simulate {
+		return rpcClient.Gc.SimulateTransaction(ctx, re)
+	}
 	resp, err := rpcClient.Gc.SendTransaction(ctx, re)
 	if err != nil {
 		return nil, err
@@ -407,6 +413,56 @@ func GetTransactionRpc(hashStr string) (*rpc.Response, error) {
 	return resp, err
 }
 
+var GetAddressTransactionsCmd = &cobra.Command{
+	Use:     "GetAddressTransactions {address} {start} {count}; Get the transactions of an address from the newest;",
+	Aliases: []string{"getaddresstransactions", "gat", "GAT"},
+	Short:   "GetAddressTransactions {address} {start} {count}; Get the transactions of an address from the newest;",
+	Example: `
+	GetAddressTransactions UWDKoLj4mRTKr4SjyyFG4LY3ExZVSZT9dNZv
+		OR
+	GetAddressTransactions UWDKoLj4mRTKr4SjyyFG4LY3ExZVSZT9dNZv 0 20
+	`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  GetAddressTransactions,
+}
+
+func GetAddressTransactions(cmd *cobra.Command, args []string) {
+	var start, count uint64 = 0, 20
+	var err error
+	if len(args) > 1 {
+		if start, err = strconv.ParseUint(args[1], 10, 64); err != nil {
+			outputError(cmd.Use, errors.New("wrong start"))
+			return
+		}
+	}
+	if len(args) > 2 {
+		if count, err = strconv.ParseUint(args[2], 10, 64); err != nil {
+			outputError(cmd.Use, errors.New("wrong count"))
+			return
+		}
+	}
+	client, err := NewRpcClient()
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second*20)
+	defer cancel()
+
+	resp, err := client.Gc.GetAddressTransactions(ctx, &rpc.AddressPage{Address: args[0], Start: start, Count: count})
+	if err != nil {
+		outputError(cmd.Use, err)
+		return
+	}
+	if resp.Code == 0 {
+		output(string(resp.Result))
+		return
+	}
+	outputRespError(cmd.Use, resp)
+}
+
 func SendTransactionRpc(tx string) (*rpc.Response, error) {
 
 	rpcClient, err := NewRpcClient()
@@ -425,3 +481,53 @@ func SendTransactionRpc(tx string) (*rpc.Response, error) {
 	}
 	return resp, nil
 }
+
+var SendLockedTransactionCmd = &cobra.Command{
+	Use:     "SendLockedTransaction {from} {to} {contract} {amount} {start} {end} {note} {password} {nonce}; Send coins that are locked until the start height and released linearly until the end height;",
+	Aliases: []string{"sendlockedtransaction", "slt", "SLT"},
+	Short:   "SendLockedTransaction {from} {to} {contract} {amount} {start} {end} {note} {password} {nonce}; Send a locked transaction;",
+	Example: `
+	SendLockedTransaction 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ UWD 10 100000 100000 "transaction note"
+		OR
+	SendLockedTransaction 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ UWD 10 100000 200000 "transaction note" 123456
+		OR
+	SendLockedTransaction 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ 3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ UWD 10 100000 200000 "transaction note" 123456 1
+	`,
+	Args: cobra.MinimumNArgs(7),
+	Run:  SendLockedTransaction,
+}
+
+func SendLockedTransaction(cmd *cobra.Command, args []string) {
+	sendTokenTx(cmd, args, 7, parseSLTParams)
+}
+
+func parseSLTParams(args []string, nonce uint64) (*types.Transaction, error) {
+	var err error
+	from := args[0]
+	to := args[1]
+	contract := args[2]
+	fAmount, err := strconv.ParseFloat(args[3], 64)
+	if err != nil || fAmount < 0 {
+		return nil, errors.New("wrong amount")
+	}
+	amount, err := types.NewAmount(fAmount)
+	if err != nil {
+		return nil, errors.New("wrong amount")
+	}
+	start, err := strconv.ParseUint(args[4], 10, 64)
+	if err != nil {
+		return nil, errors.New("wrong start height")
+	}
+	end, err := strconv.ParseUint(args[5], 10, 64)
+	if err != nil {
+		return nil, errors.New("wrong end height")
+	}
+	note := args[6]
+	if len(args) > 8 {
+		nonce, err = strconv.ParseUint(args[8], 10, 64)
+		if err != nil {
+			return nil, errors.New("wrong nonce")
+		}
+	}
+	return transaction.NewLockedTransaction(from, to, contract, note, amount, start, end, nonce), nil
+}
@@ -7,5 +7,7 @@ type Config struct {
 	Format      bool
 	TestNet     bool
 	KeyStoreDir string
+	Simulate    bool
+	ValidUntil  uint64
 	config.RpcConfig
 }
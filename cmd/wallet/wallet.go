@@ -38,6 +38,8 @@ func bindFlags() {
 	gFlags := command.RootCmd.PersistentFlags()
 
 	gFlags.StringVarP(&preConfig.ConfigFile, "config", "c", "wallet.toml", "Wallet profile")
+	gFlags.BoolVar(&preConfig.Simulate, "simulate", false, "Simulate transactions against the head state instead of sending them")
+	gFlags.Uint64Var(&preConfig.ValidUntil, "validuntil", 0, "Height of the last block that can contain the transaction, 0 for no expiry")
 }
 
 // LoadConfig config file and flags
@@ -67,6 +69,8 @@ func LoadConfig(cmd *cobra.Command, args []string) (err error) {
 		fileCfg.RpcIp = defaultRpcIp
 	}
 
+	fileCfg.Simulate = preConfig.Simulate
+	fileCfg.ValidUntil = preConfig.ValidUntil
 	command.Cfg = fileCfg
 	if command.Cfg.TestNet {
 		command.Net = param.TestNet
@@ -30,29 +30,70 @@ var (
 	defaultExternalIp  = "0.0.0.0"
 	DefaultFallBack    = int64(-1)
 	defaultCoinHeight  = uint64(1)
+	DefaultConsensus   = "dpos"
+	// Copy of the genesis file of a custom network in the data directory
+	GenesisFile = "genesis.json"
 )
 
 // Config is the node startup parameter
 type Config struct {
-	ConfigFile  string `long:"config" description:"Start with a configuration file"`
-	HomeDir     string `long:"appdata" description:"Path to application home directory"`
-	DataDir     string `long:"data" description:"Path to application data directory"`
-	FileLogging bool   `long:"filelogging" description:"Logging switch"`
-	ExternalIp  string `long:"externalip" description:"External network IP address"`
-	Bootstrap   string `long:"bootstrap" description:"Custom bootstrap"`
-	P2pPort     string `long:"p2pport" description:"Add an interface/port to listen for connections"`
-	RpcPort     string `long:"rpcport" description:"Add an interface/port to listen for RPC connections"`
-	HttpPort    string `long:"httpport" description:"Add an interface/port to listen for HTTP connections"`
-	RpcTLS      bool   `long:"rpctls" description:"Open TLS for the RPC server -- NOTE: This is only allowed if the RPC server is bound to localhost"`
-	RpcCert     string `long:"rpccert" description:"File containing the certificate file"`
-	RpcKey      string `long:"rpckey" description:"File containing the certificate key"`
-	RpcPass     string `long:"rpcpass" description:"Password for RPC connections"`
-	TestNet     bool   `long:"testnet" description:"Use the test network"`
-	KeyFile     string `long:"keyfile" description:"If you participate in mining, you need to configure the mining address key file"`
-	KeyPass     string `long:"keypass" description:"The decryption password for key file"`
-	FallBackTo  int64  `long:"fallbackto" description:"Force back to a height"`
-	Version     bool   `long:"version" description:"View Version number"`
+	ConfigFile  string          `long:"config" description:"Start with a configuration file"`
+	HomeDir     string          `long:"appdata" description:"Path to application home directory"`
+	DataDir     string          `long:"data" description:"Path to application data directory"`
+	FileLogging bool            `long:"filelogging" description:"Logging switch"`
+	ExternalIp  string          `long:"externalip" description:"External network IP address"`
+	Bootstrap   string          `long:"bootstrap" description:"Custom bootstrap"`
+	P2pPort     string          `long:"p2pport" description:"Add an interface/port to listen for connections"`
+	RpcPort     string          `long:"rpcport" description:"Add an interface/port to listen for RPC connections"`
+	HttpPort    string          `long:"httpport" description:"Add an interface/port to listen for HTTP connections"`
+	RpcTLS      bool            `long:"rpctls" description:"Open TLS for the RPC server -- NOTE: This is only allowed if the RPC server is bound to localhost"`
+	RpcCert     string          `long:"rpccert" description:"File containing the certificate file"`
+	RpcKey      string          `long:"rpckey" description:"File containing the certificate key"`
+	RpcPass     string          `long:"rpcpass" description:"Password for RPC connections"`
+	TestNet     bool            `long:"testnet" description:"Use the test network"`
+	KeyFile     string          `long:"keyfile" description:"If you participate in mining, you need to configure the mining address key file"`
+	KeyPass     string          `long:"keypass" description:"The decryption password for key file"`
+	FallBackTo  int64           `long:"fallbackto" description:"Force back to a height"`
+	AddrIndex   bool            `long:"addrindex" description:"Maintain the address transaction history index"`
+	Genesis     string          `long:"genesis" description:"Genesis file of a custom network, json or toml"`
+	Consensus   string          `long:"consensus" description:"Consensus of the node, dpos or devseal for a development network"`
+	Authority   string          `long:"authority" description:"Address sealing the blocks of devseal, the address of the key file by default"`
+	Version     bool            `long:"version" description:"View Version number"`
+	Init        InitCommand     `command:"init" description:"Write the genesis block of the network given by --genesis"`
+	Export      ExportCommand   `command:"export" description:"Export blocks to a compressed archive file"`
+	Import      ImportCommand   `command:"import" description:"Import blocks from an archive file"`
+	Snapshot    SnapshotCommand `command:"snapshot" description:"Export the state of the confirmed height to a snapshot file"`
+	Restore     RestoreCommand  `command:"restore" description:"Restore an empty chain from a snapshot file"`
+	Reindex     ReindexCommand  `command:"reindex" description:"Rebuild the state by executing all blocks again"`
 	NodePrivate *NodePrivate
+
+	// Name of the subcommand to run instead of the node
+	Command string
+}
+
+type InitCommand struct{}
+
+type ExportCommand struct {
+	From uint64 `long:"from" description:"First block height to export"`
+	To   uint64 `long:"to" description:"Last block height to export, the last height of the chain if it is 0"`
+	Out  string `long:"out" description:"Archive file to write, an existing archive is continued" required:"true"`
+}
+
+type ImportCommand struct {
+	In string `long:"in" description:"Archive file to import, blocks already in the chain are skipped" required:"true"`
+}
+
+type SnapshotCommand struct {
+	Out string `long:"out" description:"Snapshot file to write" required:"true"`
+}
+
+type RestoreCommand struct {
+	In         string `long:"in" description:"Snapshot file to restore, the chain must only have the genesis block" required:"true"`
+	Checkpoint string `long:"checkpoint" description:"Hash of the header after the snapshot height from a trusted source, it is logged by snapshot" required:"true"`
+}
+
+type ReindexCommand struct {
+	Verify bool `long:"verify" description:"Only check the roots of every block, the state is not changed"`
 }
 
 // LoadConfig load the parse node startup parameter
@@ -63,6 +104,7 @@ func LoadConfig() (*Config, error) {
 		RpcPort:    DefaultRpcPort,
 		HttpPort:   DefaultHttpPort,
 		FallBackTo: DefaultFallBack,
+		Consensus:  DefaultConsensus,
 	}
 	appName := filepath.Base(os.Args[0])
 	appName = strings.TrimSuffix(appName, filepath.Ext(appName))
@@ -75,6 +117,9 @@ func LoadConfig() (*Config, error) {
 			return nil, err
 		}
 	}
+	if preParser.Active != nil {
+		cfg.Command = preParser.Active.Name
+	}
 
 	if cfg.ConfigFile != "" {
 		_, err = toml.DecodeFile(cfg.ConfigFile, cfg)
@@ -118,8 +163,9 @@ func LoadConfig() (*Config, error) {
 		param.Net = param.TestNet
 	}
 
-	// p2p same network label, the label is different and cannot communicate
-	param.UniqueNetWork = param.Net + param.UniqueNetWork
+	if cfg.Consensus == "" {
+		cfg.Consensus = DefaultConsensus
+	}
 
 	if !utils.IsExist(cfg.HomeDir) {
 		if err := os.Mkdir(cfg.HomeDir, os.ModePerm); err != nil {
@@ -135,6 +181,15 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// A custom network is described by a genesis file. The init command
+	// keeps a copy in the data directory, so it is not needed again.
+	if err := loadGenesis(cfg); err != nil {
+		return nil, err
+	}
+
+	// p2p same network label, the label is different and cannot communicate
+	param.UniqueNetWork = param.Net + param.UniqueNetWork
+
 	// Each node requires a secp256k1 private key, which is used as the p2p id
 	// generation and signature of the node that generates the block.
 	// If this parameter is not configured in the startup parameter,
@@ -190,8 +245,42 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// Load the genesis file of the network, a genesis file that differs
+// from the copy in the data directory is refused. Not all parameters
+// are in the genesis block, so it can not be found by the chain.
+func loadGenesis(cfg *Config) error {
+	var genesis, local *param.Genesis
+	var err error
+	localFile := cfg.DataDir + "/" + GenesisFile
+	if utils.IsExist(localFile) {
+		if local, err = param.LoadGenesis(localFile); err != nil {
+			return err
+		}
+	}
+	if cfg.Genesis != "" {
+		if genesis, err = param.LoadGenesis(cfg.Genesis); err != nil {
+			return err
+		}
+		if local != nil {
+			localBytes, _ := json.Marshal(local)
+			bytes, _ := json.Marshal(genesis)
+			if string(localBytes) != string(bytes) {
+				return fmt.Errorf("genesis file %s is different from the genesis of the data directory %s", cfg.Genesis, localFile)
+			}
+		}
+	} else if local != nil {
+		genesis = local
+	} else if cfg.Command == "init" {
+		return errors.New("init requires the genesis file, use --genesis")
+	} else {
+		return nil
+	}
+	return param.SetGenesis(genesis)
+}
+
 func newConfigParser(cfg *Config, options flags.Options) *flags.Parser {
 	parser := flags.NewParser(cfg, options)
+	parser.SubcommandsOptional = true
 	return parser
 }
 
@@ -45,8 +45,14 @@ type IDPos interface {
 	// Check whether a block
 	CheckWinner(chain IChain, header *types.Header) error
 
+	// Percentage of the block rewards of the term kept by the winner
+	GetCommission(address hasharry.Address, term uint64) uint64
+
+	// Get the rewards accrued to the voter and not claimed
+	GetReward(address hasharry.Address) uint64
+
 	// Update dpos status
-	UpdateConsensus(block *types.Block)
+	UpdateConsensus(chain IChain, block *types.Block)
 }
 
 // consensus verify
@@ -55,13 +61,23 @@ type IConsensusVerify interface {
 
 	VerifySeal(chain IChain, header *types.Header, parents *types.Header) error
 
-	VerifyTx(tx types.ITransaction) error
+	// Verify the signature and the signer of a block that is not applied
+	// yet, the consensus state is not changed
+	VerifySigner(header *types.Header) error
+
+	VerifyTx(tx types.ITransaction, height uint64) error
 }
 
 // DPos trie
 type IDPosTrie interface {
 	// Initialize dpos trie
 	InitTrie(consensusRoot hasharry.Hash) error
+	// Walk the stored nodes of the dpos trie
+	DumpTrie(consensusRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error
+	// Read a stored node of the dpos trie
+	TrieNode(hash hasharry.Hash) ([]byte, error)
+	// Store the dpos trie with the given nodes
+	SyncTrie(consensusRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error
 
 	// Commit dpos trie
 	Commit() (hasharry.Hash, error)
@@ -93,6 +109,12 @@ type IChain interface {
 	// Get the number of votes cast by the address
 	GetAddressVote(address hasharry.Address) uint64
 
+	// Get the bond locked by the candidate
+	GetAddressBond(address hasharry.Address) uint64
+
+	// Get all coins of the address, whether confirmed or not
+	GetAddressHolding(address hasharry.Address) uint64
+
 	// Get the hash of the last block of the previous cycle
 	GetTermLastHash(term uint64) (hasharry.Hash, error)
 
@@ -105,3 +127,9 @@ type ISign interface {
 	// Sign the hash
 	SignHash(hash hasharry.Hash) (*types.SignScript, error)
 }
+
+// Consensus that seals blocks on request
+type ISealer interface {
+	// Seal the next block even if there are no transactions
+	Seal() error
+}
@@ -0,0 +1,179 @@
+package devseal
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/consensus"
+	"github.com/uworldao/UWORLD/consensus/dpos"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+	"sync"
+	"time"
+)
+
+const (
+	// Name of the consensus in the configuration
+	Name = "devseal"
+
+	// Seconds the time of a block may be ahead of the local clock
+	maxClockDrift = 15
+)
+
+// Transactions waiting to be sealed
+type ITxPool interface {
+	PreparedLen() int
+}
+
+// DevSeal is a consensus for development networks. A single authority
+// seals a block as soon as the pool has a transaction, or on demand.
+// Candidates and votes are kept by the dpos storage, so the consensus
+// root is maintained as usual, but there are no elections. The authority
+// is the only winner, it is not jailed and keeps the whole block reward.
+type DevSeal struct {
+	*dpos.DPos
+	signer    hasharry.Address
+	authority hasharry.Address
+	txPool    ITxPool
+	mutex     sync.Mutex
+	// A block is requested without transactions
+	requested bool
+	// The last signed block, it is not signed again until
+	// it is inserted or a block interval has passed
+	sealedHeight uint64
+	sealedTime   uint64
+}
+
+func NewDevSeal(DataDir string, signer, authority hasharry.Address, sign consensus.ISign) (*DevSeal, error) {
+	d, err := dpos.NewDPos(DataDir, signer, sign)
+	if err != nil {
+		return nil, err
+	}
+	return &DevSeal{
+		DPos:      d,
+		signer:    signer,
+		authority: authority,
+	}, nil
+}
+
+// Set the pool whose transactions trigger the sealing
+func (d *DevSeal) SetTxPool(txPool ITxPool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.txPool = txPool
+}
+
+// Seal the next block even if the pool is empty
+func (d *DevSeal) Seal() error {
+	if !d.signer.IsEqual(d.authority) {
+		return fmt.Errorf("the node is not the authority %s", d.authority.String())
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.requested = true
+	return nil
+}
+
+func (d *DevSeal) Sign(block *types.Block) error {
+	if err := d.DPos.Sign(block); err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.requested = false
+	d.sealedHeight = block.Height
+	d.sealedTime = uint64(time.Now().Unix())
+	return nil
+}
+
+// Check whether the authority has a block to seal
+func (d *DevSeal) CheckWinner(chain consensus.IChain, header *types.Header) error {
+	if !d.signer.IsEqual(d.authority) {
+		return errors.New("it's not the authority")
+	}
+	d.mutex.Lock()
+	requested, txPool := d.requested, d.txPool
+	sealed := header.Height <= d.sealedHeight && uint64(time.Now().Unix()) < d.sealedTime+param.BlockInterval
+	d.mutex.Unlock()
+
+	if sealed {
+		return errors.New("wait for the last block to be inserted")
+	}
+	if requested {
+		return nil
+	}
+	if txPool != nil && txPool.PreparedLen() != 0 {
+		return nil
+	}
+	return errors.New("nothing to seal")
+}
+
+// Blocks follow each other without intervals
+func (d *DevSeal) VerifyHeader(header, parent *types.Header) error {
+	if header.Time > uint64(time.Now().Unix())+maxClockDrift {
+		return errors.New("block in the future")
+	}
+	if header.Time < parent.Time {
+		return errors.New("invalid timestamp")
+	}
+	if header.Term != header.Time/param.TermInterval {
+		return errors.New("wrong term")
+	}
+	if header.SignScript == nil {
+		return errors.New("no signature")
+	}
+	return nil
+}
+
+// Verify the signature of the authority. The authority is the only
+// signer, so the parent of a valid block is confirmed.
+func (d *DevSeal) VerifySeal(chain consensus.IChain, header *types.Header, parent *types.Header) error {
+	if header.Height == 0 {
+		return errors.New("unknown block")
+	}
+	if header.Height <= d.GetConfirmedBlockHeader(chain).Height {
+		return errors.New("height error")
+	}
+	if !header.Signer.IsEqual(d.authority) {
+		return fmt.Errorf("%s is not the authority", header.Signer.String())
+	}
+	if err := header.VerifySignature(); err != nil {
+		return err
+	}
+	d.SetConfirmedHeader(parent)
+	chain.UpdateConfirmedHeight(parent.Height)
+	return nil
+}
+
+func (d *DevSeal) VerifySigner(header *types.Header) error {
+	if !header.Signer.IsEqual(d.authority) {
+		return fmt.Errorf("%s is not the authority", header.Signer.String())
+	}
+	return header.VerifySignature()
+}
+
+// Only the candidate and vote transactions are applied, the blocks are
+// not counted and the rewards are not shared
+func (d *DevSeal) UpdateConsensus(chain consensus.IChain, block *types.Block) {
+	d.UpdateCandidates(block)
+}
+
+// The authority keeps the whole reward whatever rate it sets
+func (d *DevSeal) GetCommission(address hasharry.Address, term uint64) uint64 {
+	return types.MaxCommission
+}
+
+// The authority is the winner of every term
+func (d *DevSeal) GetTermWinners(term uint64) *types.Winners {
+	return &types.Winners{
+		Candidates: []*types.Candidate{{Signer: d.authority}},
+	}
+}
+
+// The blocks are not sent to other nodes
+func (d *DevSeal) GetWinnersPeerID(time uint64) ([]string, error) {
+	return []string{}, nil
+}
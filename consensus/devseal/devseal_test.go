@@ -0,0 +1,204 @@
+package devseal
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/consensus"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+type testPool struct {
+	prepared int
+}
+
+func (p *testPool) PreparedLen() int {
+	return p.prepared
+}
+
+type testSigner struct {
+	priv *secp256k1.PrivateKey
+}
+
+func (s *testSigner) SignHash(hash hasharry.Hash) (*types.SignScript, error) {
+	return types.Sign(s.priv, hash)
+}
+
+// Only the genesis is known, the confirmed height is recorded
+type testChain struct {
+	consensus.IChain
+	genesis   *types.Header
+	confirmed uint64
+}
+
+func (c *testChain) GetHeaderByHash(hash hasharry.Hash) (*types.Header, error) {
+	return nil, errors.New("not found")
+}
+
+func (c *testChain) GetHeaderByHeight(height uint64) (*types.Header, error) {
+	if height != 0 {
+		return nil, errors.New("not found")
+	}
+	return c.genesis, nil
+}
+
+func (c *testChain) UpdateConfirmedHeight(height uint64) {
+	c.confirmed = height
+}
+
+func newTestKey(t *testing.T) (*secp256k1.PrivateKey, hasharry.Address) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, hasharry.StringToAddress(ut.GenerateUWDAddress(param.Net, priv.PubKey()))
+}
+
+func newTestDevSeal(t *testing.T, priv *secp256k1.PrivateKey, authority hasharry.Address) (*DevSeal, func()) {
+	dir, err := ioutil.TempDir("", "devseal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := hasharry.StringToAddress(ut.GenerateUWDAddress(param.Net, priv.PubKey()))
+	d, err := NewDevSeal(dir, signer, authority, &testSigner{priv: priv})
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	if err := d.InitTrie(hasharry.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	return d, func() {
+		d.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func newTestBlock(t *testing.T, d *DevSeal, signer hasharry.Address, height uint64) *types.Block {
+	now := uint64(time.Now().Unix())
+	header := &types.Header{Version: types.BlockVersion, Height: height, Time: now,
+		Term: now / param.TermInterval, Signer: signer}
+	header.SetHash()
+	block := &types.Block{Header: header, Body: &types.Body{}}
+	if err := d.Sign(block); err != nil {
+		t.Fatal(err)
+	}
+	return block
+}
+
+func TestSealOnPool(t *testing.T) {
+	priv, authority := newTestKey(t)
+	d, closeFn := newTestDevSeal(t, priv, authority)
+	defer closeFn()
+	pool := &testPool{}
+	d.SetTxPool(pool)
+
+	header := &types.Header{Height: 1}
+	if err := d.CheckWinner(nil, header); err == nil {
+		t.Fatal("an empty pool is sealed")
+	}
+	pool.prepared = 1
+	if err := d.CheckWinner(nil, header); err != nil {
+		t.Fatal(err)
+	}
+	newTestBlock(t, d, authority, 1)
+	if err := d.CheckWinner(nil, header); err == nil {
+		t.Fatal("a block is sealed again before it is inserted")
+	}
+	if err := d.CheckWinner(nil, &types.Header{Height: 2}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSealOnRequest(t *testing.T) {
+	priv, authority := newTestKey(t)
+	d, closeFn := newTestDevSeal(t, priv, authority)
+	defer closeFn()
+	d.SetTxPool(&testPool{})
+
+	if err := d.CheckWinner(nil, &types.Header{Height: 1}); err == nil {
+		t.Fatal("an empty pool is sealed")
+	}
+	if err := d.Seal(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.CheckWinner(nil, &types.Header{Height: 1}); err != nil {
+		t.Fatal(err)
+	}
+	newTestBlock(t, d, authority, 1)
+	if err := d.CheckWinner(nil, &types.Header{Height: 2}); err == nil {
+		t.Fatal("the request is not reset by the sealed block")
+	}
+
+	// Only the authority seals
+	otherPriv, _ := newTestKey(t)
+	other, closeOther := newTestDevSeal(t, otherPriv, authority)
+	defer closeOther()
+	other.SetTxPool(&testPool{prepared: 1})
+	if err := other.Seal(); err == nil {
+		t.Fatal("a block is requested from a node that is not the authority")
+	}
+	if err := other.CheckWinner(nil, &types.Header{Height: 1}); err == nil {
+		t.Fatal("a node that is not the authority seals")
+	}
+}
+
+func TestVerifySealSigner(t *testing.T) {
+	priv, authority := newTestKey(t)
+	d, closeFn := newTestDevSeal(t, priv, authority)
+	defer closeFn()
+	otherPriv, signer := newTestKey(t)
+	other, closeOther := newTestDevSeal(t, otherPriv, authority)
+	defer closeOther()
+
+	genesis := &types.Header{Height: 0}
+	chain := &testChain{genesis: genesis}
+	parent := &types.Header{Height: 1}
+	forged := newTestBlock(t, other, signer, 2)
+	if err := d.VerifySeal(chain, forged.Header, parent); err == nil {
+		t.Fatal("a block of a signer that is not the authority is accepted")
+	}
+	// A block that names the authority but is not signed by it
+	forged.Header.Signer = authority
+	forged.Header.Hash, forged.Header.SignScript = hasharry.Hash{}, nil
+	forged.Header.SetHash()
+	if err := other.Sign(forged); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.VerifySeal(chain, forged.Header, parent); err == nil {
+		t.Fatal("a block not signed by the authority is accepted")
+	}
+	block := newTestBlock(t, d, authority, 2)
+	if err := d.VerifySeal(chain, block.Header, parent); err != nil {
+		t.Fatal(err)
+	}
+	if chain.confirmed != parent.Height {
+		t.Fatalf("confirmed height %d, want %d", chain.confirmed, parent.Height)
+	}
+}
+
+func TestVerifyHeaderClockDrift(t *testing.T) {
+	priv, authority := newTestKey(t)
+	d, closeFn := newTestDevSeal(t, priv, authority)
+	defer closeFn()
+
+	parent := &types.Header{Height: 1}
+	newHeader := func(blockTime uint64) *types.Header {
+		return &types.Header{Height: 2, Time: blockTime, Term: blockTime / param.TermInterval,
+			SignScript: &types.SignScript{}}
+	}
+	now := uint64(time.Now().Unix())
+	if err := d.VerifyHeader(newHeader(now+maxClockDrift-1), parent); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.VerifyHeader(newHeader(now+maxClockDrift+10), parent); err == nil {
+		t.Fatal("a block too far in the future is accepted")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/uworldao/UWORLD/crypto/hash"
 	"github.com/uworldao/UWORLD/database/dposdb"
 	"github.com/uworldao/UWORLD/param"
+	"math/bits"
 	"sort"
 	"time"
 )
@@ -24,6 +25,9 @@ type DPos struct {
 	signer               hasharry.Address
 	sign                 consensus.ISign
 	confirmedBlockHeader *types.Header
+	// The chain of Init, the evidences of double signing are checked
+	// against its headers
+	chain consensus.IChain
 }
 
 func NewDPos(DataDir string, signer hasharry.Address, sign consensus.ISign) (*DPos, error) {
@@ -46,6 +50,7 @@ func (dpos *DPos) Close() error {
 }
 
 func (dpos *DPos) Init(chain consensus.IChain) error {
+	dpos.chain = chain
 	gensis, err := chain.GetHeaderByHeight(0)
 	if err != nil {
 		return err
@@ -62,6 +67,7 @@ func (dpos *DPos) Init(chain consensus.IChain) error {
 }
 
 func (dpos *DPos) GetGenesisBlock() *types.Block {
+	genesis := param.GetGenesis()
 	block := &types.Block{
 		Header: &types.Header{
 			Hash:          hasharry.Hash{},
@@ -71,14 +77,14 @@ func (dpos *DPos) GetGenesisBlock() *types.Block {
 			ContractRoot:  hasharry.Hash{},
 			ConsensusRoot: hasharry.Hash{},
 			Height:        0,
-			Time:          1569398062,
+			Time:          genesis.Time,
 			Term:          0,
 			SignScript:    &types.SignScript{},
 			Signer:        hasharry.Address{},
 		},
 		Body: &types.Body{Transactions: types.Transactions{}},
 	}
-	for _, info := range initialCandidates {
+	for _, info := range genesis.Candidates {
 		var peerId types.PeerId
 		copy(peerId[:], info.PeerId)
 		tx := &types.Transaction{
@@ -88,7 +94,7 @@ func (dpos *DPos) GetGenesisBlock() *types.Block {
 				From:       hasharry.StringToAddress(info.Address),
 				Nonce:      0,
 				Fees:       0,
-				Time:       1569398062,
+				Time:       genesis.Time,
 				SignScript: &types.SignScript{},
 			},
 			TxBody: &types.LoginTransactionBody{
@@ -98,8 +104,31 @@ func (dpos *DPos) GetGenesisBlock() *types.Block {
 		tx.SetHash()
 		block.Transactions = append(block.Transactions, tx)
 	}
-	var sumCoins uint64
-	for _, info := range param.MappingCoin {
+	for _, info := range genesis.Alloc {
+		if info.IsLocked() {
+			tx := &types.Transaction{
+				TxHead: &types.TransactionHead{
+					TxHash:     hasharry.Hash{},
+					TxType:     types.LockedTransfer_,
+					From:       hasharry.StringToAddress(info.Address),
+					Nonce:      0,
+					Fees:       0,
+					Time:       genesis.Time,
+					Note:       info.Note,
+					SignScript: &types.SignScript{},
+				},
+				TxBody: &types.LockedTransferBody{
+					Contract: param.Token,
+					To:       hasharry.StringToAddress(info.Address),
+					Amount:   info.Amount,
+					Start:    info.Start,
+					End:      info.End,
+				},
+			}
+			tx.SetHash()
+			block.Transactions = append(block.Transactions, tx)
+			continue
+		}
 		tx := &types.Transaction{
 			TxHead: &types.TransactionHead{
 				TxHash:     hasharry.Hash{},
@@ -107,7 +136,7 @@ func (dpos *DPos) GetGenesisBlock() *types.Block {
 				From:       hasharry.StringToAddress(info.Address),
 				Nonce:      0,
 				Fees:       0,
-				Time:       1569398062,
+				Time:       genesis.Time,
 				Note:       info.Note,
 				SignScript: &types.SignScript{},
 			},
@@ -117,7 +146,6 @@ func (dpos *DPos) GetGenesisBlock() *types.Block {
 				Amount:   info.Amount,
 			},
 		}
-		sumCoins += info.Amount
 		tx.SetHash()
 		block.Transactions = append(block.Transactions, tx)
 	}
@@ -218,19 +246,114 @@ func (dpos *DPos) VerifySeal(chain consensus.IChain, header *types.Header, paren
 	return dpos.updateConfirmedBlockHeader(chain)
 }
 
+// The signer must be the winner of the slot in the elections stored in
+// the consensus state, the block is not elected for. A block of a side
+// branch is verified in full when its branch is applied.
+func (dpos *DPos) VerifySigner(header *types.Header) error {
+	if err := header.VerifySignature(); err != nil {
+		return err
+	}
+	winner, err := dpos.lookupWinners(header.Time)
+	if err != nil {
+		return err
+	}
+	if !winner.IsEqual(header.Signer) {
+		return fmt.Errorf("%s is not the winner at %d", header.Signer.String(), header.Time)
+	}
+	return nil
+}
+
 // If the current number of candidates is less than or equal to the
 // number of super nodes, it is not allowed to withdraw candidates.
-func (dpos *DPos) VerifyTx(tx types.ITransaction) error {
+func (dpos *DPos) VerifyTx(tx types.ITransaction, height uint64) error {
 	switch tx.GetTxType() {
-	/*case types.LogoutCandidate:
-	cans, _ := dpos.dposStorage.GetCandidates()
-	if cans.Len() <= maxWinnerSize {
-		return fmt.Errorf("candidate nodes are already in the minimum number. Cannot cancel the candidate status now, please wait")
-	}*/
+	case types.LoginCandidate_:
+		if dpos.isCandidate(tx.From()) {
+			return fmt.Errorf("%s is already a candidate", tx.From().String())
+		}
+	case types.LogoutCandidate_:
+		if !dpos.isCandidate(tx.From()) {
+			return fmt.Errorf("%s is not a candidate", tx.From().String())
+		}
+		cans, _ := dpos.dposStorage.GetCandidates()
+		if cans.Len() <= param.MaxWinnerSize {
+			return fmt.Errorf("candidate nodes are already in the minimum number. Cannot cancel the candidate status now, please wait")
+		}
+	case types.VoteToCandidate_:
+		to := tx.GetTxBody().ToAddress().ReceiverList()[0].Address
+		if !dpos.isCandidate(to) {
+			return fmt.Errorf("%s is not a candidate", to.String())
+		}
+	case types.SetCommission_:
+		if !dpos.isCandidate(tx.From()) {
+			return fmt.Errorf("%s is not a candidate", tx.From().String())
+		}
+	case types.ClaimReward_:
+		body := tx.GetTxBody().(*types.ClaimRewardBody)
+		if reward := dpos.dposStorage.GetReward(tx.From()); body.Amount > reward {
+			return fmt.Errorf("%s has %d rewards to claim", tx.From().String(), reward)
+		}
+	case types.DoubleSign_:
+		body := tx.GetTxBody().(*types.DoubleSignBody)
+		if body.Height() >= height {
+			return errors.New("evidence of a future block")
+		}
+		// The bond may have been released after the unbonding period
+		if body.Height()+param.UnbondingPeriod < height {
+			return errors.New("evidence is too old")
+		}
+		if dpos.dposStorage.HasDoubleSign(body.Signer(), body.Height()) {
+			return fmt.Errorf("double signing of %s at %d has been punished", body.Signer().String(), body.Height())
+		}
+		if !dpos.isTermWinner(body.Signer(), body.Header1.Term) {
+			return fmt.Errorf("%s is not a winner of term %d", body.Signer().String(), body.Header1.Term)
+		}
+		if !dpos.isKnownHeader(body.Header1) && !dpos.isKnownHeader(body.Header2) {
+			return errors.New("neither header is a block of this chain")
+		}
 	}
 	return nil
 }
 
+func (dpos *DPos) isTermWinner(address hasharry.Address, term uint64) bool {
+	winners, err := dpos.dposStorage.GetTermWinners(term)
+	if err != nil {
+		return false
+	}
+	for _, winner := range winners.Candidates {
+		if winner.Signer.IsEqual(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// The header is a stored block of the chain or of a side branch, or
+// its parent is
+func (dpos *DPos) isKnownHeader(header *types.Header) bool {
+	if dpos.chain == nil {
+		return false
+	}
+	if stored, err := dpos.chain.GetHeaderByHash(header.Hash); err == nil && stored.Height == header.Height {
+		return true
+	}
+	parent, err := dpos.chain.GetHeaderByHash(header.ParentHash)
+	return err == nil && parent.Height+1 == header.Height
+}
+
+func (dpos *DPos) isCandidate(address hasharry.Address) bool {
+	cans, err := dpos.dposStorage.GetCandidates()
+	if err != nil {
+		return false
+	}
+	for _, can := range cans.Members {
+		if can.Signer.IsEqual(address) {
+			return true
+		}
+	}
+	return false
+}
+
 // Verify that the address of the block generated at this time is correct,
 // and verify the signature.
 func (dpos *DPos) VerifyCreator(header *types.Header, parent *types.Header, chain consensus.IChain) error {
@@ -276,11 +399,18 @@ func (dpos *DPos) GetCandidates(chain consensus.IChain) []*types.Candidate {
 	if err != nil {
 		return nil
 	}
+	header, err := chain.CurrentHeader()
+	if err != nil {
+		return nil
+	}
 	for index, candidate := range candidates.Members {
 		voters := dpos.dposStorage.GetCandidateVoters(candidate.Signer)
 		for _, voter := range voters {
 			candidates.Members[index].Weight += chain.GetAddressVote(voter)
 		}
+		candidates.Members[index].Stake = chain.GetAddressBond(candidate.Signer)
+		candidates.Members[index].Weight += candidates.Members[index].Stake
+		candidates.Members[index].Commission = dpos.dposStorage.GetCommission(candidate.Signer, header.Term)
 	}
 	sortedCandidates := types.SortableCandidates{}
 	for _, candidate := range candidates.Members {
@@ -290,6 +420,14 @@ func (dpos *DPos) GetCandidates(chain consensus.IChain) []*types.Candidate {
 	return sortedCandidates
 }
 
+func (dpos *DPos) GetCommission(address hasharry.Address, term uint64) uint64 {
+	return dpos.dposStorage.GetCommission(address, term)
+}
+
+func (dpos *DPos) GetReward(address hasharry.Address) uint64 {
+	return dpos.dposStorage.GetReward(address)
+}
+
 func (dpos *DPos) GetTermWinners(term uint64) *types.Winners {
 	winners, _ := dpos.dposStorage.GetTermWinners(term)
 	return winners
@@ -308,6 +446,18 @@ func (dpos *DPos) InitTrie(consensusRoot hasharry.Hash) error {
 	return dpos.dposStorage.InitTrie(consensusRoot)
 }
 
+func (dpos *DPos) DumpTrie(consensusRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error {
+	return dpos.dposStorage.DumpTrie(consensusRoot, fn)
+}
+
+func (dpos *DPos) TrieNode(hash hasharry.Hash) ([]byte, error) {
+	return dpos.dposStorage.TrieNode(hash)
+}
+
+func (dpos *DPos) SyncTrie(consensusRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error {
+	return dpos.dposStorage.SyncTrie(consensusRoot, fn)
+}
+
 func (dpos *DPos) Commit() (hasharry.Hash, error) {
 	return dpos.dposStorage.Commit()
 }
@@ -421,12 +571,24 @@ func (dpos *DPos) updateGenesisDPosStorage(chain consensus.IChain) error {
 	if err != nil {
 		return err
 	}
-	dpos.UpdateConsensus(block)
+	dpos.UpdateConsensus(chain, block)
 	return nil
 }
 
 // Update consensus candidates and voting information
-func (dpos *DPos) UpdateConsensus(block *types.Block) {
+func (dpos *DPos) UpdateConsensus(chain consensus.IChain, block *types.Block) {
+	dpos.UpdateCandidates(block)
+	if block.Height != 0 && param.IsActive(param.Slashing, block.Height) {
+		dpos.updateMintCount(block)
+	}
+	if block.Height != 0 && param.IsActive(param.RewardSharing, block.Height) {
+		dpos.updateRewardPool(chain, block)
+	}
+}
+
+// Apply the candidate, vote, commission, reward claim and double sign
+// transactions of the block to the consensus state
+func (dpos *DPos) UpdateCandidates(block *types.Block) {
 	for _, tx := range block.Transactions {
 		switch tx.GetTxType() {
 		case types.LoginCandidate_:
@@ -438,24 +600,143 @@ func (dpos *DPos) UpdateConsensus(block *types.Block) {
 			// When becoming a candidate, also vote for yourself
 			dpos.dposStorage.SetCandidate(candidate)
 			dpos.dposStorage.SetVoter(tx.From(), tx.From())
-			/*case types.LogoutCandidate:
-				candidate := &types.Candidate{
-					Signer: tx.From(),
-					PeerId: "",
-					Weight: 0,
+		case types.LogoutCandidate_:
+			candidate := &types.Candidate{
+				Signer: tx.From(),
+				PeerId: "",
+				Weight: 0,
+			}
+			dpos.dposStorage.DeleteCandidate(candidate)
+		case types.VoteToCandidate_:
+			// A new vote replaces the previous vote of the address
+			dpos.dposStorage.SetVoter(tx.From(), tx.GetTxBody().ToAddress().ReceiverList()[0].Address)
+		case types.DoubleSign_:
+			// The bond is slashed by the account state
+			body := tx.GetTxBody().(*types.DoubleSignBody)
+			dpos.dposStorage.SetDoubleSign(body.Signer(), body.Height())
+			dpos.dposStorage.SetJailedUntil(body.Signer(), block.Term+1+param.JailTerms)
+		case types.SetCommission_:
+			body := tx.GetTxBody().(*types.CommissionBody)
+			dpos.dposStorage.SetCommission(tx.From(), body.Rate, block.Term)
+		case types.ClaimReward_:
+			// The claimed amount is minted by the account state
+			body := tx.GetTxBody().(*types.ClaimRewardBody)
+			dpos.dposStorage.SetReward(tx.From(), dpos.dposStorage.GetReward(tx.From())-body.Amount)
+		}
+	}
+}
+
+// Add the share of the voters in the reward of the block to the pool. The
+// first block of a term queues the pool of the previous term to be paid
+// to the voters, every block pays a part of the queue.
+func (dpos *DPos) updateRewardPool(chain consensus.IChain, block *types.Block) {
+	pool, err := dpos.dposStorage.GetRewardPool()
+	if err != nil || pool.Term != block.Term {
+		if err == nil {
+			dpos.queueRewards(pool)
+		}
+		pool = &dposdb.RewardPool{Term: block.Term}
+	}
+	reward := types.CalCoinBase(block.Height, param.CoinHeight)
+	share := reward - types.CalCommission(reward, dpos.dposStorage.GetCommission(block.Signer, block.Term))
+	if share != 0 {
+		pool.Add(block.Signer, share)
+	}
+	dpos.dposStorage.SetRewardPool(pool)
+	dpos.payRewards(chain)
+}
+
+// The share of each winner goes to the voters it had when the term
+// ended, a vote changed later does not take it away. The voters are
+// stored once under their own keys, the payout only counts them.
+func (dpos *DPos) queueRewards(pool *dposdb.RewardPool) {
+	payouts := dpos.dposStorage.GetRewardPayouts()
+	for _, share := range pool.Shares {
+		voters := dpos.dposStorage.GetCandidateVoters(share.Winner)
+		payouts = append(payouts, &dposdb.RewardPayout{
+			Winner: share.Winner,
+			Term:   pool.Term,
+			Amount: share.Amount,
+			Voters: dpos.dposStorage.SetTermVoters(pool.Term, share.Winner, voters),
+		})
+	}
+	dpos.dposStorage.SetRewardPayouts(payouts)
+}
+
+// The share of each winner is paid to its voters in proportion to their
+// coins, the remainder of the division goes to the winner. A block sums
+// or pays at most RewardPayoutsPerBlock voters, so the work of a block
+// does not grow with the number of voters. The coins of a voter may
+// change between the blocks, the payment is capped so the voters never
+// get more than the share.
+func (dpos *DPos) payRewards(chain consensus.IChain) {
+	payouts := dpos.dposStorage.GetRewardPayouts()
+	if len(payouts) == 0 {
+		return
+	}
+	for steps := 0; steps < param.RewardPayoutsPerBlock && len(payouts) != 0; steps++ {
+		payout := payouts[0]
+		switch {
+		case payout.Summed < payout.Voters:
+			if voter, err := dpos.dposStorage.GetTermVoter(payout.Term, payout.Winner, payout.Summed); err == nil {
+				payout.Sum += chain.GetAddressHolding(voter)
+			}
+			payout.Summed++
+		case payout.Rewarded < payout.Voters:
+			voter, err := dpos.dposStorage.GetTermVoter(payout.Term, payout.Winner, payout.Rewarded)
+			// The voter is not needed after its payment
+			dpos.dposStorage.DeleteTermVoter(payout.Term, payout.Winner, payout.Rewarded)
+			payout.Rewarded++
+			if err != nil || payout.Sum == 0 {
+				continue
+			}
+			holding := chain.GetAddressHolding(voter)
+			if holding > payout.Sum {
+				holding = payout.Sum
+			}
+			// payout.Amount * holding / payout.Sum without overflow,
+			// the quotient is not greater than payout.Amount
+			hi, lo := bits.Mul64(payout.Amount, holding)
+			reward, _ := bits.Div64(hi, lo, payout.Sum)
+			if reward > payout.Amount-payout.Paid {
+				reward = payout.Amount - payout.Paid
+			}
+			if reward != 0 {
+				dpos.dposStorage.SetReward(voter, dpos.dposStorage.GetReward(voter)+reward)
+				payout.Paid += reward
+			}
+		default:
+			if payout.Paid < payout.Amount {
+				dpos.dposStorage.SetReward(payout.Winner, dpos.dposStorage.GetReward(payout.Winner)+payout.Amount-payout.Paid)
+			}
+			payouts = payouts[1:]
+		}
+	}
+	dpos.dposStorage.SetRewardPayouts(payouts)
+}
+
+// Add 1 to the number of blocks of the signer in the term. The first block
+// of a term jails the winners of the previous term that missed blocks.
+func (dpos *DPos) updateMintCount(block *types.Block) {
+	mintTerm, err := dpos.dposStorage.GetMintTerm()
+	if err != nil || mintTerm.Term != block.Term {
+		if err == nil {
+			term := &Term{dPosStorage: dpos.dposStorage}
+			term.jailValidators(mintTerm, block.Term+1)
+		}
+		// The blocks of the term before the activation of the
+		// slashing are not counted, it has no winners to jail
+		newMintTerm := &dposdb.MintTerm{Term: block.Term}
+		if err == nil || block.Height == 1 {
+			if winners, err := dpos.dposStorage.GetTermWinners(block.Term); err == nil {
+				for _, winner := range winners.Candidates {
+					newMintTerm.Winners = append(newMintTerm.Winners, winner.Signer)
 				}
-				dpos.dposStorage.DeleteCandidate(candidate)
-			case types.VoteToCandidate:
-				dpos.dposStorage.SetVoter(tx.From(), tx.GetTxBody().ToAddress())*/
+			}
 		}
+		dpos.dposStorage.SetMintTerm(newMintTerm)
 	}
-	// Add 1 to the number of blocks at this address
-	/*dpos.dposStorage.SetTermWinnerMintCnt(block.Term, block.Signer)
-	nextBlockTerm := (block.Time + blockInterval) / termInterval
-	if nextBlockTerm == block.Term+1 {
-		term := &Term{dPosStorage: dpos.dposStorage}
-		term.kickOutValidator(block.Term)
-	}*/
+	dpos.dposStorage.SetTermWinnerMintCnt(block.Term, block.Signer)
 }
 
 func (dpos *DPos) loadConfirmedBlockHeader(chain consensus.IChain) (*types.Header, error) {
@@ -499,7 +780,7 @@ func (dpos *DPos) isSkipCurrentWinner(now uint64, lastTime uint64) bool {
 	if skipTimes < 1 {
 		return false
 	}
-	skipIndex := skipTimes % param.MaxWinnerSize
+	skipIndex := skipTimes % uint64(param.MaxWinnerSize)
 	return now%(param.SkipCurrentWinnerWaitTimeBase+skipIndex*param.BlockInterval) == 0
 }
 
@@ -3,6 +3,7 @@ package dpos
 import (
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/database/dposdb"
 )
 
 // DPos storage data interface
@@ -40,9 +41,70 @@ type IDPosStorage interface {
 	// Read the number of blocks to the super node address of a certain period
 	GetTermWinnerMintCnt(term uint64, address hasharry.Address) (uint64, error)
 
+	// Read the term whose blocks are counted
+	GetMintTerm() (*dposdb.MintTerm, error)
+
+	// Store the term whose blocks are counted
+	SetMintTerm(mintTerm *dposdb.MintTerm) error
+
+	// Read the first term the address can be elected in
+	GetJailedUntil(address hasharry.Address) uint64
+
+	// Exclude the address from the elections before the term
+	SetJailedUntil(address hasharry.Address, term uint64)
+
+	// Whether the double signing of the address at the height is punished
+	HasDoubleSign(address hasharry.Address, height uint64) bool
+
+	// Record the punished double signing of the address at the height
+	SetDoubleSign(address hasharry.Address, height uint64)
+
+	// Read the commission rate of the address in the term
+	GetCommission(address hasharry.Address, term uint64) uint64
+
+	// Change the commission rate of the address from the next term
+	SetCommission(address hasharry.Address, rate, term uint64) error
+
+	// Read the reward shares of the winners in the current term
+	GetRewardPool() (*dposdb.RewardPool, error)
+
+	// Store the reward shares of the winners in the current term
+	SetRewardPool(pool *dposdb.RewardPool) error
+
+	// Read the shares of the finished terms waiting to be paid
+	GetRewardPayouts() []*dposdb.RewardPayout
+
+	// Store the shares of the finished terms waiting to be paid
+	SetRewardPayouts(payouts []*dposdb.RewardPayout) error
+
+	// Store the voters of the winner at the end of the term and return
+	// their number
+	SetTermVoters(term uint64, winner hasharry.Address, voters []hasharry.Address) uint64
+
+	// Read the voter of the winner in the term at the index
+	GetTermVoter(term uint64, winner hasharry.Address, index uint64) (hasharry.Address, error)
+
+	// Delete the voter of the winner in the term at the index
+	DeleteTermVoter(term uint64, winner hasharry.Address, index uint64)
+
+	// Read the unclaimed rewards of the address
+	GetReward(address hasharry.Address) uint64
+
+	// Store the unclaimed rewards of the address
+	SetReward(address hasharry.Address, reward uint64)
+
 	// Initialize dpos trie root
 	InitTrie(contractRoot hasharry.Hash) error
 
+	// Walk the stored nodes of the dpos trie
+	DumpTrie(consensusRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error
+
+	// Read a stored node of the dpos trie
+	TrieNode(hash hasharry.Hash) ([]byte, error)
+
+	// Store the dpos trie with the given nodes
+	SyncTrie(consensusRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error
+
 	//Commit dpos trie information
 	Commit() (hasharry.Hash, error)
 
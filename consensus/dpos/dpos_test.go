@@ -0,0 +1,476 @@
+package dpos
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/consensus"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+)
+
+// The blocks of a day, a block reward is paid once a day
+var testDayBlocks = 60 * 60 * 24 / param.BlockInterval
+
+// The first height with a block reward
+var testRewardHeight = testDayBlocks + param.CoinHeight - 1
+
+// The coins, votes and bonds of the addresses and the stored headers,
+// nothing else of the chain is used
+type testChain struct {
+	consensus.IChain
+	holdings map[hasharry.Address]uint64
+	votes    map[hasharry.Address]uint64
+	bonds    map[hasharry.Address]uint64
+	headers  map[hasharry.Hash]*types.Header
+}
+
+func (c *testChain) GetAddressHolding(address hasharry.Address) uint64 {
+	return c.holdings[address]
+}
+
+func (c *testChain) GetAddressVote(address hasharry.Address) uint64 {
+	return c.votes[address]
+}
+
+func (c *testChain) GetAddressBond(address hasharry.Address) uint64 {
+	return c.bonds[address]
+}
+
+func (c *testChain) GetHeaderByHash(hash hasharry.Hash) (*types.Header, error) {
+	header, ok := c.headers[hash]
+	if !ok {
+		return nil, errors.New("header does not exist")
+	}
+	return header, nil
+}
+
+func newTestDPos(t *testing.T) (*DPos, *testChain, func()) {
+	dir, err := ioutil.TempDir("", "dpos")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dpos, err := NewDPos(dir, hasharry.Address{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dpos.InitTrie(hasharry.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	chain := &testChain{
+		holdings: make(map[hasharry.Address]uint64),
+		votes:    make(map[hasharry.Address]uint64),
+		bonds:    make(map[hasharry.Address]uint64),
+		headers:  make(map[hasharry.Hash]*types.Header),
+	}
+	dpos.chain = chain
+	return dpos, chain, func() {
+		dpos.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func testAddress(name string, i int) hasharry.Address {
+	return hasharry.BytesToAddress([]byte(fmt.Sprintf("%s%d", name, i)))
+}
+
+func newTestBlock(height, term uint64, signer hasharry.Address, txs ...types.ITransaction) *types.Block {
+	return &types.Block{
+		Header: &types.Header{Height: height, Term: term, Signer: signer},
+		Body:   &types.Body{Transactions: txs},
+	}
+}
+
+func newTestTx(from hasharry.Address, txType types.TransactionType, body types.ITransactionBody) types.ITransaction {
+	return &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     txType,
+			From:       from,
+			Nonce:      1,
+			Fees:       param.Fees,
+			Time:       1600000000,
+			SignScript: &types.SignScript{},
+		},
+		TxBody: body,
+	}
+}
+
+// Log in the candidate of the index, it votes for itself with the
+// votes of its index plus one
+func loginTestCandidate(dpos *DPos, chain *testChain, i int) hasharry.Address {
+	candidate := testAddress("candidate", i)
+	login := newTestTx(candidate, types.LoginCandidate_, &types.LoginTransactionBody{})
+	dpos.UpdateCandidates(newTestBlock(1, 0, hasharry.Address{}, login))
+	chain.votes[candidate] = uint64(i+1) * param.AtomsPerCoin
+	return candidate
+}
+
+func loginTestCandidates(dpos *DPos, chain *testChain, n int) []hasharry.Address {
+	candidates := make([]hasharry.Address, 0, n)
+	for i := 0; i < n; i++ {
+		candidates = append(candidates, loginTestCandidate(dpos, chain, i))
+	}
+	return candidates
+}
+
+// Elect the winners of the term and return whether each address is one
+func electTestTerm(t *testing.T, dpos *DPos, chain *testChain, term uint64, addresses ...hasharry.Address) []bool {
+	t.Helper()
+	election := &Term{dPosStorage: dpos.dposStorage, chain: chain}
+	if err := election.elect(term*param.TermInterval, hasharry.Hash{}, true); err != nil {
+		t.Fatal(err)
+	}
+	elected := make([]bool, len(addresses))
+	for i, address := range addresses {
+		elected[i] = dpos.isTermWinner(address, term)
+	}
+	return elected
+}
+
+// amount * holding / sum as the payment rounds it
+func testShare(amount, holding, sum uint64) uint64 {
+	share := new(big.Int).Mul(new(big.Int).SetUint64(amount), new(big.Int).SetUint64(holding))
+	return share.Div(share, new(big.Int).SetUint64(sum)).Uint64()
+}
+
+func TestRewardSharing(t *testing.T) {
+	dpos, chain, remove := newTestDPos(t)
+	defer remove()
+
+	// More voters than a block pays, the winner votes for itself
+	winner := testAddress("winner", 0)
+	voters := []hasharry.Address{winner}
+	chain.holdings[winner] = 5 * param.AtomsPerCoin
+	for i := 1; i <= param.RewardPayoutsPerBlock+20; i++ {
+		voter := testAddress("voter", i)
+		voters = append(voters, voter)
+		chain.holdings[voter] = uint64(i) * param.AtomsPerCoin
+	}
+	for _, voter := range voters {
+		dpos.dposStorage.SetVoter(voter, winner)
+	}
+	dpos.dposStorage.SetCommission(winner, 20, 0)
+
+	reward := types.CalCoinBase(testRewardHeight, param.CoinHeight)
+	if reward == 0 {
+		t.Fatal("no reward at the test height")
+	}
+	share := reward - reward*20/types.MaxCommission
+	dpos.updateRewardPool(chain, newTestBlock(testRewardHeight, 1, winner))
+	pool, err := dpos.dposStorage.GetRewardPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pool.Shares) != 1 || pool.Shares[0].Amount != share {
+		t.Fatalf("pool %v, want a share of %d", pool.Shares, share)
+	}
+
+	// The first block of the next term queues the share, the voters of
+	// the winner are stored when the term ends
+	dpos.updateRewardPool(chain, newTestBlock(testRewardHeight+1, 2, winner))
+	payouts := dpos.dposStorage.GetRewardPayouts()
+	if len(payouts) != 1 || payouts[0].Voters != uint64(len(voters)) || payouts[0].Summed != param.RewardPayoutsPerBlock {
+		t.Fatal("the payout is not a cursor over the voters of the term")
+	}
+	// A vote changed after the term does not take the share away
+	dpos.dposStorage.SetVoter(voters[1], testAddress("winner", 1))
+
+	blocks := 1
+	for height := testRewardHeight + 2; len(dpos.dposStorage.GetRewardPayouts()) != 0; height++ {
+		dpos.updateRewardPool(chain, newTestBlock(height, 2, winner))
+		blocks++
+	}
+	// Summing and paying every voter, then the remainder to the winner
+	if want := (2*len(voters) + 1 + param.RewardPayoutsPerBlock - 1) / param.RewardPayoutsPerBlock; blocks != want {
+		t.Fatalf("paid in %d blocks, want %d", blocks, want)
+	}
+
+	var sum, paid uint64
+	for _, voter := range voters {
+		sum += chain.holdings[voter]
+	}
+	for _, voter := range voters[1:] {
+		want := testShare(share, chain.holdings[voter], sum)
+		if got := dpos.GetReward(voter); got != want {
+			t.Fatalf("reward of %s is %d, want %d", voter.String(), got, want)
+		}
+		paid += want
+	}
+	if got := dpos.GetReward(winner); got != share-paid {
+		t.Fatalf("reward of the winner is %d, want %d", got, share-paid)
+	}
+	if _, err := dpos.dposStorage.GetTermVoter(1, winner, 0); err == nil {
+		t.Fatal("the voters of a paid term are kept")
+	}
+}
+
+func TestCommissionFromNextTerm(t *testing.T) {
+	dpos, chain, remove := newTestDPos(t)
+	defer remove()
+	winner, voter := testAddress("winner", 0), testAddress("voter", 0)
+	dpos.dposStorage.SetVoter(voter, winner)
+	chain.holdings[voter] = 1 * param.AtomsPerCoin
+
+	// The winner keeps the whole reward of the term the rate is set in
+	commission := newTestTx(winner, types.SetCommission_, &types.CommissionBody{Rate: 30})
+	if err := dpos.VerifyTx(commission, testRewardHeight); err == nil {
+		t.Fatal("the commission of an address that is not a candidate is accepted")
+	}
+	dpos.dposStorage.SetCandidate(&types.Candidate{Signer: winner})
+	if err := dpos.VerifyTx(commission, testRewardHeight); err != nil {
+		t.Fatal(err)
+	}
+	block := newTestBlock(testRewardHeight, 1, winner, commission)
+	dpos.UpdateCandidates(block)
+	dpos.updateRewardPool(chain, block)
+	if pool, err := dpos.dposStorage.GetRewardPool(); err != nil || len(pool.Shares) != 0 {
+		t.Fatal("the voters share the reward of a winner that kept it")
+	}
+
+	height := testRewardHeight + testDayBlocks
+	reward := types.CalCoinBase(height, param.CoinHeight)
+	dpos.updateRewardPool(chain, newTestBlock(height, 2, winner))
+	pool, err := dpos.dposStorage.GetRewardPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := reward - reward*30/types.MaxCommission; len(pool.Shares) != 1 || pool.Shares[0].Amount != want {
+		t.Fatalf("pool %v, want a share of %d", pool.Shares, want)
+	}
+
+	// The only voter gets the whole share
+	dpos.updateRewardPool(chain, newTestBlock(height+1, 3, winner))
+	if got := dpos.GetReward(voter); got != pool.Shares[0].Amount {
+		t.Fatalf("reward of the voter is %d, want %d", got, pool.Shares[0].Amount)
+	}
+	if got := dpos.GetReward(winner); got != 0 {
+		t.Fatalf("reward of the winner is %d, its commission is paid by the coinbase", got)
+	}
+}
+
+func TestClaimReward(t *testing.T) {
+	dpos, _, remove := newTestDPos(t)
+	defer remove()
+	voter := testAddress("voter", 0)
+	dpos.dposStorage.SetReward(voter, 10*param.AtomsPerCoin)
+
+	claim := func(amount uint64) types.ITransaction {
+		return newTestTx(voter, types.ClaimReward_, &types.ClaimRewardBody{Amount: amount})
+	}
+	if err := dpos.VerifyTx(claim(11*param.AtomsPerCoin), 1); err == nil {
+		t.Fatal("a claim of more than the rewards is accepted")
+	}
+	if err := dpos.VerifyTx(claim(4*param.AtomsPerCoin), 1); err != nil {
+		t.Fatal(err)
+	}
+	dpos.UpdateCandidates(newTestBlock(1, 0, hasharry.Address{}, claim(4*param.AtomsPerCoin)))
+	if got, want := dpos.GetReward(voter), uint64(6*param.AtomsPerCoin); got != want {
+		t.Fatalf("reward %d after the claim, want %d", got, want)
+	}
+	if err := dpos.VerifyTx(claim(7*param.AtomsPerCoin), 2); err == nil {
+		t.Fatal("a claimed reward is claimed again")
+	}
+	dpos.UpdateCandidates(newTestBlock(2, 0, hasharry.Address{}, claim(6*param.AtomsPerCoin)))
+	if got := dpos.GetReward(voter); got != 0 {
+		t.Fatalf("reward %d after claiming all", got)
+	}
+	if err := dpos.VerifyTx(claim(1), 3); err == nil {
+		t.Fatal("a claim without rewards is accepted")
+	}
+}
+
+func TestLogoutKeepsMinimumCandidates(t *testing.T) {
+	dpos, chain, remove := newTestDPos(t)
+	defer remove()
+	candidates := loginTestCandidates(dpos, chain, param.MaxWinnerSize)
+
+	logout := func(from hasharry.Address) types.ITransaction {
+		return newTestTx(from, types.LogoutCandidate_, &types.LogoutTransactionBody{})
+	}
+	if err := dpos.VerifyTx(logout(testAddress("voter", 0)), 2); err == nil {
+		t.Fatal("the logout of an address that is not a candidate is accepted")
+	}
+	if err := dpos.VerifyTx(logout(candidates[0]), 2); err == nil {
+		t.Fatal("a logout that leaves fewer candidates than the seats is accepted")
+	}
+	extra := loginTestCandidate(dpos, chain, param.MaxWinnerSize)
+	if err := dpos.VerifyTx(logout(candidates[0]), 2); err != nil {
+		t.Fatal(err)
+	}
+	dpos.UpdateCandidates(newTestBlock(2, 0, hasharry.Address{}, logout(candidates[0])))
+	if dpos.isCandidate(candidates[0]) {
+		t.Fatal("the candidate is kept after the logout")
+	}
+	if err := dpos.VerifyTx(logout(extra), 3); err == nil {
+		t.Fatal("a second logout below the minimum is accepted")
+	}
+}
+
+func TestVotesChangeWinners(t *testing.T) {
+	dpos, chain, remove := newTestDPos(t)
+	defer remove()
+	candidates := loginTestCandidates(dpos, chain, param.MaxWinnerSize+1)
+	lowest, next := candidates[0], candidates[1]
+
+	if elected := electTestTerm(t, dpos, chain, 0, lowest, next); elected[0] || !elected[1] {
+		t.Fatal("the candidate with the fewest votes is elected")
+	}
+
+	// The coins of a voter count for the candidate it votes for
+	voter := testAddress("voter", 0)
+	chain.votes[voter] = 100 * param.AtomsPerCoin
+	vote := newTestTx(voter, types.VoteToCandidate_, &types.VoteTransactionBody{To: lowest})
+	if err := dpos.VerifyTx(vote, 2); err != nil {
+		t.Fatal(err)
+	}
+	dpos.UpdateCandidates(newTestBlock(2, 0, hasharry.Address{}, vote))
+	if elected := electTestTerm(t, dpos, chain, 1, lowest, next); !elected[0] || elected[1] {
+		t.Fatal("the vote does not move the candidate into the winners")
+	}
+
+	// A new vote replaces the previous one
+	vote = newTestTx(voter, types.VoteToCandidate_, &types.VoteTransactionBody{To: candidates[5]})
+	dpos.UpdateCandidates(newTestBlock(3, 1, hasharry.Address{}, vote))
+	if elected := electTestTerm(t, dpos, chain, 2, lowest, next); elected[0] || !elected[1] {
+		t.Fatal("the replaced vote is still counted")
+	}
+
+	// The votes for a candidate that logged out are not counted
+	logout := newTestTx(candidates[5], types.LogoutCandidate_, &types.LogoutTransactionBody{})
+	dpos.UpdateCandidates(newTestBlock(4, 2, hasharry.Address{}, logout))
+	if elected := electTestTerm(t, dpos, chain, 3, candidates[5], lowest); elected[0] || !elected[1] {
+		t.Fatal("a candidate that logged out is elected")
+	}
+	if err := dpos.VerifyTx(newTestTx(voter, types.VoteToCandidate_, &types.VoteTransactionBody{To: candidates[5]}), 5); err == nil {
+		t.Fatal("a vote for an address that is not a candidate is accepted")
+	}
+}
+
+func TestBondCountsAsVotes(t *testing.T) {
+	dpos, chain, remove := newTestDPos(t)
+	defer remove()
+	candidates := loginTestCandidates(dpos, chain, param.MaxWinnerSize+1)
+	lowest := candidates[0]
+
+	chain.bonds[lowest] = 50 * param.AtomsPerCoin
+	if elected := electTestTerm(t, dpos, chain, 0, lowest, candidates[1]); !elected[0] || elected[1] {
+		t.Fatal("the bond of the candidate is not counted as its votes")
+	}
+	for _, winner := range dpos.GetTermWinners(0).Candidates {
+		if want := chain.bonds[lowest] + chain.votes[lowest]; winner.Signer.IsEqual(lowest) && winner.Weight != want {
+			t.Fatalf("weight %d, want %d with the bond", winner.Weight, want)
+		}
+	}
+
+	// A bond slashed below the votes of the others is not enough
+	chain.bonds[lowest] = 1
+	if elected := electTestTerm(t, dpos, chain, 1, lowest, candidates[1]); elected[0] || !elected[1] {
+		t.Fatal("the candidate is elected without enough bond")
+	}
+}
+
+func TestJailedWinnerNotElected(t *testing.T) {
+	dpos, chain, remove := newTestDPos(t)
+	defer remove()
+	candidates := loginTestCandidates(dpos, chain, param.MaxWinnerSize+1)
+	electTestTerm(t, dpos, chain, 0)
+	winners := dpos.GetTermWinners(0).Candidates
+
+	// Every winner but the last mints its blocks of the term
+	height := uint64(1)
+	for round := 0; round < 9; round++ {
+		for _, winner := range winners[:len(winners)-1] {
+			dpos.updateMintCount(newTestBlock(height, 0, winner.Signer))
+			height++
+		}
+	}
+	missed := winners[len(winners)-1].Signer
+	dpos.updateMintCount(newTestBlock(height, 1, winners[0].Signer))
+	if until := dpos.dposStorage.GetJailedUntil(missed); until != 2+param.JailTerms {
+		t.Fatalf("jailed until %d, want %d", until, 2+param.JailTerms)
+	}
+	for _, winner := range winners[:len(winners)-1] {
+		if dpos.dposStorage.GetJailedUntil(winner.Signer) != 0 {
+			t.Fatalf("%s is jailed for its blocks", winner.Signer.String())
+		}
+	}
+
+	// The candidate that was not a winner takes the seat
+	var other hasharry.Address
+	for _, candidate := range candidates {
+		if !dpos.isTermWinner(candidate, 0) {
+			other = candidate
+		}
+	}
+	if elected := electTestTerm(t, dpos, chain, 2, missed, other); elected[0] || !elected[1] {
+		t.Fatal("the jailed winner is elected for the next term")
+	}
+	if elected := electTestTerm(t, dpos, chain, 2+param.JailTerms, missed); !elected[0] {
+		t.Fatal("the winner is not elected after the jail")
+	}
+}
+
+func TestDoubleSignEvidence(t *testing.T) {
+	dpos, chain, remove := newTestDPos(t)
+	defer remove()
+	signer, reporter := testAddress("winner", 0), testAddress("reporter", 0)
+	dpos.dposStorage.SetTermWinners(0, &types.Winners{Candidates: []*types.Candidate{{Signer: signer}}})
+
+	parent := &types.Header{Height: 4, Signer: signer}
+	parent.SetHash()
+	chain.headers[parent.Hash] = parent
+	header := func(height, term uint64, signer hasharry.Address, txRoot string) *types.Header {
+		h := &types.Header{ParentHash: parent.Hash, Height: height, Term: term, Signer: signer,
+			TxRoot: hasharry.BytesToHash([]byte(txRoot))}
+		h.SetHash()
+		return h
+	}
+	evidence := func(h1, h2 *types.Header) types.ITransaction {
+		return newTestTx(reporter, types.DoubleSign_, &types.DoubleSignBody{Header1: h1, Header2: h2})
+	}
+
+	// The headers only need a known parent
+	valid := evidence(header(5, 0, signer, "a"), header(5, 0, signer, "b"))
+	if err := dpos.VerifyTx(valid, 6); err != nil {
+		t.Fatal(err)
+	}
+	if err := dpos.VerifyTx(valid, 5); err == nil {
+		t.Fatal("evidence of a future block is accepted")
+	}
+	if err := dpos.VerifyTx(valid, 5+param.UnbondingPeriod+1); err == nil {
+		t.Fatal("evidence older than the unbonding period is accepted")
+	}
+	other := testAddress("winner", 1)
+	if err := dpos.VerifyTx(evidence(header(5, 0, other, "a"), header(5, 0, other, "b")), 6); err == nil {
+		t.Fatal("evidence of a signer that is not a winner is accepted")
+	}
+	if err := dpos.VerifyTx(evidence(header(5, 1, signer, "a"), header(5, 1, signer, "b")), 6); err == nil {
+		t.Fatal("evidence of a term the signer did not win is accepted")
+	}
+	if err := dpos.VerifyTx(evidence(header(6, 0, signer, "a"), header(6, 0, signer, "b")), 7); err == nil {
+		t.Fatal("evidence of headers whose parent is not at the height below is accepted")
+	}
+	delete(chain.headers, parent.Hash)
+	if err := dpos.VerifyTx(valid, 6); err == nil {
+		t.Fatal("evidence of headers unknown to the chain is accepted")
+	}
+	// A stored header is known without its parent
+	h1 := valid.GetTxBody().(*types.DoubleSignBody).Header1
+	chain.headers[h1.Hash] = h1
+	if err := dpos.VerifyTx(valid, 6); err != nil {
+		t.Fatal(err)
+	}
+
+	dpos.UpdateCandidates(newTestBlock(6, 0, hasharry.Address{}, valid))
+	if until := dpos.dposStorage.GetJailedUntil(signer); until != 1+param.JailTerms {
+		t.Fatalf("jailed until %d, want %d", until, 1+param.JailTerms)
+	}
+	if err := dpos.VerifyTx(valid, 7); err == nil {
+		t.Fatal("punished evidence is accepted again")
+	}
+}
@@ -3,11 +3,11 @@ package dpos
 import (
 	"encoding/binary"
 	"errors"
-	"fmt"
 	hash2 "github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/consensus"
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/crypto/hash"
+	"github.com/uworldao/UWORLD/database/dposdb"
 	log "github.com/uworldao/UWORLD/log/log15"
 	"github.com/uworldao/UWORLD/param"
 	"math/rand"
@@ -26,7 +26,9 @@ type Term struct {
 
 // Calculate the votes of all candidates.An address can only vote for one
 // address, and the real-time balance of the address is used as the number
-// of votes it voted for another address.
+// of votes it voted for another address. The votes for an address that
+// has logged out are not counted until it logs in again. The bond of a
+// candidate is counted as its own votes.
 func (term *Term) countVote() ([]*types.Candidate, error) {
 	candidates, err := term.dPosStorage.GetCandidates()
 	if err != nil {
@@ -40,6 +42,8 @@ func (term *Term) countVote() ([]*types.Candidate, error) {
 		for _, voter := range voters {
 			candidates.Members[index].Weight += term.chain.GetAddressVote(voter)
 		}
+		candidates.Members[index].Stake = term.chain.GetAddressBond(candidate.Signer)
+		candidates.Members[index].Weight += candidates.Members[index].Stake
 	}
 	return candidates.Members, nil
 }
@@ -52,16 +56,25 @@ func (term *Term) elect(current uint64, parentHash hash2.Hash, isSort bool) erro
 		return err
 	}
 	candidates := types.SortableCandidates{}
+	jailed := types.SortableCandidates{}
 	for _, candidate := range voters {
-		candidates = append(candidates, candidate)
-	}
-	if len(candidates) < param.SafeSize {
-		return errors.New("too few candidates")
+		if term.dPosStorage.GetJailedUntil(candidate.Signer) > currentTerm {
+			jailed = append(jailed, candidate)
+		} else {
+			candidates = append(candidates, candidate)
+		}
 	}
 
 	//
 	if isSort {
 		sort.Sort(candidates)
+		sort.Sort(jailed)
+	}
+	// The jailed candidates only take the seats that the
+	// others can not fill
+	candidates = append(candidates, jailed...)
+	if len(candidates) < param.SafeSize {
+		return errors.New("too few candidates")
 	}
 
 	if len(candidates) > param.MaxWinnerSize {
@@ -92,45 +105,58 @@ func (term *Term) electCheckTime(chain consensus.IChain, parent, current uint64)
 	return nil
 }
 
-// If a super node has fewer blocks in the previous cycle,
-// the super node is kicked out of the candidate and cannot
-// participate in subsequent blocks unless it becomes a candidate again
-func (term *Term) kickOutValidator(preTerm uint64) error {
-	winners, err := term.dPosStorage.GetTermWinners(preTerm)
-	if err != nil {
-		return fmt.Errorf("failed to get validator: %s", err)
-	}
-	if len(winners.Candidates) == 0 {
-		return errors.New("no winner could be kick out")
+// A winner that minted less than a third of its share of the blocks of
+// the term is jailed, it can not be elected until JailTerms terms after
+// the next term. The share is counted from the blocks the winners
+// minted, not from the slots of the term, so a term cut short by a halt
+// does not jail everyone. The winners with the fewest blocks are jailed
+// first, and no more are jailed once only SafeSize candidates are left
+// to elect for the next term.
+func (term *Term) jailValidators(mintTerm *dposdb.MintTerm, next uint64) {
+	if len(mintTerm.Winners) == 0 {
+		return
 	}
-
-	needKickOutWinners := types.SortableCandidates{}
-	for _, winner := range winners.Candidates {
-		cnt, err := term.dPosStorage.GetTermWinnerMintCnt(preTerm, winner.Signer)
+	counts := make(map[hash2.Address]uint64, len(mintTerm.Winners))
+	var total uint64
+	for _, winner := range mintTerm.Winners {
+		cnt, err := term.dPosStorage.GetTermWinnerMintCnt(mintTerm.Term, winner)
 		if err != nil {
 			cnt = 0
 		}
-		if cnt < param.TermInterval/param.BlockInterval/param.MaxWinnerSize/3 {
-			needKickOutWinners = append(needKickOutWinners, &types.Candidate{Signer: winner.Signer, PeerId: "", Weight: cnt})
-		}
+		counts[winner] = cnt
+		total += cnt
 	}
-	needKickOutWinnerCnt := len(needKickOutWinners)
-	if needKickOutWinnerCnt <= 0 {
-		return nil
+	share := total / uint64(len(mintTerm.Winners))
+	missed := make([]hash2.Address, 0)
+	for _, winner := range mintTerm.Winners {
+		if counts[winner] < share/3 {
+			missed = append(missed, winner)
+		}
 	}
-	sort.Sort(sort.Reverse(needKickOutWinners))
-	candidates, err := term.dPosStorage.GetCandidates()
-	candidateCount := len(candidates.Members)
+	sort.Slice(missed, func(i, j int) bool {
+		if counts[missed[i]] != counts[missed[j]] {
+			return counts[missed[i]] < counts[missed[j]]
+		}
+		return missed[i].String() < missed[j].String()
+	})
 
-	for _, winner := range needKickOutWinners {
-		// If the number of candidates is already the smallest, don’t kick out
-		if candidateCount <= param.MaxWinnerSize {
-			//log.Info("No more candidate can be kic out", "prevEpochID", preTerm, "candidateCount", candidateCount, "needKicOutCount", len(needKickOutWinners)-i)
-			return nil
+	eligible := make(map[hash2.Address]bool)
+	if candidates, err := term.dPosStorage.GetCandidates(); err == nil {
+		for _, candidate := range candidates.Members {
+			if term.dPosStorage.GetJailedUntil(candidate.Signer) <= next {
+				eligible[candidate.Signer] = true
+			}
 		}
-		term.dPosStorage.DeleteCandidate(winner)
-		candidateCount--
-		log.Info("Kick out candidate", "prevTerm", preTerm, "candidate", winner.Signer.String(), "mintCnt", winner.Weight)
 	}
-	return nil
+	for _, winner := range missed {
+		if eligible[winner] {
+			if len(eligible) <= param.SafeSize {
+				log.Info("Keep winner", "term", mintTerm.Term, "winner", winner.String(), "mintCnt", counts[winner])
+				continue
+			}
+			delete(eligible, winner)
+		}
+		term.dPosStorage.SetJailedUntil(winner, next+param.JailTerms)
+		log.Info("Jail winner", "term", mintTerm.Term, "winner", winner.String(), "mintCnt", counts[winner])
+	}
 }
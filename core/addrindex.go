@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/interface"
+	"github.com/uworldao/UWORLD/core/types"
+	log "github.com/uworldao/UWORLD/log/log15"
+)
+
+// Maximum number of entries returned by one page of address transactions
+const maxAddressTxsPage = 1000
+
+// EnableAddressIndex turns on the address transaction history index, the
+// blocks stored while it was off are indexed first.
+func (blc *BlockChain) EnableAddressIndex() error {
+	blc.insertMutex.Lock()
+	defer blc.insertMutex.Unlock()
+
+	var start uint64
+	if height, err := blc.storage.GetAddrIndexHeight(); err == nil {
+		start = height + 1
+	}
+	lastHeight := blc.GetLastHeight()
+	if start <= lastHeight {
+		log.Info("Build address index", "from", start, "to", lastHeight)
+	}
+	for height := start; height <= lastHeight; height++ {
+		block, err := blc.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		if err := blc.storage.UpdateAddressTxs(height, blc.addressTxs(block)); err != nil {
+			return err
+		}
+	}
+	blc.mutex.Lock()
+	blc.addrIndex = true
+	blc.mutex.Unlock()
+	return nil
+}
+
+func (blc *BlockChain) GetAddressTransactions(address hasharry.Address, start, count uint64) ([]*types.AddressTx, uint64, error) {
+	if !blc.isAddrIndex() {
+		return nil, 0, errors.New("address index is not enabled")
+	}
+	if count > maxAddressTxsPage {
+		count = maxAddressTxsPage
+	}
+	return blc.storage.GetAddressTxs(address, start, count)
+}
+
+func (blc *BlockChain) isAddrIndex() bool {
+	blc.mutex.RLock()
+	defer blc.mutex.RUnlock()
+
+	return blc.addrIndex
+}
+
+// Collect the addresses touched by each transaction of the block: the
+// sender, the receivers and the addresses in the contract events. It
+// must be called while the contract state still contains the block.
+func (blc *BlockChain) addressTxs(block *types.Block) []*types.AddressTx {
+	addrTxs := make([]*types.AddressTx, 0)
+	for index, tx := range block.Transactions {
+		for address, _ := range txAddresses(tx, blc.contractState) {
+			addrTxs = append(addrTxs, &types.AddressTx{
+				Address: address,
+				TxHash:  tx.Hash(),
+				Height:  block.Height,
+				TxIndex: uint32(index),
+			})
+		}
+	}
+	return addrTxs
+}
+
+// Get the addresses a transaction touches, the contract state
+// must contain the result of the transaction
+func txAddresses(tx types.ITransaction, contractState _interface.IContractState) map[hasharry.Address]bool {
+	addresses := make(map[hasharry.Address]bool)
+	if !tx.IsCoinBase() {
+		addresses[tx.From()] = true
+	}
+	if receivers := tx.GetTxBody().ToAddress(); receivers != nil {
+		for _, re := range receivers.ReceiverList() {
+			addresses[re.Address] = true
+		}
+	}
+	if tx.GetTxType() == types.ContractV2_ {
+		if state := contractState.GetContractV2State(tx.Hash().String()); state != nil {
+			for _, event := range state.Event {
+				addresses[event.From] = true
+				addresses[event.To] = true
+			}
+		}
+	}
+	delete(addresses, hasharry.Address{})
+	return addresses
+}
+
+// Get the index entries of the block stored together with it, nil
+// if the index is off. The contract state must contain the block.
+func (blc *BlockChain) indexTxs(block *types.Block) []*types.AddressTx {
+	if !blc.addrIndex {
+		return nil
+	}
+	return blc.addressTxs(block)
+}
+
+// Get the index entries removed together with the reverted blocks, nil
+// if the index is off. It must be called before the contract state is reverted.
+func (blc *BlockChain) unindexTxs(blocks []*types.Block) []*types.AddressTx {
+	if !blc.addrIndex {
+		return nil
+	}
+	addrTxs := make([]*types.AddressTx, 0)
+	for _, block := range blocks {
+		addrTxs = append(addrTxs, blc.addressTxs(block)...)
+	}
+	return addrTxs
+}
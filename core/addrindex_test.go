@@ -0,0 +1,84 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+)
+
+// Get the transaction hashes of the address from the oldest
+func addressTxHashes(t *testing.T, c *testChain, address hasharry.Address) []hasharry.Hash {
+	t.Helper()
+	addrTxs, total, err := c.GetAddressTransactions(address, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != uint64(len(addrTxs)) {
+		t.Fatalf("%d of %d entries", len(addrTxs), total)
+	}
+	hashes := make([]hasharry.Hash, len(addrTxs))
+	for i, addrTx := range addrTxs {
+		hashes[len(addrTxs)-1-i] = addrTx.TxHash
+	}
+	return hashes
+}
+
+func checkAddressTxs(t *testing.T, c *testChain, address hasharry.Address, want []hasharry.Hash, txs ...types.ITransaction) {
+	t.Helper()
+	for _, tx := range txs {
+		want = append(want, tx.Hash())
+	}
+	hashes := addressTxHashes(t, c, address)
+	if len(hashes) != len(want) {
+		t.Fatalf("%d entries, want %d", len(hashes), len(want))
+	}
+	for i := range want {
+		if hashes[i] != want[i] {
+			t.Fatalf("entry %d is %s, want %s", i, hashes[i].String(), want[i].String())
+		}
+	}
+}
+
+func TestAddressIndexRevert(t *testing.T) {
+	a, b := newTestForks(t)
+	defer a.remove()
+	defer b.remove()
+
+	if _, _, err := a.GetAddressTransactions(testReceiver, 0, 10); err == nil {
+		t.Fatal("entries are returned without the index")
+	}
+	common, err := a.GetBlockByHeight(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The blocks stored before are indexed when the index is enabled
+	if err := a.EnableAddressIndex(); err != nil {
+		t.Fatal(err)
+	}
+	checkAddressTxs(t, a, testReceiver, nil, common.Transactions[0])
+	// The sender also has the premine of the genesis
+	sent := addressTxHashes(t, a, testSender)
+
+	reverted := newTestTransfer(t, 2, testReceiver, 2*param.AtomsPerCoin)
+	a.mine(testGenesisTime+20, reverted)
+	checkAddressTxs(t, a, testReceiver, nil, common.Transactions[0], reverted)
+	checkAddressTxs(t, a, testSender, sent, reverted)
+
+	// The reorg removes the entries of the replaced block and adds the
+	// entries of the branch
+	side := newTestTransfer(t, 2, testReceiver, 5*param.AtomsPerCoin)
+	side1 := b.mine(testGenesisTime+30, side)
+	side2 := b.mine(testGenesisTime + 40)
+	for _, block := range []*types.Block{side1, side2} {
+		if err := a.InsertChain(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if head, _ := a.CurrentHeader(); head.Hash != side2.Hash {
+		t.Fatal("the chain did not switch to the branch")
+	}
+	checkAddressTxs(t, a, testReceiver, nil, common.Transactions[0], side)
+	checkAddressTxs(t, a, testSender, sent, side)
+}
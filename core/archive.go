@@ -0,0 +1,211 @@
+package core
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/utils"
+	"github.com/uworldao/UWORLD/core/types"
+	log "github.com/uworldao/UWORLD/log/log15"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// A block archive is a gzip stream of rlp encoded RlpBlocks in height
+// order. Gzip readers accept streams made of several members, so an
+// export is resumed by appending a new member to the archive.
+
+// Number of blocks between two progress logs
+const archiveProgress = 1000
+
+// Export the blocks from the height to the height into the archive file,
+// to is the last height if it is 0. If the file already holds blocks,
+// only the blocks after its last one are appended.
+func (blc *BlockChain) ExportChain(path string, from, to uint64) error {
+	lastHeight := blc.GetLastHeight()
+	if to == 0 || to > lastHeight {
+		to = lastHeight
+	}
+	if utils.IsExist(path) {
+		archived, err := readArchiveTail(path)
+		if err != nil {
+			return fmt.Errorf("can not resume archive %s! %s", path, err.Error())
+		}
+		// The member being written when an export was interrupted is cut off
+		if err := os.Truncate(path, archived.end); err != nil {
+			return err
+		}
+		if archived.header != nil {
+			height := archived.header.Height
+			header, err := blc.GetHeaderByHeight(height)
+			if err != nil || !header.Hash.IsEqual(archived.header.Hash) {
+				return fmt.Errorf("archive %s ends with block %s at %d which is not in the local chain", path, archived.header.Hash.String(), height)
+			}
+			if height+1 < from {
+				return fmt.Errorf("archive %s ends at %d, can not continue from %d", path, height, from)
+			}
+			from = height + 1
+		}
+	}
+	if from > to {
+		log.Info("No blocks to export", "from", from, "to", to)
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	zw := gzip.NewWriter(writer)
+
+	for height := from; height <= to; height++ {
+		block, err := blc.GetRlpBlockByHeight(height)
+		if err != nil {
+			return fmt.Errorf("get block %d failed! %s", height, err.Error())
+		}
+		if err := rlp.Encode(zw, block); err != nil {
+			return fmt.Errorf("write block %d failed! %s", height, err.Error())
+		}
+		if (height-from+1)%archiveProgress == 0 {
+			log.Info("Exporting blocks", "height", height, "to", to)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	log.Info("Export blocks completed", "from", from, "to", to, "file", path)
+	return nil
+}
+
+// Import the blocks of the archive file through InsertChain. Blocks
+// the chain already has are skipped, so an interrupted import can be
+// run again to resume it.
+func (blc *BlockChain) ImportChain(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	var imported uint64
+	stream := rlp.NewStream(zr, 0)
+	for {
+		rlpBlock := &types.RlpBlock{}
+		if err := stream.Decode(rlpBlock); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("read archive failed after %d imported blocks! %s", imported, err.Error())
+		}
+		block := rlpBlock.TranslateToBlock()
+		lastHeight := blc.GetLastHeight()
+		if block.Height <= lastHeight {
+			header, err := blc.storage.GetHeaderByHeight(block.Height)
+			if err != nil {
+				return err
+			}
+			if header.Hash != block.Hash {
+				return fmt.Errorf("block %d of the archive %s is different from the local block %s", block.Height, block.Hash.String(), header.Hash.String())
+			}
+			continue
+		}
+		if block.Height != lastHeight+1 {
+			return fmt.Errorf("archive is missing blocks from %d to %d", lastHeight+1, block.Height-1)
+		}
+		if err := blc.InsertChain(block); err != nil {
+			return fmt.Errorf("insert block %d failed! %s", block.Height, err.Error())
+		}
+		imported++
+		if imported%archiveProgress == 0 {
+			log.Info("Importing blocks", "height", block.Height, "imported", imported)
+		}
+	}
+	log.Info("Import blocks completed", "imported", imported, "height", blc.GetLastHeight())
+	return nil
+}
+
+// The end of the complete members of an archive and the header of
+// their last block, the header is nil if there is no complete member
+type archiveTail struct {
+	end    int64
+	header *types.Header
+}
+
+// Count the bytes read from the file. It is a byte reader, so gzip
+// reads it directly and the count is exactly the end of a member.
+type countingReader struct {
+	r *bufio.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	b, err := c.r.ReadByte()
+	if err == nil {
+		c.n++
+	}
+	return b, err
+}
+
+// Read the archive file member by member up to the first incomplete one
+func readArchiveTail(path string) (*archiveTail, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tail := &archiveTail{}
+	cr := &countingReader{r: bufio.NewReader(file)}
+	zr, err := gzip.NewReader(cr)
+	for err == nil {
+		zr.Multistream(false)
+		var header *types.Header
+		stream := rlp.NewStream(zr, 0)
+		for {
+			rlpBlock := &types.RlpBlock{}
+			if err = stream.Decode(rlpBlock); err != nil {
+				break
+			}
+			header = rlpBlock.Header
+		}
+		if err == io.EOF {
+			// The end of the rlp stream hides the errors of the
+			// gzip trailer, they are returned again by the reader
+			_, err = io.Copy(ioutil.Discard, zr)
+		}
+		if err != nil && err != io.EOF {
+			// The member is truncated or damaged
+			break
+		}
+		tail.end = cr.n
+		if header != nil {
+			tail.header = header
+		}
+		err = zr.Reset(cr)
+	}
+	if err != io.EOF && err != io.ErrUnexpectedEOF && err != gzip.ErrChecksum {
+		return nil, err
+	}
+	if err != io.EOF {
+		log.Warn("Cut off the incomplete end of the archive", "file", path, "size", tail.end)
+	}
+	return tail, nil
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/uworldao/UWORLD/param"
+)
+
+func fileSize(t *testing.T, path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Size()
+}
+
+func checkArchiveTail(t *testing.T, path string, end int64, height uint64) {
+	t.Helper()
+	tail, err := readArchiveTail(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tail.end != end || tail.header == nil || tail.header.Height != height {
+		t.Fatalf("tail at %d, want the end %d of the member with block %d", tail.end, end, height)
+	}
+}
+
+func TestReadArchiveTail(t *testing.T) {
+	c := newTestChain(t)
+	defer c.remove()
+	c.mine(testGenesisTime+10, newTestTransfer(t, 1, testReceiver, 1*param.AtomsPerCoin))
+	c.mine(testGenesisTime + 20)
+
+	// Two members, the second one resumes the export
+	path := filepath.Join(c.dir, "blocks.archive")
+	if err := c.ExportChain(path, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	first := fileSize(t, path)
+	c.mine(testGenesisTime+30, newTestTransfer(t, 2, testReceiver, 2*param.AtomsPerCoin))
+	c.mine(testGenesisTime + 40)
+	if err := c.ExportChain(path, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	second := fileSize(t, path)
+	checkArchiveTail(t, path, second, 4)
+
+	// A member without its trailer or cut in its header is not complete
+	for _, size := range []int64{second - 4, first + 5} {
+		if err := os.Truncate(path, size); err != nil {
+			t.Fatal(err)
+		}
+		checkArchiveTail(t, path, first, 2)
+	}
+
+	// The export cuts the incomplete member and writes it again
+	if err := c.ExportChain(path, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if size := fileSize(t, path); size != second {
+		t.Fatalf("archive of %d bytes, want %d", size, second)
+	}
+	r := newTestChain(t)
+	defer r.remove()
+	if err := r.ImportChain(path); err != nil {
+		t.Fatal(err)
+	}
+	if r.GetLastHeight() != 4 {
+		t.Fatalf("imported to %d, want 4", r.GetLastHeight())
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/uworldao/UWORLD/database/blcdb"
 	log "github.com/uworldao/UWORLD/log/log15"
 	"github.com/uworldao/UWORLD/param"
+	"runtime"
 	"sync"
 )
 
@@ -35,12 +36,19 @@ type BlockChain struct {
 	// to be deleted by tx pool
 	removeTxsCh chan types.Transactions
 
+	// Transactions of the blocks reverted by a reorganization that
+	// are not in the new branch, the tx pool takes them back
+	revertedTxsCh chan types.Transactions
+
 	// Confirmed valid block height
 	confirmedHeight uint64
+
+	// Maintain the address transaction history index
+	addrIndex bool
 }
 
 func NewBlockChain(dataDir string, consensus consensus.IConsensus, stateUpdateCh chan struct{},
-	removeTxsCh chan types.Transactions, accountState _interface.IAccountState, contractState _interface.IContractState, runner *runner2.ContractRunner) (*BlockChain, error) {
+	removeTxsCh, revertedTxsCh chan types.Transactions, accountState _interface.IAccountState, contractState _interface.IContractState, runner *runner2.ContractRunner) (*BlockChain, error) {
 	blockChain := &BlockChain{}
 	storage := blcdb.NewBlockChainStorage(dataDir + "/" + blockChainStorage)
 	err := storage.Open()
@@ -54,32 +62,31 @@ func NewBlockChain(dataDir string, consensus consensus.IConsensus, stateUpdateCh
 	blockChain.stateUpdateCh = stateUpdateCh
 	blockChain.consensus = consensus
 	blockChain.removeTxsCh = removeTxsCh
+	blockChain.revertedTxsCh = revertedTxsCh
 	blockChain.runner = runner
-	stateRoot, _ := blockChain.storage.GetStateRoot()
-	err = blockChain.accountState.InitTrie(stateRoot)
-	if err != nil {
+	if err := blockChain.recoverCommit(); err != nil {
 		return nil, err
 	}
-	blockChain.stateRoot = blockChain.accountState.RootHash()
-
+	stateRoot, _ := blockChain.storage.GetStateRoot()
 	contractRoot, _ := blockChain.storage.GetContractRoot()
-	err = blockChain.contractState.InitTrie(contractRoot)
-	if err != nil {
-		return nil, err
-	}
-	blockChain.contractRoot = blockChain.contractState.RootHash()
-
 	consensusRoot, _ := blockChain.storage.GetConsensusRoot()
-	err = blockChain.consensus.InitTrie(consensusRoot)
-	if err != nil {
+	if err := blockChain.initTries(stateRoot, contractRoot, consensusRoot); err != nil {
 		return nil, err
 	}
-	blockChain.consensusRoot = blockChain.consensus.RootHash()
 
 	if blockChain.currentHeight, err = blockChain.storage.GetLastHeight(); err != nil {
 		if err := blockChain.SaveGenesisBlock(consensus.GetGenesisBlock()); err != nil {
 			return nil, err
 		}
+	} else {
+		// The data directory may belong to another network
+		genesis, err := blockChain.GetBlockByHeight(0)
+		if err != nil {
+			return nil, err
+		}
+		if err := blockChain.VerifyGenesis(genesis); err != nil {
+			return nil, err
+		}
 	}
 
 	blockChain.UpdateConfirmedHeight(consensus.GetConfirmedBlockHeader(blockChain).Height)
@@ -219,6 +226,14 @@ func (blc *BlockChain) GetAddressVote(address hasharry.Address) uint64 {
 	return vote
 }
 
+func (blc *BlockChain) GetAddressBond(address hasharry.Address) uint64 {
+	return blc.accountState.GetAccountState(address).GetBond()
+}
+
+func (blc *BlockChain) GetAddressHolding(address hasharry.Address) uint64 {
+	return blc.accountState.GetAccountState(address).GetHolding(param.Token.String())
+}
+
 func (blc *BlockChain) GetTermLastHash(term uint64) (hasharry.Hash, error) {
 	return blc.storage.GetTermLastHash(term)
 }
@@ -227,31 +242,34 @@ func (blc *BlockChain) InsertChain(block *types.Block) error {
 	blc.insertMutex.Lock()
 	defer blc.insertMutex.Unlock()
 
-	if _, err := blc.GetBlockByHeight(block.Height - 1); err != nil {
+	if _, err := blc.storage.GetHeader(block.Hash); err == nil {
+		return ErrDuplicateBlock
+	}
+	head, err := blc.CurrentHeader()
+	if err != nil {
 		return err
 	}
+	if !block.ParentHash.IsEqual(head.Hash) {
+		return blc.insertSideBlock(block)
+	}
 	return blc.dealBlock(block)
 }
 
-func (blc *BlockChain) saveBlock(block *types.Block) error {
+// The tries and the block data are kept in separate databases. The tries
+// are committed first, their nodes are addressed by hash and stay unreachable
+// until the new roots are stored together with the block in one atomic batch.
+// The commit journal marks the block as in progress until that batch lands.
+func (blc *BlockChain) saveBlock(block *types.Block, receipts types.Receipts) error {
 	blc.mutex.Lock()
 	defer blc.mutex.Unlock()
 
-	blc.storage.UpdateHeader(block.Header)
-	blc.storage.UpdateTransactions(block.TxRoot, block.Body.TranslateToRlpBody().Transactions)
-	blc.storage.UpdateTxLocation(block.GetTxsLocations())
-	blc.storage.UpdateHeightHash(block.Height, block.Hash)
-	blc.storage.UpdateHistoryConfirmedHeight(block.Height, blc.confirmedHeight)
-	blc.storage.UpdateTermLastHash(block.Term, block.Hash)
-	blc.stateRoot, _ = blc.accountState.StateTrieCommit()
-	blc.contractRoot, _ = blc.contractState.ContractTrieCommit()
-	blc.consensusRoot, _ = blc.consensus.Commit()
-	blc.storage.UpdateStateRoot(blc.stateRoot)
-	blc.storage.UpdateContractRoot(blc.contractRoot)
-	blc.storage.UpdateConsensusRoot(blc.consensusRoot)
-
-	blc.currentHeight = block.Height
-	blc.storage.UpdateLastHeight(block.Height)
+	if err := blc.commitBlock(block, receipts, blc.confirmedHeight); err != nil {
+		log.Error("Save block failed", "height", block.Height, "hash", block.HashString(), "error", err)
+		if err := blc.initTries(blc.stateRoot, blc.contractRoot, blc.consensusRoot); err != nil {
+			log.Error("Restore tries failed", "height", blc.currentHeight, "error", err)
+		}
+		return err
+	}
 
 	/*log.Info("Save block", "height", block.Height, "hash", block.HashString(),
 	"state root", block.StateRoot.String(),
@@ -262,6 +280,87 @@ func (blc *BlockChain) saveBlock(block *types.Block) error {
 	return nil
 }
 
+func (blc *BlockChain) commitBlock(block *types.Block, receipts types.Receipts, confirmedHeight uint64) error {
+	if err := blc.storage.UpdateCommitJournal(block.Height, block.Hash); err != nil {
+		return err
+	}
+	stateRoot, err := blc.accountState.StateTrieCommit()
+	if err != nil {
+		return err
+	}
+	contractRoot, err := blc.contractState.ContractTrieCommit()
+	if err != nil {
+		return err
+	}
+	consensusRoot, err := blc.consensus.Commit()
+	if err != nil {
+		return err
+	}
+	if err := blc.storage.CommitBlock(block, receipts, confirmedHeight, blc.indexTxs(block), stateRoot, contractRoot, consensusRoot); err != nil {
+		return err
+	}
+	blc.stateRoot = stateRoot
+	blc.contractRoot = contractRoot
+	blc.consensusRoot = consensusRoot
+	blc.currentHeight = block.Height
+	return nil
+}
+
+// Repair the storage if the last block commit was interrupted. The stored tip
+// is only replaced by an atomic batch, so it is consistent unless it was written
+// by a version without the journal. In that case step back until the tries of
+// the tip can be loaded, the header of a block holds the roots of its parent.
+func (blc *BlockChain) recoverCommit() error {
+	height, hash, err := blc.storage.GetCommitJournal()
+	if err != nil {
+		return nil
+	}
+	lastHeight, err := blc.storage.GetLastHeight()
+	if err != nil {
+		return blc.storage.DeleteCommitJournal()
+	}
+	log.Warn("Recover interrupted block commit", "height", height, "hash", hash.String(), "last height", lastHeight)
+
+	for {
+		stateRoot, _ := blc.storage.GetStateRoot()
+		contractRoot, _ := blc.storage.GetContractRoot()
+		consensusRoot, _ := blc.storage.GetConsensusRoot()
+		if err := blc.initTries(stateRoot, contractRoot, consensusRoot); err == nil {
+			break
+		} else if lastHeight == 0 {
+			return fmt.Errorf("recover block commit failed! %s", err.Error())
+		}
+		header, err := blc.storage.GetHeaderByHeight(lastHeight)
+		if err != nil {
+			return fmt.Errorf("recover block commit failed! Can not find block %d", lastHeight)
+		}
+		lastHeight--
+		if err := blc.storage.UpdateTip(lastHeight, header.StateRoot, header.ContractRoot, header.ConsensusRoot); err != nil {
+			return err
+		}
+		log.Warn("Roll back block chain tip", "height", lastHeight)
+	}
+	return blc.storage.DeleteCommitJournal()
+}
+
+func (blc *BlockChain) initTries(stateRoot, contractRoot, consensusRoot hasharry.Hash) error {
+	if err := blc.accountState.InitTrie(stateRoot); err != nil {
+		return err
+	}
+	blc.stateRoot = blc.accountState.RootHash()
+
+	if err := blc.contractState.InitTrie(contractRoot); err != nil {
+		return err
+	}
+	blc.contractRoot = blc.contractState.RootHash()
+
+	if err := blc.consensus.InitTrie(consensusRoot); err != nil {
+		return err
+	}
+	blc.consensusRoot = blc.consensus.RootHash()
+	return nil
+}
+
 func (blc *BlockChain) SaveGenesisBlock(block *types.Block) error {
 	blc.mutex.Lock()
 	defer blc.mutex.Unlock()
@@ -269,73 +368,110 @@ func (blc *BlockChain) SaveGenesisBlock(block *types.Block) error {
 	if err := blc.VerifyGenesis(block); err != nil {
 		return err
 	}
-	blc.updateGenesisState(block)
-	blc.storage.UpdateHeader(block.Header)
-	blc.storage.UpdateTransactions(block.TxRoot, block.Body.TranslateToRlpBody().Transactions)
-	blc.storage.UpdateTxLocation(block.GetTxsLocations())
-	blc.storage.UpdateHeightHash(block.Height, block.Hash)
-	blc.storage.UpdateLastHeight(block.Height)
-	blc.storage.UpdateHistoryConfirmedHeight(block.Height, 0)
+	if err := blc.updateGenesisState(block); err != nil {
+		return err
+	}
 	blc.consensus.SetConfirmedHeader(block.Header)
-	blc.stateRoot, _ = blc.accountState.StateTrieCommit()
-	blc.contractRoot, _ = blc.contractState.ContractTrieCommit()
-	blc.consensusRoot, _ = blc.consensus.Commit()
-
-	blc.storage.UpdateConsensusRoot(blc.consensusRoot)
-	blc.currentHeight = block.Height
+	if err := blc.commitBlock(block, nil, 0); err != nil {
+		return err
+	}
 	log.Info("Save block", "height", block.Height, "hash", block.HashString(),
 		"state", block.StateRoot.String(), "signer", block.Signer.String(), "txcount", block.Transactions.Len(),
 		"time", block.Time, "term", block.Term)
 	return nil
 }
 
-func (blc *BlockChain) updateState(block *types.Block) error {
+func (blc *BlockChain) updateState(block *types.Block) (types.Receipts, error) {
+	origin, err := blc.accountState.StateAt(blc.StateRoot(), blc.GetConfirmedHeight())
+	if err != nil {
+		return nil, err
+	}
+	receipts := newReceiptBuilder(origin, blc.accountState, blc.contractState)
 	for _, tx := range block.Body.Transactions {
-		switch tx.GetTxType() {
-		case types.Transfer_:
-			if err := blc.accountState.UpdateTransferFrom(tx, block.Height); err != nil {
-				return err
-			}
-			if err := blc.accountState.UpdateTransferTo(tx, block.Height); err != nil {
-				return err
-			}
-		case types.TransferV2_:
-			if err := blc.accountState.UpdateTransferV2From(tx, block.Height); err != nil {
-				return err
-			}
-			if err := blc.accountState.UpdateTransferV2To(tx, block.Height); err != nil {
-				return err
-			}
-		case types.Contract_:
-			if err := blc.accountState.UpdateContractFrom(tx, block.Height); err != nil {
-				return err
-			}
-			if err := blc.accountState.TxContractMint(tx, block.Height); err != nil {
-				return err
-			}
-			blc.contractState.UpdateContract(tx, block.Height)
-		case types.ContractV2_:
-			if err := blc.accountState.UpdateContractFrom(tx, block.Height); err != nil {
-				return err
-			}
-			if err := blc.runner.RunContract(tx, block.Height, block.Time); err != nil {
-				return err
-			}
-			/*case types.VoteToCandidate:
-				fallthrough
-			case types.LoginCandidate_:
-				fallthrough
-			case types.LogoutCandidate:
-				if err := blc.accountState.UpdateFrom(tx, block.Height); err != nil {
-					return err
-				}*/
+		if err := applyTx(blc.accountState, blc.contractState, blc.runner, tx, block.Height, block.Time); err != nil {
+			return nil, err
 		}
-
+		receipts.add(tx, block.Height)
 	}
 	if err := blc.accountState.UpdateFees(block.Body.Transactions.SumFees(), block.Height); err != nil {
-		return err
+		return nil, err
+	}
+	if err := blc.accountState.UpdateConsumption(block.Body.Transactions.SumConsumption(), block.Height); err != nil {
+		return nil, err
+	}
+	return receipts.receipts, nil
+}
+
+// Apply the changes of a transaction to the states
+func applyTx(accountState _interface.IAccountState, contractState _interface.IContractState, runner *runner2.ContractRunner,
+	tx types.ITransaction, height, blockTime uint64) error {
+	switch tx.GetTxType() {
+	case types.Transfer_:
+		if err := accountState.UpdateTransferFrom(tx, height); err != nil {
+			return err
+		}
+		if err := accountState.UpdateTransferTo(tx, height); err != nil {
+			return err
+		}
+	case types.TransferV2_:
+		if err := accountState.UpdateTransferV2From(tx, height); err != nil {
+			return err
+		}
+		if err := accountState.UpdateTransferV2To(tx, height); err != nil {
+			return err
+		}
+	case types.LockedTransfer_:
+		if err := accountState.UpdateTransferV2From(tx, height); err != nil {
+			return err
+		}
+		if err := accountState.UpdateLockedTransferTo(tx, height); err != nil {
+			return err
+		}
+	case types.Contract_:
+		if err := accountState.UpdateContractFrom(tx, height); err != nil {
+			return err
+		}
+		if err := accountState.TxContractMint(tx, height); err != nil {
+			return err
+		}
+		contractState.UpdateContract(tx, height)
+	case types.ContractV2_:
+		if err := accountState.UpdateContractFrom(tx, height); err != nil {
+			return err
+		}
+		if err := runner.RunContract(tx, height, blockTime); err != nil {
+			return err
+		}
+	case types.LoginCandidate_, types.VoteToCandidate_, types.LogoutCandidate_:
+		// The fees, the nonce and the bond, the candidates and the
+		// votes are updated by the consensus
+		if err := accountState.UpdateCandidateFrom(tx, height); err != nil {
+			return err
+		}
+	case types.DoubleSign_:
+		if err := accountState.UpdateContractFrom(tx, height); err != nil {
+			return err
+		}
+		// The signer is jailed by the consensus
+		body := tx.GetTxBody().(*types.DoubleSignBody)
+		if err := accountState.SlashBond(body.Signer(), param.DoubleSignSlash); err != nil {
+			return err
+		}
+	case types.SetCommission_:
+		if err := accountState.UpdateContractFrom(tx, height); err != nil {
+			return err
+		}
+	case types.ClaimReward_:
+		if err := accountState.UpdateContractFrom(tx, height); err != nil {
+			return err
+		}
+		// The accrued rewards are reduced by the consensus
+		body := tx.GetTxBody().(*types.ClaimRewardBody)
+		if err := accountState.Mint(tx.From(), param.Token, body.Amount, height); err != nil {
+			return err
+		}
 	}
-	return blc.accountState.UpdateConsumption(block.Body.Transactions.SumConsumption(), block.Height)
+	return nil
 }
 
 func (blc *BlockChain) updateGenesisState(block *types.Block) error {
@@ -349,14 +485,18 @@ func (blc *BlockChain) updateGenesisState(block *types.Block) error {
 			if err := blc.accountState.UpdateTransferV2To(tx, block.Height); err != nil {
 				return err
 			}
+		case types.LockedTransfer_:
+			if err := blc.accountState.UpdateLockedTransferTo(tx, block.Height); err != nil {
+				return err
+			}
 		}
 	}
-	blc.consensus.UpdateConsensus(block)
+	blc.consensus.UpdateConsensus(blc, block)
 	return nil
 }
 
 func (blc *BlockChain) updateConsensus(block *types.Block) error {
-	blc.consensus.UpdateConsensus(block)
+	blc.consensus.UpdateConsensus(blc, block)
 	return nil
 }
 
@@ -376,14 +516,23 @@ func (blc *BlockChain) ValidationBlockHash(header *types.Header) (bool, error) {
 	return localHeader.Hash.IsEqual(header.Hash), nil
 }
 
+// Check the genesis block against the genesis of the network
 func (blc *BlockChain) VerifyGenesis(block *types.Block) error {
+	genesis := param.GetGenesis()
+	if block.Transactions.Len() != len(genesis.Candidates)+len(genesis.Alloc) {
+		return fmt.Errorf("wrong genesis transactions")
+	}
 	var sumCoins uint64
 	for _, tx := range block.Transactions {
 		sumCoins += tx.GetTxBody().GetAmount()
 	}
-	if sumCoins != param.GenesisCoins {
+	if sumCoins != genesis.SumAlloc() {
 		return fmt.Errorf("wrong genesis coins")
 	}
+	if expect := blc.consensus.GetGenesisBlock(); !block.Hash.IsEqual(expect.Hash) {
+		return fmt.Errorf("genesis block %s is different from the genesis %s of the network %s",
+			block.Hash.String(), expect.Hash.String(), genesis.ChainId)
+	}
 	return nil
 }
 
@@ -412,7 +561,7 @@ func (blc *BlockChain) verifyBlock(block *types.Block) error {
 		log.Warn("consensus root wrong", "height", block.Header.Height, "consensus root", block.Header.ConsensusRoot.String())
 		return errors.New("wrong consensus root")
 	}
-	if err := blc.verifyTxs(block.Transactions, block.Height); err != nil {
+	if err := blc.verifyTxs(block.Transactions, block.Header); err != nil {
 		return err
 	}
 	parent, err := blc.GetHeaderByHash(block.ParentHash)
@@ -428,16 +577,15 @@ func (blc *BlockChain) verifyBlock(block *types.Block) error {
 	return nil
 }
 
-func (blc *BlockChain) verifyTx(tx types.ITransaction, blockHeight uint64) error {
-	if err := tx.VerifyTx(); err != nil {
-		return err
-	}
-
-	if err := blc.consensus.VerifyTx(tx); err != nil {
+// Verify the transaction against the current state, it must
+// be called in the order of the transactions in the block. The
+// sender accounts changed by the earlier transactions are in pending.
+func (blc *BlockChain) verifyTxState(tx types.ITransaction, blockHeight uint64, pending map[hasharry.Address]types.IAccount) error {
+	if err := blc.consensus.VerifyTx(tx, blockHeight); err != nil {
 		return err
 	}
 
-	if err := blc.accountState.VerifyState(tx); err != nil {
+	if err := blc.accountState.VerifyPendingState(tx, pending, blockHeight); err != nil {
 		return err
 	}
 
@@ -445,143 +593,186 @@ func (blc *BlockChain) verifyTx(tx types.ITransaction, blockHeight uint64) error
 		return err
 	}
 
-	if err := blc.verifyBusiness(tx, blockHeight); err != nil {
-		return err
-	}
-
 	return nil
 }
 
-func (blc *BlockChain) verifyBusiness(tx types.ITransaction, blockHeight uint64) error {
-	switch tx.GetTxType() {
-	case types.Transfer_:
-		account := blc.accountState.GetAccountState(tx.From())
-		return account.VerifyNonce(tx.GetNonce())
-	case types.TransferV2_:
-		account := blc.accountState.GetAccountState(tx.From())
-		return account.VerifyNonce(tx.GetNonce())
-	}
-	return nil
-}
-
-func (blc *BlockChain) verifyTxs(txs types.Transactions, blockHeight uint64) error {
-	address := make(map[string]bool)
-	for _, tx := range txs {
-		if tx.IsCoinBase() {
-			if err := blc.verifyCoinBaseTx(tx, blockHeight, 0); err != nil {
-				return err
+func (blc *BlockChain) verifyTxs(txs types.Transactions, header *types.Header) error {
+	blockHeight := header.Height
+	errs := blc.verifyTxsStateless(txs, blockHeight, blc.coinBaseRate(header))
+	pending := make(map[hasharry.Address]types.IAccount)
+	once := make(map[types.TransactionType]bool)
+	senders := make(map[types.TransactionType]map[hasharry.Address]bool)
+	for i, tx := range txs {
+		if errs[i] != nil {
+			if !tx.IsCoinBase() {
+				blc.removeTxsCh <- types.Transactions{tx}
 			}
-		} else {
-			if err := blc.verifyTx(tx, blockHeight); err != nil {
+			return errs[i]
+		}
+		// The minimum number of candidates and the evidences are verified
+		// against the consensus state before the block
+		if types.OncePerBlock(tx.GetTxType()) {
+			if once[tx.GetTxType()] {
+				return fmt.Errorf("more than one transaction of type %d in the block", tx.GetTxType())
+			}
+			once[tx.GetTxType()] = true
+		}
+		// The claimed amount and the candidacy are verified against the
+		// consensus state before the block
+		if types.OncePerSender(tx.GetTxType()) {
+			if senders[tx.GetTxType()][tx.From()] {
+				return fmt.Errorf("more than one transaction of type %d from %s in the block", tx.GetTxType(), tx.From().String())
+			}
+			if senders[tx.GetTxType()] == nil {
+				senders[tx.GetTxType()] = make(map[hasharry.Address]bool)
+			}
+			senders[tx.GetTxType()][tx.From()] = true
+		}
+		if !tx.IsCoinBase() {
+			if err := blc.verifyTxState(tx, blockHeight, pending); err != nil {
 				blc.removeTxsCh <- types.Transactions{tx}
 				return err
 			}
 		}
-		from := tx.From().String()
-		if _, ok := address[from]; !ok {
-			address[from] = true
-		} else {
-			return errors.New("one address in a block can only send one transaction")
-		}
 	}
 	return nil
 }
 
-func (blc *BlockChain) verifyCoinBaseTx(tx types.ITransaction, height, sumFees uint64) error {
-	return tx.VerifyCoinBaseTx(height, sumFees)
+// Hash, signature and format checks do not depend on the state, they are
+// run by a pool of workers. The error of each transaction is returned at
+// its index, so the first error in block order can still be reported.
+func (blc *BlockChain) verifyTxsStateless(txs types.Transactions, blockHeight, rate uint64) []error {
+	errs := make([]error, len(txs))
+	workers := runtime.NumCPU()
+	if workers > len(txs) {
+		workers = len(txs)
+	}
+	jobs := make(chan int, len(txs))
+	for i := range txs {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if txs[i].IsCoinBase() {
+					errs[i] = blc.verifyCoinBaseTx(txs[i], blockHeight, 0, rate)
+				} else {
+					errs[i] = txs[i].VerifyTx(blockHeight)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func (blc *BlockChain) verifyCoinBaseTx(tx types.ITransaction, height, sumFees, rate uint64) error {
+	return tx.VerifyCoinBaseTx(height, sumFees, rate)
+}
+
+// The commission rate of the signer of the block, the coinbase pays
+// the whole reward before the reward sharing
+func (blc *BlockChain) coinBaseRate(header *types.Header) uint64 {
+	if !param.IsActive(param.RewardSharing, header.Height) {
+		return types.MaxCommission
+	}
+	return blc.consensus.GetCommission(header.Signer, header.Term)
 }
 
 // When a serious inconsistency occurs, it can fall back to any height
 // below the effective height
 func (blc *BlockChain) FallBackTo(height uint64) error {
-	confirmedHeight := blc.confirmedHeight
+	blc.insertMutex.Lock()
+	defer blc.insertMutex.Unlock()
+
+	confirmedHeight := blc.GetConfirmedHeight()
 	if height > confirmedHeight && height != 0 {
 		err := fmt.Sprintf("the height of the fallback must be less than or equal %d and greater than %d", confirmedHeight, 0)
 		log.Error("Fall back to block height", "height", height, "error", err)
 		return errors.New(err)
 	}
+	log.Warn("Fall back to block height", "height", height)
+	if err := blc.revertTo(height); err != nil {
+		log.Error("Fall back to block height", "height", height, "error", err)
+		return fmt.Errorf("fall back to block height %d failed! %s", height, err.Error())
+	}
+	return nil
+}
 
-	var curBlockHeight, nextBlockHeight uint64
-	curStateRoot := hasharry.Hash{}
-	curContractRoot := hasharry.Hash{}
-	curConsensusRoot := hasharry.Hash{}
-
-	nextBlockHeight = height + 1
-	curBlockHeight = height
-
-	// set new confirmed height and header
-	hisConfirmedHeight, err := blc.GetHistoryConfirmedHeight(curBlockHeight)
+// Revert the tries to the state after the block of the height, and
+// the confirmed block to the one recorded at that height. The blocks
+// above the height are kept as side blocks.
+func (blc *BlockChain) revertTo(height uint64) error {
+	lastHeight := blc.GetLastHeight()
+	if height >= lastHeight {
+		return fmt.Errorf("height %d is not below the last height %d", height, lastHeight)
+	}
+	fork, err := blc.GetHeaderByHeight(height)
 	if err != nil {
-		log.Error("Fall back to block height", "height", height, "error", "can not find history confirmed height")
-		return fmt.Errorf("fall back to block height %d failed! Can not find history confirmed height", height)
+		return fmt.Errorf("can not find block %d", height)
 	}
-	hisHeader, err := blc.GetHeaderByHeight(hisConfirmedHeight)
+	// The header of the next block holds the trie roots after the fork block
+	next, err := blc.GetHeaderByHeight(height + 1)
 	if err != nil {
-		log.Error("Fall back to block height", "height", height, "error", "can not find block")
-		return fmt.Errorf("fall back to block height %d failed! Can not find block %d", height, hisConfirmedHeight)
+		return fmt.Errorf("can not find block %d", height+1)
 	}
-	blc.consensus.SetConfirmedHeader(hisHeader)
-
-	log.Warn("Fall back to block height", "height", height)
-	header, err := blc.GetHeaderByHeight(nextBlockHeight)
+	hisConfirmedHeight, err := blc.GetHistoryConfirmedHeight(height)
+	if err != nil {
+		return errors.New("can not find history confirmed height")
+	}
+	hisHeader, err := blc.GetHeaderByHeight(hisConfirmedHeight)
 	if err != nil {
-		log.Error("Fall back to block height", "height", height, "error", "can not find block")
-		return fmt.Errorf("fall back to block height %d failed! Can not find block %d", height, nextBlockHeight)
+		return fmt.Errorf("can not find block %d", hisConfirmedHeight)
 	}
+	reverted := make([]*types.Block, 0, lastHeight-height)
+	for h := height + 1; h <= lastHeight; h++ {
+		block, err := blc.GetBlockByHeight(h)
+		if err != nil {
+			return fmt.Errorf("can not find block %d", h)
+		}
+		reverted = append(reverted, block)
+	}
+	blc.consensus.SetConfirmedHeader(hisHeader)
 
 	blc.mutex.Lock()
 	defer blc.mutex.Unlock()
 
+	// The contract state must still contain the reverted blocks
+	addrTxs := blc.unindexTxs(reverted)
 	blc.confirmedHeight = hisConfirmedHeight
 	blc.accountState.UpdateConfirmedHeight(hisConfirmedHeight)
-
-	// fall back to pre state root
-	curStateRoot = header.StateRoot
-	err = blc.accountState.InitTrie(curStateRoot)
-	if err != nil {
-		log.Error("Fall back to block height", "height", height, "error", "init state trie failed")
-		return fmt.Errorf("fall back to block height %d failed! nit state trie failed", height)
+	if err := blc.initTries(next.StateRoot, next.ContractRoot, next.ConsensusRoot); err != nil {
+		return fmt.Errorf("init trie failed! %s", err.Error())
 	}
-	blc.stateRoot = blc.accountState.RootHash()
-
-	// fall back to pre contract root
-	curContractRoot = header.ContractRoot
-	err = blc.contractState.InitTrie(curContractRoot)
-	if err != nil {
-		log.Error("Fall back to block height", "height", height, "error", "init contract trie failed")
-		return fmt.Errorf("fall back to block height %d failed! nit contract trie failed", height)
-	}
-	blc.contractRoot = blc.contractState.RootHash()
-
-	// fall back to consensus root
-	curConsensusRoot = header.ConsensusRoot
-	err = blc.consensus.InitTrie(curConsensusRoot)
-	if err != nil {
-		log.Error("Fall back to block height", "height", height, "error", "init consensus trie failed")
-		return fmt.Errorf("fall back to block height %d failed! init consensus trie failed", height)
+	if err := blc.storage.RevertTip(fork, reverted, addrTxs, blc.stateRoot, blc.contractRoot, blc.consensusRoot); err != nil {
+		return err
 	}
-	blc.consensusRoot = blc.consensus.RootHash()
-
-	blc.currentHeight = curBlockHeight
-	blc.storage.UpdateLastHeight(curBlockHeight)
+	blc.currentHeight = height
 	return nil
 }
 
-func (blc *BlockChain) FallBack() {
-	err := blc.FallBackTo(blc.GetConfirmedHeight())
-	if err != nil {
-		blc.FallBackTo(blc.GetConfirmedHeight() - param.MaxWinnerSize)
-	}
-}
-
 func (blc *BlockChain) dealBlock(block *types.Block) error {
 	err := blc.verifyBlock(block)
 	if err == nil {
-		if err := blc.updateState(block); err != nil {
+		receipts, err := blc.updateState(block)
+		if err != nil {
+			// Drop the changes of the transactions executed before the failed one
+			blc.mutex.Lock()
+			if err := blc.initTries(blc.stateRoot, blc.contractRoot, blc.consensusRoot); err != nil {
+				log.Error("Restore tries failed", "height", blc.currentHeight, "error", err)
+			}
+			blc.mutex.Unlock()
 			return err
 		}
 		blc.updateConsensus(block)
-		blc.saveBlock(block)
+		if err := blc.saveBlock(block, receipts); err != nil {
+			return err
+		}
 		blc.stateUpdateCh <- struct{}{}
 		return nil
 	}
@@ -0,0 +1,329 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/consensus/devseal"
+	runner2 "github.com/uworldao/UWORLD/core/runner"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/services/accountstate"
+	"github.com/uworldao/UWORLD/services/contractstate"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+const (
+	testGenesisTime = 1600000000
+	testAlloc       = 1000 * param.AtomsPerCoin
+)
+
+var (
+	// The authority seals the blocks, the sender has the premine and
+	// pays the receiver
+	testAuthorityKey, _ = secp256k1.PrivKeyFromBytes([]byte("core test authority key 00000000"))
+	testSenderKey, _    = secp256k1.PrivKeyFromBytes([]byte("core test sender key 00000000000"))
+	testReceiverKey, _  = secp256k1.PrivKeyFromBytes([]byte("core test receiver key 000000000"))
+	testAuthority       hasharry.Address
+	testSender          hasharry.Address
+	testReceiver        hasharry.Address
+	testGenesisOnce     sync.Once
+)
+
+// The chains of the tests run a custom network sealed by devseal
+func setTestGenesis(t *testing.T) {
+	testGenesisOnce.Do(func() {
+		testAuthority = hasharry.StringToAddress(ut.GenerateUWDAddress(param.Net, testAuthorityKey.PubKey()))
+		testSender = hasharry.StringToAddress(ut.GenerateUWDAddress(param.Net, testSenderKey.PubKey()))
+		testReceiver = hasharry.StringToAddress(ut.GenerateUWDAddress(param.Net, testReceiverKey.PubKey()))
+		g := param.DefaultGenesis()
+		g.ChainId = "coretest"
+		g.Time = testGenesisTime
+		g.MaxWinnerSize = 1
+		g.Alloc = []param.MappingInfo{{Address: testSender.String(), Amount: testAlloc}}
+		g.Candidates = []param.CandidatesInfo{{Address: testAuthority.String(),
+			PeerId: "16Uiu2HAm8dGc2gAuQG9WAdPNeXRDG1GU8wQtv4f2jdGEGkrX9Ln1"}}
+		if err := param.SetGenesis(g); err != nil {
+			panic(err)
+		}
+	})
+}
+
+type testSigner struct {
+	priv *secp256k1.PrivateKey
+}
+
+func (s *testSigner) SignHash(hash hasharry.Hash) (*types.SignScript, error) {
+	return types.Sign(s.priv, hash)
+}
+
+// A block chain on a temporary leveldb with the states and the
+// consensus of a node
+type testChain struct {
+	*BlockChain
+	t    *testing.T
+	dir  string
+	seal *devseal.DevSeal
+	// Transactions the chain sends back to the tx pool
+	reverted chan types.Transactions
+	// Notifications nobody else receives
+	stateUpdate chan struct{}
+	removed     chan types.Transactions
+}
+
+func newTestChain(t *testing.T) *testChain {
+	dir, err := ioutil.TempDir("", "blockchain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return openTestChain(t, dir)
+}
+
+func openTestChain(t *testing.T, dir string) *testChain {
+	setTestGenesis(t)
+	return openTestChainOf(t, dir, testAuthority)
+}
+
+// Open a chain that only accepts the blocks of the authority
+func openTestChainOf(t *testing.T, dir string, authority hasharry.Address) *testChain {
+	setTestGenesis(t)
+	accountState, err := accountstate.NewAccountState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contractState, err := contractstate.NewContractState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seal, err := devseal.NewDevSeal(dir, testAuthority, authority, &testSigner{testAuthorityKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &testChain{
+		t:           t,
+		dir:         dir,
+		seal:        seal,
+		reverted:    make(chan types.Transactions, 10),
+		stateUpdate: make(chan struct{}, 10),
+		removed:     make(chan types.Transactions, 10),
+	}
+	go func() {
+		for range c.stateUpdate {
+		}
+	}()
+	go func() {
+		for range c.removed {
+		}
+	}()
+	runner := runner2.NewContractRunner(accountState, contractState)
+	if c.BlockChain, err = NewBlockChain(dir, seal, c.stateUpdate, c.removed, c.reverted, accountState, contractState, runner); err != nil {
+		t.Fatal(err)
+	}
+	if err := seal.Init(c.BlockChain); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// Close the storage, the data directory is kept
+func (c *testChain) close() {
+	c.CloseStorage()
+	close(c.stateUpdate)
+	close(c.removed)
+}
+
+func (c *testChain) remove() {
+	c.close()
+	os.RemoveAll(c.dir)
+}
+
+// Build a signed block on the head of the chain, it is not inserted
+func (c *testChain) newBlock(blockTime uint64, txs ...types.ITransaction) *types.Block {
+	head, err := c.CurrentHeader()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	stateRoot, contractRoot, consensusRoot := c.TireRoot()
+	header := &types.Header{
+		Version:       types.BlockVersion,
+		ParentHash:    head.Hash,
+		StateRoot:     stateRoot,
+		ContractRoot:  contractRoot,
+		ConsensusRoot: consensusRoot,
+		Height:        head.Height + 1,
+		Time:          blockTime,
+		Term:          blockTime / param.TermInterval,
+		Signer:        testAuthority,
+	}
+	body := types.NewBody(types.Transactions(txs))
+	header.TxRoot = body.Transactions.Hash()
+	header.SetHash()
+	block := types.NewBlock(header, body)
+	if err := c.seal.Sign(block); err != nil {
+		c.t.Fatal(err)
+	}
+	return block
+}
+
+// Build a block on the head and insert it
+func (c *testChain) mine(blockTime uint64, txs ...types.ITransaction) *types.Block {
+	block := c.newBlock(blockTime, txs...)
+	if err := c.InsertChain(block); err != nil {
+		c.t.Fatalf("insert block %d: %v", block.Height, err)
+	}
+	return block
+}
+
+// All coins of the address, confirmed or not
+func (c *testChain) balance(address hasharry.Address) uint64 {
+	return c.accountState.GetAccountState(address).GetHolding(param.Token.String())
+}
+
+// A transfer of the premine signed by the sender
+func newTestTransfer(t *testing.T, nonce uint64, to hasharry.Address, amount uint64) types.ITransaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.Transfer_,
+			From:       testSender,
+			Nonce:      nonce,
+			Fees:       param.Fees,
+			Time:       testGenesisTime,
+			SignScript: &types.SignScript{},
+		},
+		TxBody: &types.TransferBody{
+			Contract: param.Token,
+			To:       to,
+			Amount:   amount,
+		},
+	}
+	if err := tx.SetHash(); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SignTx(testSenderKey); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestInsertChain(t *testing.T) {
+	c := newTestChain(t)
+	defer c.remove()
+
+	receiver := testReceiver
+	c.mine(testGenesisTime+10, newTestTransfer(t, 1, receiver, 1*param.AtomsPerCoin))
+	c.mine(testGenesisTime+20, newTestTransfer(t, 2, receiver, 2*param.AtomsPerCoin))
+	if c.GetLastHeight() != 2 {
+		t.Fatalf("last height %d, want 2", c.GetLastHeight())
+	}
+	// The receiver pays the fees of a transfer of the main coin
+	if want := 3*param.AtomsPerCoin - 2*param.Fees; c.balance(receiver) != want {
+		t.Fatalf("balance %d, want %d", c.balance(receiver), want)
+	}
+}
+
+// The tries are committed but the batch with the block is not written,
+// the reopened chain is at the previous block and takes the block again
+func TestRecoverInterruptedCommit(t *testing.T) {
+	c := newTestChain(t)
+	defer os.RemoveAll(c.dir)
+
+	receiver := testReceiver
+	c.mine(testGenesisTime+10, newTestTransfer(t, 1, receiver, 1*param.AtomsPerCoin))
+	stateRoot, contractRoot, consensusRoot := c.TireRoot()
+	block := c.newBlock(testGenesisTime+20, newTestTransfer(t, 2, receiver, 2*param.AtomsPerCoin))
+
+	if err := c.verifyBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.updateState(block); err != nil {
+		t.Fatal(err)
+	}
+	c.updateConsensus(block)
+	if err := c.storage.UpdateCommitJournal(block.Height, block.Hash); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.accountState.StateTrieCommit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.contractState.ContractTrieCommit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.consensus.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	c.close()
+
+	c = openTestChain(t, c.dir)
+	defer c.close()
+	if _, _, err := c.storage.GetCommitJournal(); err == nil {
+		t.Fatal("the commit journal is not removed")
+	}
+	if c.GetLastHeight() != 1 {
+		t.Fatalf("last height %d, want 1", c.GetLastHeight())
+	}
+	if s, ct, cs := c.TireRoot(); s != stateRoot || ct != contractRoot || cs != consensusRoot {
+		t.Fatal("the roots are not the roots of the last stored block")
+	}
+	if want := 1*param.AtomsPerCoin - param.Fees; c.balance(receiver) != want {
+		t.Fatalf("balance %d, want %d", c.balance(receiver), want)
+	}
+	if err := c.InsertChain(block); err != nil {
+		t.Fatal(err)
+	}
+	if want := 3*param.AtomsPerCoin - 2*param.Fees; c.balance(receiver) != want {
+		t.Fatalf("balance %d, want %d", c.balance(receiver), want)
+	}
+}
+
+// The evidence of a double sign burns DoubleSignSlash percent of the
+// bond of the signer, the reporter pays the fees
+func TestApplyDoubleSign(t *testing.T) {
+	c := newTestChain(t)
+	defer c.remove()
+	oldBond := param.CandidateBond
+	defer func() { param.CandidateBond = oldBond }()
+	param.CandidateBond = 100 * param.AtomsPerCoin
+
+	newTx := func(from hasharry.Address, txType types.TransactionType, body types.ITransactionBody) types.ITransaction {
+		return &types.Transaction{
+			TxHead: &types.TransactionHead{
+				TxType:     txType,
+				From:       from,
+				Nonce:      1,
+				Fees:       param.Fees,
+				Time:       testGenesisTime,
+				SignScript: &types.SignScript{},
+			},
+			TxBody: body,
+		}
+	}
+	login := newTx(testSender, types.LoginCandidate_, &types.LoginTransactionBody{})
+	if err := applyTx(c.accountState, c.contractState, c.runner, login, 1, testGenesisTime+10); err != nil {
+		t.Fatal(err)
+	}
+	if bond := c.GetAddressBond(testSender); bond != param.CandidateBond {
+		t.Fatalf("bond %d, want %d", bond, param.CandidateBond)
+	}
+
+	if err := c.accountState.Mint(testReceiver, param.Token, 1*param.AtomsPerCoin, 0); err != nil {
+		t.Fatal(err)
+	}
+	h1 := &types.Header{Height: 1, Signer: testSender, TxRoot: hasharry.BytesToHash([]byte("a"))}
+	h2 := &types.Header{Height: 1, Signer: testSender, TxRoot: hasharry.BytesToHash([]byte("b"))}
+	h1.SetHash()
+	h2.SetHash()
+	evidence := newTx(testReceiver, types.DoubleSign_, &types.DoubleSignBody{Header1: h1, Header2: h2})
+	if err := applyTx(c.accountState, c.contractState, c.runner, evidence, 2, testGenesisTime+20); err != nil {
+		t.Fatal(err)
+	}
+	if want := param.CandidateBond - param.CandidateBond*param.DoubleSignSlash/100; c.GetAddressBond(testSender) != want {
+		t.Fatalf("bond %d after the evidence, want %d", c.GetAddressBond(testSender), want)
+	}
+	if want := 1*param.AtomsPerCoin - param.Fees; c.balance(testReceiver) != want {
+		t.Fatalf("reporter balance %d, want %d", c.balance(testReceiver), want)
+	}
+}
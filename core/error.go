@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrDuplicateBlock = errors.New("duplicate block")
 	ErrNoParent       = errors.New("not find block parent header")
+	ErrBelowConfirmed = errors.New("block is not above the confirmed height")
 )
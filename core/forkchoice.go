@@ -0,0 +1,169 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	log "github.com/uworldao/UWORLD/log/log15"
+)
+
+// Fork choice rule. A branch that forks below the DPoS confirmed
+// block can never become canonical. Otherwise the higher branch wins,
+// at the same height the head produced in the earlier slot wins and
+// then the smaller hash, so that nodes seeing two racing producers
+// choose the same head.
+func isBetterBranch(head, branchHead, fork *types.Header, confirmedHeight uint64) bool {
+	if fork.Height < confirmedHeight {
+		return false
+	}
+	if branchHead.Height != head.Height {
+		return branchHead.Height > head.Height
+	}
+	if branchHead.Time != head.Time {
+		return branchHead.Time < head.Time
+	}
+	return bytes.Compare(branchHead.Hash.Bytes(), head.Hash.Bytes()) < 0
+}
+
+// Store a block that does not extend the current head, and switch
+// to its branch if the fork choice prefers it
+func (blc *BlockChain) insertSideBlock(block *types.Block) error {
+	parent, err := blc.storage.GetHeader(block.ParentHash)
+	if err != nil {
+		return ErrNoParent
+	}
+	if parent.Height+1 != block.Height {
+		return errors.New("wrong block height")
+	}
+	if block.Height <= blc.GetConfirmedHeight() {
+		return ErrBelowConfirmed
+	}
+	if !block.VerifyTxRoot() {
+		return errors.New("wrong tx root")
+	}
+	if err := blc.consensus.VerifyHeader(block.Header, parent); err != nil {
+		return err
+	}
+	// Only a block signed by a winner is stored, so a peer can not fill
+	// the storage with side blocks or force a reorganization
+	if err := blc.consensus.VerifySigner(block.Header); err != nil {
+		return err
+	}
+	if err := blc.storage.UpdateSideBlock(block); err != nil {
+		return err
+	}
+	log.Info("Save side block", "height", block.Height, "hash", block.HashString(), "signer", block.Signer.String())
+
+	fork, branch, err := blc.sideBranch(block)
+	if err != nil {
+		return err
+	}
+	head, err := blc.CurrentHeader()
+	if err != nil {
+		return err
+	}
+	if !isBetterBranch(head, block.Header, fork, blc.GetConfirmedHeight()) {
+		return nil
+	}
+	return blc.reorg(fork, branch)
+}
+
+// Find the canonical block the branch of head forks from,
+// and the side blocks from the fork up to head
+func (blc *BlockChain) sideBranch(head *types.Block) (*types.Header, []*types.Block, error) {
+	branch := []*types.Block{head}
+	parentHash := head.ParentHash
+	for {
+		header, err := blc.storage.GetHeader(parentHash)
+		if err != nil {
+			return nil, nil, ErrNoParent
+		}
+		if blc.isCanonical(header) {
+			return header, branch, nil
+		}
+		block, err := blc.GetBlockByHash(parentHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		branch = append([]*types.Block{block}, branch...)
+		parentHash = header.ParentHash
+	}
+}
+
+func (blc *BlockChain) isCanonical(header *types.Header) bool {
+	local, err := blc.GetHeaderByHeight(header.Height)
+	if err != nil {
+		return false
+	}
+	return local.Hash.IsEqual(header.Hash)
+}
+
+// Revert the state to the fork block and apply the blocks of the branch.
+// If a block of the branch is invalid, the previous canonical blocks are
+// applied again. Otherwise the transactions of the reverted blocks that
+// are not in the branch go back to the tx pool.
+func (blc *BlockChain) reorg(fork *types.Header, branch []*types.Block) error {
+	lastHeight := blc.GetLastHeight()
+	oldBlocks := make([]*types.Block, 0, lastHeight-fork.Height)
+	for height := fork.Height + 1; height <= lastHeight; height++ {
+		block, err := blc.GetBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		oldBlocks = append(oldBlocks, block)
+	}
+
+	head := branch[len(branch)-1]
+	log.Warn("Reorganize chain", "fork", fork.Height, "old head", lastHeight, "new head", head.Height, "hash", head.HashString())
+	if err := blc.revertTo(fork.Height); err != nil {
+		return err
+	}
+	for _, block := range branch {
+		if err := blc.dealBlock(block); err != nil {
+			log.Warn("Reorganize chain failed", "height", block.Height, "hash", block.HashString(), "error", err)
+			if err := blc.restoreBranch(fork.Height, oldBlocks); err != nil {
+				log.Error("Restore chain failed", "height", fork.Height, "error", err)
+			}
+			return err
+		}
+	}
+	if txs := revertedTxs(oldBlocks, branch); txs.Len() != 0 {
+		blc.revertedTxsCh <- txs
+	}
+	return nil
+}
+
+// The transactions of the reverted blocks that are not in the blocks
+// of the new branch, in the order of the reverted blocks
+func revertedTxs(reverted, branch []*types.Block) types.Transactions {
+	included := make(map[hasharry.Hash]bool)
+	for _, block := range branch {
+		for _, tx := range block.Transactions {
+			included[tx.Hash()] = true
+		}
+	}
+	txs := types.Transactions{}
+	for _, block := range reverted {
+		for _, tx := range block.Transactions {
+			if !tx.IsCoinBase() && !included[tx.Hash()] {
+				txs = append(txs, tx)
+			}
+		}
+	}
+	return txs
+}
+
+func (blc *BlockChain) restoreBranch(forkHeight uint64, blocks []*types.Block) error {
+	if blc.GetLastHeight() > forkHeight {
+		if err := blc.revertTo(forkHeight); err != nil {
+			return err
+		}
+	}
+	for _, block := range blocks {
+		if err := blc.dealBlock(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+)
+
+func TestIsBetterBranch(t *testing.T) {
+	head := &types.Header{Height: 10, Time: 100, Hash: hasharry.Hash{5}}
+	fork := &types.Header{Height: 8}
+	for name, test := range map[string]struct {
+		branchHead *types.Header
+		fork       *types.Header
+		confirmed  uint64
+		better     bool
+	}{
+		"higher":              {&types.Header{Height: 11, Time: 110, Hash: hasharry.Hash{9}}, fork, 8, true},
+		"lower":               {&types.Header{Height: 9, Time: 90, Hash: hasharry.Hash{1}}, fork, 8, false},
+		"below confirmed":     {&types.Header{Height: 12, Time: 120, Hash: hasharry.Hash{1}}, fork, 9, false},
+		"earlier slot":        {&types.Header{Height: 10, Time: 99, Hash: hasharry.Hash{9}}, fork, 8, true},
+		"later slot":          {&types.Header{Height: 10, Time: 101, Hash: hasharry.Hash{1}}, fork, 8, false},
+		"same slot smaller":   {&types.Header{Height: 10, Time: 100, Hash: hasharry.Hash{4}}, fork, 8, true},
+		"same slot greater":   {&types.Header{Height: 10, Time: 100, Hash: hasharry.Hash{6}}, fork, 8, false},
+		"same slot same hash": {&types.Header{Height: 10, Time: 100, Hash: hasharry.Hash{5}}, fork, 8, false},
+	} {
+		if better := isBetterBranch(head, test.branchHead, test.fork, test.confirmed); better != test.better {
+			t.Errorf("%s: better %v, want %v", name, better, test.better)
+		}
+	}
+}
+
+// Two chains with the same first block, a and b build different blocks
+// on it
+func newTestForks(t *testing.T) (*testChain, *testChain) {
+	a, b := newTestChain(t), newTestChain(t)
+	common := a.mine(testGenesisTime+10, newTestTransfer(t, 1, testReceiver, 1*param.AtomsPerCoin))
+	if err := b.InsertChain(common); err != nil {
+		t.Fatal(err)
+	}
+	return a, b
+}
+
+// Sign the block again after a change of its header
+func (c *testChain) resign(block *types.Block) {
+	block.Hash, block.SignScript = hasharry.Hash{}, nil
+	block.SetHash()
+	if err := c.seal.Sign(block); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func TestReorgToLongerBranch(t *testing.T) {
+	a, b := newTestForks(t)
+	defer a.remove()
+	defer b.remove()
+
+	reverted := newTestTransfer(t, 2, testReceiver, 2*param.AtomsPerCoin)
+	a.mine(testGenesisTime+20, reverted)
+	side1 := b.mine(testGenesisTime+30, newTestTransfer(t, 2, testReceiver, 5*param.AtomsPerCoin))
+	side2 := b.mine(testGenesisTime + 40)
+
+	// A block of the later slot at the same height is kept aside
+	if err := a.InsertChain(side1); err != nil {
+		t.Fatal(err)
+	}
+	if head, _ := a.CurrentHeader(); head.Hash == side1.Hash {
+		t.Fatal("switched to a branch of the same height in a later slot")
+	}
+	if err := a.InsertChain(side2); err != nil {
+		t.Fatal(err)
+	}
+	if head, _ := a.CurrentHeader(); head.Hash != side2.Hash {
+		t.Fatalf("head %s, want %s", head.Hash.String(), side2.Hash.String())
+	}
+	if s, ct, cs := a.TireRoot(); s != b.StateRoot() || ct != b.ContractRoot() || cs != b.ConsensusRoot() {
+		t.Fatal("the state is not the state of the branch")
+	}
+	if want := 6*param.AtomsPerCoin - 2*param.Fees; a.balance(testReceiver) != want {
+		t.Fatalf("balance %d, want %d", a.balance(testReceiver), want)
+	}
+	select {
+	case txs := <-a.reverted:
+		if txs.Len() != 1 || txs[0].Hash() != reverted.Hash() {
+			t.Fatalf("reverted transactions %v, want the transaction of the replaced block", txs)
+		}
+	default:
+		t.Fatal("the transactions of the replaced block are not returned to the pool")
+	}
+}
+
+func TestReorgToInvalidBranch(t *testing.T) {
+	a, b := newTestForks(t)
+	defer a.remove()
+	defer b.remove()
+
+	head := a.mine(testGenesisTime+20, newTestTransfer(t, 2, testReceiver, 2*param.AtomsPerCoin))
+	stateRoot, contractRoot, consensusRoot := a.TireRoot()
+	balance := a.balance(testReceiver)
+	side1 := b.mine(testGenesisTime + 30)
+	// The block is signed by the authority, but its state root is wrong
+	invalid := b.newBlock(testGenesisTime + 40)
+	invalid.StateRoot = hasharry.Hash{1}
+	b.resign(invalid)
+
+	if err := a.InsertChain(side1); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.InsertChain(invalid); err == nil {
+		t.Fatal("a branch with an invalid block is applied")
+	}
+	if current, _ := a.CurrentHeader(); current.Hash != head.Hash {
+		t.Fatalf("head %s, want the old head %s", current.Hash.String(), head.Hash.String())
+	}
+	if s, ct, cs := a.TireRoot(); s != stateRoot || ct != contractRoot || cs != consensusRoot {
+		t.Fatal("the state of the old head is not restored")
+	}
+	if a.balance(testReceiver) != balance {
+		t.Fatalf("balance %d, want %d", a.balance(testReceiver), balance)
+	}
+	select {
+	case <-a.reverted:
+		t.Fatal("transactions are returned to the pool by a failed reorganization")
+	default:
+	}
+	// The restored chain goes on
+	a.mine(testGenesisTime+50, newTestTransfer(t, 3, testReceiver, 1*param.AtomsPerCoin))
+}
+
+func TestRejectUnsignedSideBlock(t *testing.T) {
+	a, b := newTestForks(t)
+	defer a.remove()
+	defer b.remove()
+
+	a.mine(testGenesisTime + 20)
+	// Another key signs a block of an earlier slot for the authority
+	forged := b.newBlock(testGenesisTime + 15)
+	var err error
+	if forged.SignScript, err = types.Sign(testSenderKey, forged.Hash); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.InsertChain(forged); err == nil {
+		t.Fatal("a side block that is not signed by the authority is accepted")
+	}
+	if _, err := a.GetHeaderByHash(forged.Hash); err == nil {
+		t.Fatal("a side block that is not signed by the authority is stored")
+	}
+}
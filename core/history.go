@@ -0,0 +1,67 @@
+package core
+
+import (
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/interface"
+)
+
+// Get the state, contract and consensus roots after the block of
+// the height was executed. A header records the roots its parent
+// left, so they are taken from the next header, or from the current
+// roots for the last block.
+func (blc *BlockChain) RootsAt(height uint64) (hasharry.Hash, hasharry.Hash, hasharry.Hash, error) {
+	blc.mutex.RLock()
+	defer blc.mutex.RUnlock()
+
+	if height > blc.currentHeight {
+		return hasharry.Hash{}, hasharry.Hash{}, hasharry.Hash{}, fmt.Errorf("height %d is greater than the last height %d", height, blc.currentHeight)
+	}
+	if height == blc.currentHeight {
+		return blc.stateRoot, blc.contractRoot, blc.consensusRoot, nil
+	}
+	header, err := blc.storage.GetHeaderByHeight(height + 1)
+	if err != nil {
+		return hasharry.Hash{}, hasharry.Hash{}, hasharry.Hash{}, err
+	}
+	return header.StateRoot, header.ContractRoot, header.ConsensusRoot, nil
+}
+
+// Get the roots after the block of the height from the header at
+// height+1. Unlike RootsAt the last block is refused, its roots are
+// not in any header yet and a proof against them can not be checked.
+func (blc *BlockChain) HeaderRootsAt(height uint64) (hasharry.Hash, hasharry.Hash, hasharry.Hash, error) {
+	blc.mutex.RLock()
+	defer blc.mutex.RUnlock()
+
+	if height >= blc.currentHeight {
+		return hasharry.Hash{}, hasharry.Hash{}, hasharry.Hash{}, fmt.Errorf("the roots after height %d are not in a header yet, the last height is %d", height, blc.currentHeight)
+	}
+	header, err := blc.storage.GetHeaderByHeight(height + 1)
+	if err != nil {
+		return hasharry.Hash{}, hasharry.Hash{}, hasharry.Hash{}, err
+	}
+	return header.StateRoot, header.ContractRoot, header.ConsensusRoot, nil
+}
+
+// Open read-only account and contract states as they were after the
+// block of the height, the states used by the chain are not affected
+func (blc *BlockChain) StateAt(height uint64) (_interface.IAccountState, _interface.IContractState, error) {
+	stateRoot, contractRoot, _, err := blc.RootsAt(height)
+	if err != nil {
+		return nil, nil, err
+	}
+	confirmedHeight, err := blc.storage.GetHistoryConfirmedHeight(height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get confirmed height of %d failed! %s", height, err.Error())
+	}
+	accountState, err := blc.accountState.StateAt(stateRoot, confirmedHeight)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open account state at %d failed! %s", height, err.Error())
+	}
+	contractState, err := blc.contractState.StateAt(contractRoot, confirmedHeight)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open contract state at %d failed! %s", height, err.Error())
+	}
+	return accountState, contractState, nil
+}
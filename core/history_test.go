@@ -0,0 +1,78 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/uworldao/UWORLD/param"
+)
+
+func TestHeaderRootsAt(t *testing.T) {
+	c := newTestChain(t)
+	defer c.remove()
+
+	c.mine(testGenesisTime+10, newTestTransfer(t, 1, testReceiver, 1*param.AtomsPerCoin))
+	stateRoot, contractRoot, consensusRoot := c.TireRoot()
+	next := c.mine(testGenesisTime + 20)
+
+	// The roots after the last block are not in a header
+	if _, _, _, err := c.HeaderRootsAt(2); err == nil {
+		t.Fatal("roots of the last height are returned")
+	}
+	if _, _, _, err := c.HeaderRootsAt(3); err == nil {
+		t.Fatal("roots of a future height are returned")
+	}
+	s, ct, cs, err := c.HeaderRootsAt(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != stateRoot || ct != contractRoot || cs != consensusRoot {
+		t.Fatal("the roots are not the roots after the block")
+	}
+	if s != next.StateRoot || ct != next.ContractRoot || cs != next.ConsensusRoot {
+		t.Fatal("the roots are not the roots of the next header")
+	}
+}
+
+func TestStateAt(t *testing.T) {
+	c := newTestChain(t)
+	defer c.remove()
+
+	c.mine(testGenesisTime+10, newTestTransfer(t, 1, testReceiver, 1*param.AtomsPerCoin))
+	c.mine(testGenesisTime+20, newTestTransfer(t, 2, testReceiver, 2*param.AtomsPerCoin))
+	stateRoot, contractRoot, consensusRoot := c.TireRoot()
+
+	for height, want := range []uint64{0, 1*param.AtomsPerCoin - param.Fees, 3*param.AtomsPerCoin - 2*param.Fees} {
+		accountState, contractState, err := c.StateAt(uint64(height))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if contractState == nil {
+			t.Fatalf("no contract state at %d", height)
+		}
+		if balance := accountState.GetAccountState(testReceiver).GetHolding(param.Token.String()); balance != want {
+			t.Fatalf("balance %d at %d, want %d", balance, height, want)
+		}
+		if nonce := accountState.GetAccountState(testSender).GetNonce(); nonce != uint64(height) {
+			t.Fatalf("nonce %d at %d, want %d", nonce, height, height)
+		}
+	}
+	if _, _, err := c.StateAt(3); err == nil {
+		t.Fatal("the state of a future height is returned")
+	}
+
+	// A change of the view does not reach the states of the chain
+	view, _, err := c.StateAt(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := view.Mint(testReceiver, param.Token, 5*param.AtomsPerCoin, 1); err != nil {
+		t.Fatal(err)
+	}
+	if s, ct, cs := c.TireRoot(); s != stateRoot || ct != contractRoot || cs != consensusRoot {
+		t.Fatal("the roots of the chain changed")
+	}
+	if want := 3*param.AtomsPerCoin - 2*param.Fees; c.balance(testReceiver) != want {
+		t.Fatalf("balance %d, want %d", c.balance(testReceiver), want)
+	}
+	c.mine(testGenesisTime+30, newTestTransfer(t, 3, testReceiver, 1*param.AtomsPerCoin))
+}
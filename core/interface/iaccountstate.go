@@ -8,20 +8,36 @@ import (
 type IAccountState interface {
 	InitTrie(stateRoot hasharry.Hash) error
 
+	StateAt(stateRoot hasharry.Hash, confirmedHeight uint64) (IAccountState, error)
+
+	DumpTrie(stateRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error
+
+	TrieNode(hash hasharry.Hash) ([]byte, error)
+
+	SyncTrie(stateRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error
+
 	GetAccountState(stateKey hasharry.Address) types.IAccount
 
 	GetAccountNonce(stateKey hasharry.Address) (uint64, error)
 
+	GetAccountProof(stateKey hasharry.Address) ([]byte, [][]byte, error)
+
 	UpdateTransferFrom(tx types.ITransaction, blockHeight uint64) error
 
 	UpdateTransferV2From(tx types.ITransaction, blockHeight uint64) error
 
 	UpdateContractFrom(tx types.ITransaction, blockHeight uint64) error
 
+	UpdateCandidateFrom(tx types.ITransaction, blockHeight uint64) error
+
+	SlashBond(address hasharry.Address, percent uint64) error
+
 	UpdateTransferV2To(tx types.ITransaction, blockHeight uint64) error
 
 	UpdateTransferTo(tx types.ITransaction, blockHeight uint64) error
 
+	UpdateLockedTransferTo(tx types.ITransaction, blockHeight uint64) error
+
 	TxContractMint(tx types.ITransaction, blockHeight uint64) error
 
 	Mint(reviver hasharry.Address, contract hasharry.Address, amount, height uint64) error
@@ -38,6 +54,8 @@ type IAccountState interface {
 
 	VerifyState(tx types.ITransaction) error
 
+	VerifyPendingState(tx types.ITransaction, pending map[hasharry.Address]types.IAccount, blockHeight uint64) error
+
 	Transfer(from, to, token hasharry.Address, amount, height uint64) error
 
 	PreTransfer(from, to, token hasharry.Address, amount, height uint64) error
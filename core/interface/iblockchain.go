@@ -30,10 +30,20 @@ type IBlockChain interface {
 
 	GetTransactionIndex(hash hasharry.Hash) (types.ITransactionIndex, error)
 
+	GetReceipt(txHash hasharry.Hash) (*types.Receipt, error)
+
 	GetAddressVote(address hasharry.Address) uint64
 
+	GetAddressBond(address hasharry.Address) uint64
+
+	GetAddressHolding(address hasharry.Address) uint64
+
+	GetAddressTransactions(address hasharry.Address, start, count uint64) ([]*types.AddressTx, uint64, error)
+
 	GetTermLastHash(term uint64) (hasharry.Hash, error)
 
+	SimulateTransaction(tx types.ITransaction) (*types.Simulation, error)
+
 	InsertChain(block *types.Block) error
 
 	SaveGenesisBlock(block *types.Block) error
@@ -44,8 +54,6 @@ type IBlockChain interface {
 
 	ValidationBlockHash(header *types.Header) (bool, error)
 
-	FallBack()
-
 	FallBackTo(int64 uint64) error
 
 	StateRoot() hasharry.Hash
@@ -56,5 +64,11 @@ type IBlockChain interface {
 
 	TireRoot() (hasharry.Hash, hasharry.Hash, hasharry.Hash)
 
+	RootsAt(height uint64) (hasharry.Hash, hasharry.Hash, hasharry.Hash, error)
+
+	HeaderRootsAt(height uint64) (hasharry.Hash, hasharry.Hash, hasharry.Hash, error)
+
+	StateAt(height uint64) (IAccountState, IContractState, error)
+
 	CloseStorage() error
 }
@@ -48,5 +48,29 @@ type IBlockChainStorage interface {
 
 	UpdateTermLastHash(term uint64, hash hasharry.Hash)
 
+	GetCommitJournal() (uint64, hasharry.Hash, error)
+
+	UpdateCommitJournal(height uint64, hash hasharry.Hash) error
+
+	DeleteCommitJournal() error
+
+	CommitBlock(block *types.Block, receipts types.Receipts, confirmedHeight uint64, addrTxs []*types.AddressTx, stateRoot, contractRoot, consensusRoot hasharry.Hash) error
+
+	GetReceipts(blockHash hasharry.Hash) (types.Receipts, error)
+
+	UpdateTip(height uint64, stateRoot, contractRoot, consensusRoot hasharry.Hash) error
+
+	ImportHeaders(headers []*types.Header) error
+
+	UpdateSideBlock(block *types.Block) error
+
+	RevertTip(fork *types.Header, reverted []*types.Block, addrTxs []*types.AddressTx, stateRoot, contractRoot, consensusRoot hasharry.Hash) error
+
+	GetAddrIndexHeight() (uint64, error)
+
+	UpdateAddressTxs(height uint64, addrTxs []*types.AddressTx) error
+
+	GetAddressTxs(address hasharry.Address, start, count uint64) ([]*types.AddressTx, uint64, error)
+
 	Close() error
 }
@@ -4,6 +4,8 @@ import (
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
 )
 
 type IContractState interface {
@@ -19,6 +21,32 @@ type IContractState interface {
 
 	GetContractV2State(hash string) *types.ContractV2State
 
+	GetAllowance(address, owner, spender hasharry.Address) uint64
+
+	SetAllowance(address, owner, spender hasharry.Address, amount uint64)
+
+	GetNFT(collection hasharry.Address, id uint64) *nft.Token
+
+	SetNFT(token *nft.Token)
+
+	DeleteNFT(collection hasharry.Address, id uint64)
+
+	GetCollectionNFTs(collection hasharry.Address, start, count uint64) []*nft.Token
+
+	AddOwnerNFT(owner hasharry.Address, key nft.TokenKey)
+
+	RemoveOwnerNFT(owner hasharry.Address, key nft.TokenKey)
+
+	GetOwnerNFTCount(owner hasharry.Address) uint64
+
+	GetOwnerNFTs(owner hasharry.Address, start, count uint64) []nft.TokenKey
+
+	GetHashLockSwaps(hashLock hasharry.Hash) htlc.HashLockSwaps
+
+	SetHashLockSwaps(hashLock hasharry.Hash, swaps htlc.HashLockSwaps)
+
+	GetContractProof(key []byte) ([]byte, [][]byte, error)
+
 	VerifyState(tx types.ITransaction) error
 
 	UpdateContract(tx types.ITransaction, blockHeight uint64)
@@ -27,6 +55,14 @@ type IContractState interface {
 
 	InitTrie(hash hasharry.Hash) error
 
+	StateAt(contractRoot hasharry.Hash, confirmedHeight uint64) (IContractState, error)
+
+	DumpTrie(contractRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error
+
+	TrieNode(hash hasharry.Hash) ([]byte, error)
+
+	SyncTrie(contractRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error
+
 	RootHash() hasharry.Hash
 
 	ContractTrieCommit() (hasharry.Hash, error)
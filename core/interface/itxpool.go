@@ -11,6 +11,7 @@ type ITxPool interface {
 	Stop() error
 	Add(tx types.ITransaction, isPeer bool) error
 	Gets(count int, maxBytes uint64) types.Transactions
+	PreparedLen() int
 	GetAll() (types.Transactions, types.Transactions)
 	Get() types.ITransaction
 	GetTransaction(hash hasharry.Hash) (types.ITransaction, error)
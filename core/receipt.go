@@ -0,0 +1,171 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/interface"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+	"sort"
+)
+
+// Builds the receipts of the transactions of a block while they are
+// applied. The accounts are compared with the accounts before the
+// transaction, the accounts not touched by an earlier transaction of
+// the block are read from the state before the block.
+type receiptBuilder struct {
+	origin        _interface.IAccountState
+	accountState  _interface.IAccountState
+	contractState _interface.IContractState
+	seen          map[hasharry.Address]*types.Account
+	receipts      types.Receipts
+}
+
+func newReceiptBuilder(origin, accountState _interface.IAccountState, contractState _interface.IContractState) *receiptBuilder {
+	return &receiptBuilder{
+		origin:        origin,
+		accountState:  accountState,
+		contractState: contractState,
+		seen:          make(map[hasharry.Address]*types.Account),
+		receipts:      make(types.Receipts, 0),
+	}
+}
+
+// Add the receipt of the transaction, it must be called right after
+// the transaction is applied
+func (r *receiptBuilder) add(tx types.ITransaction, height uint64) {
+	receipt := &types.Receipt{
+		TxHash:  tx.Hash(),
+		Status:  types.Receipt_Success,
+		Fees:    tx.GetFees(),
+		Changes: make([]*types.ReceiptChange, 0),
+		Events:  make([]*types.Event, 0),
+	}
+	switch tx.GetTxType() {
+	case types.Contract_:
+		receipt.Fees = 0
+		receipt.Consumption = tx.GetFees()
+		for _, re := range tx.GetTxBody().ToAddress().ReceiverList() {
+			receipt.Events = append(receipt.Events, &types.Event{
+				EventType: types.Event_Mint,
+				From:      tx.From(),
+				To:        re.Address,
+				Token:     tx.GetTxBody().GetContract(),
+				Amount:    re.Amount,
+				Height:    height,
+			})
+		}
+	case types.ContractV2_:
+		if state := r.contractState.GetContractV2State(tx.Hash().String()); state != nil {
+			if state.State == types.Contract_Failed {
+				receipt.Status = types.Receipt_Failed
+				receipt.Error = state.Error
+			}
+			receipt.Events = append(receipt.Events, state.Event...)
+		}
+	case types.Transfer_, types.TransferV2_, types.LockedTransfer_:
+		contract := tx.GetTxBody().GetContract()
+		for _, re := range tx.GetTxBody().ToAddress().ReceiverList() {
+			amount := re.Amount
+			// The fees of the first version are taken from the amount
+			if tx.GetTxType() == types.Transfer_ && contract.IsEqual(param.Token) {
+				amount -= tx.GetFees()
+			}
+			receipt.Events = append(receipt.Events, &types.Event{
+				EventType: types.Event_Transfer,
+				From:      tx.From(),
+				To:        re.Address,
+				Token:     contract,
+				Amount:    amount,
+				Height:    height,
+			})
+		}
+	}
+
+	addresses := make([]hasharry.Address, 0)
+	for address, _ := range txAddresses(tx, r.contractState) {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+	for _, address := range addresses {
+		before, ok := r.seen[address]
+		if !ok {
+			before = r.origin.GetAccountState(address).(*types.Account)
+		}
+		after := r.accountState.GetAccountState(address).(*types.Account)
+		receipt.Changes = append(receipt.Changes, receiptChanges(address, before, after)...)
+		r.seen[address] = after
+	}
+	r.receipts = append(r.receipts, receipt)
+}
+
+// Compare the balance, the locked in amount and the locks of the coins,
+// the locked out amount has already been taken from the balance
+func receiptChanges(address hasharry.Address, before, after *types.Account) []*types.ReceiptChange {
+	contracts := make([]string, 0)
+	exist := make(map[string]bool)
+	for _, coins := range []*types.Coins{before.Coins, after.Coins} {
+		if coins == nil {
+			continue
+		}
+		for _, coin := range *coins {
+			if !exist[coin.Contract] {
+				exist[coin.Contract] = true
+				contracts = append(contracts, coin.Contract)
+			}
+		}
+	}
+	for _, lock := range after.Locks {
+		if !exist[lock.Contract] {
+			exist[lock.Contract] = true
+			contracts = append(contracts, lock.Contract)
+		}
+	}
+	changes := make([]*types.ReceiptChange, 0)
+	for _, contract := range contracts {
+		var beforeAmount, afterAmount uint64
+		if coin, ok := before.Coins.Get(contract); ok {
+			beforeAmount = coin.Balance + coin.LockIn
+		}
+		if coin, ok := after.Coins.Get(contract); ok {
+			afterAmount = coin.Balance + coin.LockIn
+		}
+		beforeAmount += before.GetLocked(contract)
+		afterAmount += after.GetLocked(contract)
+		change := &types.ReceiptChange{Address: address, Contract: contract}
+		switch {
+		case afterAmount > beforeAmount:
+			change.In = afterAmount - beforeAmount
+		case afterAmount < beforeAmount:
+			change.Out = beforeAmount - afterAmount
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// GetReceipt gets the receipt of a transaction in the main chain
+func (blc *BlockChain) GetReceipt(txHash hasharry.Hash) (*types.Receipt, error) {
+	index, err := blc.storage.GetTxLocation(txHash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := blc.storage.GetHeaderByHeight(index.Height)
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := blc.storage.GetReceipts(header.Hash)
+	if err != nil {
+		return nil, err
+	}
+	receipt := receipts.Get(txHash)
+	if receipt == nil {
+		return nil, errors.New("receipt not found")
+	}
+	return receipt, nil
+}
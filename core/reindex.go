@@ -0,0 +1,138 @@
+package core
+
+import (
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	log "github.com/uworldao/UWORLD/log/log15"
+)
+
+// Number of blocks between two progress logs
+const replayProgress = 1000
+
+// Replay executes the stored blocks again on the replay chain, which is
+// opened on an empty data directory and only has the genesis block. The
+// roots of the replay chain are checked against the roots in the header
+// of every block, the first difference is reported with the transactions
+// of the block that produced it. The tries of this chain are not changed.
+func (blc *BlockChain) Replay(replay *BlockChain) error {
+	genesis, err := blc.storage.GetHeaderByHeight(0)
+	if err != nil {
+		return err
+	}
+	replayGenesis, err := replay.CurrentHeader()
+	if err != nil {
+		return err
+	}
+	if replay.GetLastHeight() != 0 || replayGenesis.Hash != genesis.Hash {
+		return fmt.Errorf("replay chain must only have the genesis block %s", genesis.Hash.String())
+	}
+
+	lastHeight := blc.GetLastHeight()
+	for height := uint64(1); height <= lastHeight; height++ {
+		block, err := blc.GetBlockByHeight(height)
+		if err != nil {
+			return fmt.Errorf("get block %d failed! %s", height, err.Error())
+		}
+		// The header holds the roots the previous block left
+		if err := blc.checkReplayRoots(replay, height-1, block.StateRoot, block.ContractRoot, block.ConsensusRoot); err != nil {
+			return err
+		}
+		if err := replay.InsertChain(block); err != nil {
+			blc.logReplayTxs(block)
+			return fmt.Errorf("replay block %d %s failed! %s", height, block.Hash.String(), err.Error())
+		}
+		if height%replayProgress == 0 {
+			log.Info("Replaying blocks", "height", height, "last", lastHeight)
+		}
+	}
+	stateRoot, contractRoot, consensusRoot := blc.TireRoot()
+	if err := blc.checkReplayRoots(replay, lastHeight, stateRoot, contractRoot, consensusRoot); err != nil {
+		return err
+	}
+	log.Info("Replay blocks completed, all roots match", "height", lastHeight)
+	return nil
+}
+
+// Reindex replays the blocks and then replaces the tries of this chain
+// with the tries the replay has built. Nothing is changed if the replay
+// finds a difference.
+func (blc *BlockChain) Reindex(replay *BlockChain) error {
+	if err := blc.Replay(replay); err != nil {
+		return err
+	}
+	stateRoot, contractRoot, consensusRoot := replay.TireRoot()
+	if err := syncReplayTrie(stateRoot, replay.accountState.TrieNode, blc.accountState.SyncTrie); err != nil {
+		return fmt.Errorf("rebuild state trie failed! %s", err.Error())
+	}
+	if err := syncReplayTrie(contractRoot, replay.contractState.TrieNode, blc.contractState.SyncTrie); err != nil {
+		return fmt.Errorf("rebuild contract trie failed! %s", err.Error())
+	}
+	if err := syncReplayTrie(consensusRoot, replay.consensus.TrieNode, blc.consensus.SyncTrie); err != nil {
+		return fmt.Errorf("rebuild consensus trie failed! %s", err.Error())
+	}
+
+	lastHeight := blc.GetLastHeight()
+	confirmedHeader := replay.consensus.GetConfirmedBlockHeader(replay)
+	if err := blc.storage.UpdateTip(lastHeight, stateRoot, contractRoot, consensusRoot); err != nil {
+		return err
+	}
+	blc.consensus.SetConfirmedHeader(confirmedHeader)
+
+	blc.mutex.Lock()
+	defer blc.mutex.Unlock()
+	if err := blc.initTries(stateRoot, contractRoot, consensusRoot); err != nil {
+		return err
+	}
+	blc.confirmedHeight = confirmedHeader.Height
+	blc.accountState.UpdateConfirmedHeight(confirmedHeader.Height)
+	log.Info("Reindex completed", "height", lastHeight, "confirmed", confirmedHeader.Height)
+	return nil
+}
+
+// Compare the roots of the replay chain after the block of the height
+// with the expected roots
+func (blc *BlockChain) checkReplayRoots(replay *BlockChain, height uint64, stateRoot, contractRoot, consensusRoot hasharry.Hash) error {
+	replayState, replayContract, replayConsensus := replay.TireRoot()
+	var name string
+	var expect, got hasharry.Hash
+	switch {
+	case replayState != stateRoot:
+		name, expect, got = "state", stateRoot, replayState
+	case replayContract != contractRoot:
+		name, expect, got = "contract", contractRoot, replayContract
+	case replayConsensus != consensusRoot:
+		name, expect, got = "consensus", consensusRoot, replayConsensus
+	default:
+		return nil
+	}
+	block, err := blc.GetBlockByHeight(height)
+	if err != nil {
+		return err
+	}
+	log.Error("Replay roots differ", "height", height, "hash", block.Hash.String(),
+		"root", name, "expect", expect.String(), "replay", got.String())
+	blc.logReplayTxs(block)
+	return fmt.Errorf("%s root after block %d is %s, the replay got %s", name, height, expect.String(), got.String())
+}
+
+func (blc *BlockChain) logReplayTxs(block *types.Block) {
+	for i, tx := range block.Transactions {
+		log.Error("Transaction of the block", "height", block.Height, "index", i,
+			"hash", tx.Hash().String(), "type", tx.GetTxType(), "from", tx.From().String())
+	}
+}
+
+// Copy the trie of the root from the replay storage to the chain storage.
+// The nodes are read from the replay storage as SyncTrie requests them,
+// and SyncTrie writes them in batches, so the trie is never held whole.
+func syncReplayTrie(root hasharry.Hash, read func(hasharry.Hash) ([]byte, error),
+	sync func(hasharry.Hash, func(hasharry.Hash) ([]byte, error)) error) error {
+	return sync(root, func(hash hasharry.Hash) ([]byte, error) {
+		node, err := read(hash)
+		if err != nil {
+			return nil, fmt.Errorf("trie node %s missing: %v", hash.String(), err)
+		}
+		return node, nil
+	})
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/uworldao/UWORLD/param"
+)
+
+func TestReplay(t *testing.T) {
+	c := newTestChain(t)
+	defer c.remove()
+	c.mine(testGenesisTime+10, newTestTransfer(t, 1, testReceiver, 1*param.AtomsPerCoin))
+	c.mine(testGenesisTime+20, newTestTransfer(t, 2, testReceiver, 2*param.AtomsPerCoin))
+
+	replay := newTestChain(t)
+	defer replay.remove()
+	if err := c.Replay(replay.BlockChain); err != nil {
+		t.Fatal(err)
+	}
+	if replay.GetLastHeight() != 2 {
+		t.Fatalf("replayed to %d, want 2", replay.GetLastHeight())
+	}
+	if err := c.Replay(replay.BlockChain); err == nil {
+		t.Fatal("a replay chain with blocks is used")
+	}
+}
+
+// The state of the chain is changed outside a block before block 3, the
+// roots differ from the replay from the header of block 4 on
+func TestReplayFirstDifference(t *testing.T) {
+	c := newTestChain(t)
+	defer c.remove()
+	c.mine(testGenesisTime+10, newTestTransfer(t, 1, testReceiver, 1*param.AtomsPerCoin))
+	c.mine(testGenesisTime + 20)
+	if err := c.accountState.Mint(testReceiver, param.Token, 1*param.AtomsPerCoin, 2); err != nil {
+		t.Fatal(err)
+	}
+	c.mine(testGenesisTime+30, newTestTransfer(t, 2, testReceiver, 2*param.AtomsPerCoin))
+	c.mine(testGenesisTime + 40)
+	c.mine(testGenesisTime + 50)
+
+	replay := newTestChain(t)
+	defer replay.remove()
+	err := c.Replay(replay.BlockChain)
+	if err == nil {
+		t.Fatal("the replay does not find the difference")
+	}
+	if !strings.HasPrefix(err.Error(), "state root after block 3 ") {
+		t.Fatalf("error %q, want the state root after block 3", err.Error())
+	}
+	if replay.GetLastHeight() != 3 {
+		t.Fatalf("replayed to %d, want to stop at 3", replay.GetLastHeight())
+	}
+
+	// Nothing is changed by a reindex that finds the difference
+	stateRoot, contractRoot, consensusRoot := c.TireRoot()
+	reindex := newTestChain(t)
+	defer reindex.remove()
+	if err := c.Reindex(reindex.BlockChain); err == nil {
+		t.Fatal("the chain is reindexed with a difference")
+	}
+	if s, ct, cs := c.TireRoot(); s != stateRoot || ct != contractRoot || cs != consensusRoot {
+		t.Fatal("the roots of the chain changed")
+	}
+}
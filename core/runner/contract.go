@@ -5,10 +5,16 @@ import (
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/interface"
 	"github.com/uworldao/UWORLD/core/runner/exchange_runner"
+	"github.com/uworldao/UWORLD/core/runner/htlc_runner"
 	"github.com/uworldao/UWORLD/core/runner/library"
+	"github.com/uworldao/UWORLD/core/runner/nft_runner"
+	"github.com/uworldao/UWORLD/core/runner/token_runner"
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
 	"github.com/uworldao/UWORLD/core/types/contractv2/exchange"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
+	"github.com/uworldao/UWORLD/core/types/contractv2/token"
 	"sync"
 )
 
@@ -57,6 +63,45 @@ func (c *ContractRunner) Verify(tx types.ITransaction, lastHeight uint64) error
 			pair := exchange_runner.NewPairRunner(c.library, tx, 0, 0)
 			return pair.PreRemoveLiquidityVerify(lastHeight)
 		}
+	case contractv2.Token_:
+		tk := token_runner.NewTokenRunner(c.library, tx, lastHeight)
+		switch body.FunctionType {
+		case contractv2.Token_Init:
+			return tk.PreInitVerify()
+		case contractv2.Token_Approve:
+			return tk.PreApproveVerify()
+		case contractv2.Token_TransferFrom:
+			return tk.PreTransferFromVerify()
+		case contractv2.Token_Burn:
+			return tk.PreBurnVerify()
+		case contractv2.Token_Mint:
+			return tk.PreMintVerify()
+		}
+	case contractv2.NFT_:
+		nf := nft_runner.NewNFTRunner(c.library, tx, lastHeight)
+		switch body.FunctionType {
+		case contractv2.NFT_Create:
+			return nf.PreCreateVerify()
+		case contractv2.NFT_Mint:
+			return nf.PreMintVerify()
+		case contractv2.NFT_Transfer:
+			return nf.PreTransferVerify()
+		case contractv2.NFT_Approve:
+			return nf.PreApproveVerify()
+		case contractv2.NFT_Burn:
+			return nf.PreBurnVerify()
+		}
+	case contractv2.HTLC_:
+		// The time locks are checked against the next block
+		hl := htlc_runner.NewHTLCRunner(c.library, tx, lastHeight+1)
+		switch body.FunctionType {
+		case contractv2.HTLC_Create:
+			return hl.PreCreateVerify()
+		case contractv2.HTLC_Claim:
+			return hl.PreClaimVerify()
+		case contractv2.HTLC_Refund:
+			return hl.PreRefundVerify()
+		}
 	}
 	return nil
 }
@@ -90,6 +135,44 @@ func (c *ContractRunner) RunContract(tx types.ITransaction, blockHeight uint64,
 			pairRunner := exchange_runner.NewPairRunner(c.library, tx, blockHeight, blockTime)
 			pairRunner.RemoveLiquidity()
 		}
+	case contractv2.Token_:
+		tk := token_runner.NewTokenRunner(c.library, tx, blockHeight)
+		switch body.FunctionType {
+		case contractv2.Token_Init:
+			tk.Init()
+		case contractv2.Token_Approve:
+			tk.Approve()
+		case contractv2.Token_TransferFrom:
+			tk.TransferFrom()
+		case contractv2.Token_Burn:
+			tk.Burn()
+		case contractv2.Token_Mint:
+			tk.Mint()
+		}
+	case contractv2.NFT_:
+		nf := nft_runner.NewNFTRunner(c.library, tx, blockHeight)
+		switch body.FunctionType {
+		case contractv2.NFT_Create:
+			nf.Create()
+		case contractv2.NFT_Mint:
+			nf.Mint()
+		case contractv2.NFT_Transfer:
+			nf.Transfer()
+		case contractv2.NFT_Approve:
+			nf.Approve()
+		case contractv2.NFT_Burn:
+			nf.Burn()
+		}
+	case contractv2.HTLC_:
+		hl := htlc_runner.NewHTLCRunner(c.library, tx, blockHeight)
+		switch body.FunctionType {
+		case contractv2.HTLC_Create:
+			hl.Create()
+		case contractv2.HTLC_Claim:
+			hl.Claim()
+		case contractv2.HTLC_Refund:
+			hl.Refund()
+		}
 	}
 	return nil
 }
@@ -113,3 +196,113 @@ func (c *ContractRunner) ExchangePair(address hasharry.Address) ([]*types.RpcPai
 	}
 	return rpcPairList, nil
 }
+
+func (c *ContractRunner) Token(address hasharry.Address) (*types.RpcToken, error) {
+	tkHeader := c.library.GetContractV2(address.String())
+	if tkHeader == nil || tkHeader.Type != contractv2.Token_ {
+		return nil, fmt.Errorf("token %s is not exist", address.String())
+	}
+	tk := tkHeader.Body.(*token.Token)
+	rpcToken := &types.RpcToken{
+		Address:     address.String(),
+		Name:        tk.Name,
+		Symbol:      tk.Symbol,
+		Decimals:    tk.Decimals,
+		TotalSupply: types.Amount(tk.TotalSupply).ToCoin(),
+		Mintable:    tk.Mintable(),
+		CreateHash:  tkHeader.CreateHash.String(),
+	}
+	if tk.Mintable() {
+		rpcToken.Owner = tk.Owner.String()
+	}
+	return rpcToken, nil
+}
+
+func (c *ContractRunner) TokenAllowance(address, owner, spender hasharry.Address) (*types.RpcTokenAllowance, error) {
+	if c.library.GetToken(address) == nil {
+		return nil, fmt.Errorf("token %s is not exist", address.String())
+	}
+	return &types.RpcTokenAllowance{
+		Token:     address.String(),
+		Owner:     owner.String(),
+		Spender:   spender.String(),
+		Allowance: types.Amount(c.library.GetAllowance(address, owner, spender)).ToCoin(),
+	}, nil
+}
+
+// Maximum number of non-fungible tokens returned at once
+const maxNFTPage = 100
+
+func (c *ContractRunner) NFTCollection(address hasharry.Address) (*types.RpcNFTCollection, error) {
+	header := c.library.GetContractV2(address.String())
+	if header == nil || header.Type != contractv2.NFT_ {
+		return nil, fmt.Errorf("collection %s is not exist", address.String())
+	}
+	collection := header.Body.(*nft.Collection)
+	return &types.RpcNFTCollection{
+		Address:    address.String(),
+		Name:       collection.Name,
+		Symbol:     collection.Symbol,
+		Creator:    collection.Creator.String(),
+		Supply:     collection.Supply,
+		Minted:     collection.NextId - 1,
+		CreateHash: header.CreateHash.String(),
+	}, nil
+}
+
+// Tokens of the collection with ids greater than start
+func (c *ContractRunner) NFTsByCollection(address hasharry.Address, start, count uint64) (*types.RpcNFTPage, error) {
+	collection := c.library.GetCollection(address)
+	if collection == nil {
+		return nil, fmt.Errorf("collection %s is not exist", address.String())
+	}
+	if count == 0 || count > maxNFTPage {
+		count = maxNFTPage
+	}
+	page := &types.RpcNFTPage{
+		Address: address.String(),
+		Start:   start,
+		Total:   collection.Supply,
+		Tokens:  make([]*types.RpcNFT, 0),
+	}
+	for _, token := range c.library.GetCollectionNFTs(address, start, count) {
+		page.Tokens = append(page.Tokens, types.TranslateNFTToRpcNFT(token))
+	}
+	return page, nil
+}
+
+// Tokens of the owner from the index start, ordered by collection and id
+func (c *ContractRunner) NFTsByOwner(owner hasharry.Address, start, count uint64) *types.RpcNFTPage {
+	if count == 0 || count > maxNFTPage {
+		count = maxNFTPage
+	}
+	page := &types.RpcNFTPage{
+		Address: owner.String(),
+		Start:   start,
+		Total:   c.library.GetOwnerNFTCount(owner),
+		Tokens:  make([]*types.RpcNFT, 0),
+	}
+	for _, key := range c.library.GetOwnerNFTs(owner, start, count) {
+		if token := c.library.GetNFT(key.Collection, key.Id); token != nil {
+			page.Tokens = append(page.Tokens, types.TranslateNFTToRpcNFT(token))
+		}
+	}
+	return page
+}
+
+// Swaps locked by the hash, in the order of creation
+func (c *ContractRunner) HTLCsByHashLock(hashLock hasharry.Hash) ([]*types.RpcHTLC, error) {
+	addresses := c.library.GetHashLockSwaps(hashLock)
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no swap is locked by %s", hashLock.String())
+	}
+	swaps := make([]*types.RpcHTLC, 0, len(addresses))
+	for _, address := range addresses {
+		header := c.library.GetContractV2(address.String())
+		if header == nil || header.Type != contractv2.HTLC_ {
+			continue
+		}
+		swaps = append(swaps, types.TranslateSwapToRpcHTLC(header.Address, header.CreateHash, header.Body.(*htlc.Swap)))
+	}
+	return swaps, nil
+}
@@ -92,12 +92,12 @@ func (p *PairRunner) PreAddLiquidityVerify() error {
 		}
 	*/
 	if !p.addBody.TokenA.IsEqual(param.Token) {
-		if contract := p.library.GetContract(p.addBody.TokenA.String()); contract == nil {
+		if !p.library.TokenExist(p.addBody.TokenA) {
 			return fmt.Errorf("tokenA %s is not exist", p.addBody.TokenA.String())
 		}
 	}
 	if !p.addBody.TokenB.IsEqual(param.Token) {
-		if contract := p.library.GetContract(p.addBody.TokenB.String()); contract == nil {
+		if !p.library.TokenExist(p.addBody.TokenB) {
 			return fmt.Errorf("tokenB %s is not exist", p.addBody.TokenB.String())
 		}
 	}
@@ -155,12 +155,12 @@ func (p *PairRunner) PreRemoveLiquidityVerify(lastHeight uint64) error {
 		return fmt.Errorf("invalid liquidity")
 	}
 	if !p.removeBody.TokenA.IsEqual(param.Token) {
-		if contract := p.library.GetContract(p.removeBody.TokenA.String()); contract == nil {
+		if !p.library.TokenExist(p.removeBody.TokenA) {
 			return fmt.Errorf("tokenA %s is not exist", p.removeBody.TokenA.String())
 		}
 	}
 	if !p.removeBody.TokenB.IsEqual(param.Token) {
-		if contract := p.library.GetContract(p.removeBody.TokenB.String()); contract == nil {
+		if !p.library.TokenExist(p.removeBody.TokenB) {
 			return fmt.Errorf("tokenB %s is not exist", p.removeBody.TokenB.String())
 		}
 	}
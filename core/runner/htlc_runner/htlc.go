@@ -0,0 +1,253 @@
+package htlc_runner
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/codec"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/runner/library"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/functionbody/htlc_func"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+// HTLCRunner runs the hashed time locked swaps. The coins of a swap are
+// held by its address, they are received as coins locked until the
+// create transaction is confirmed, so a swap can only be claimed or
+// refunded after that.
+type HTLCRunner struct {
+	library      *library.RunnerLibrary
+	header       *contractv2.ContractV2
+	swap         *htlc.Swap
+	address      hasharry.Address
+	tx           types.ITransaction
+	contractBody *types.TxContractV2Body
+	events       []*types.Event
+	height       uint64
+}
+
+func NewHTLCRunner(lib *library.RunnerLibrary, tx types.ITransaction, height uint64) *HTLCRunner {
+	var swap *htlc.Swap
+	address := tx.GetTxBody().GetContract()
+	header := lib.GetContractV2(address.String())
+	if header != nil {
+		swap, _ = header.Body.(*htlc.Swap)
+	}
+
+	contractBody := tx.GetTxBody().(*types.TxContractV2Body)
+	return &HTLCRunner{library: lib,
+		header:       header,
+		address:      address,
+		tx:           tx,
+		swap:         swap,
+		contractBody: contractBody,
+		events:       make([]*types.Event, 0),
+		height:       height,
+	}
+}
+
+func (h *HTLCRunner) PreCreateVerify() error {
+	if h.header != nil || h.library.GetContract(h.address.String()) != nil {
+		return fmt.Errorf("contract %s already exist", h.address.String())
+	}
+	address, err := HTLCAddress(param.Net, h.tx.From().String(), h.tx.GetNonce())
+	if err != nil {
+		return err
+	}
+	if address != h.address.String() {
+		return errors.New("wrong htlc contract address")
+	}
+	createBody, _ := h.contractBody.Function.(*htlc_func.HTLCCreateBody)
+	if createBody == nil {
+		return errors.New("wrong contractV2 function")
+	}
+	if !createBody.Token.IsEqual(param.Token) && !h.library.TokenExist(createBody.Token) {
+		return fmt.Errorf("token %s is not exist", createBody.Token.String())
+	}
+	if createBody.TimeLock <= h.height {
+		return fmt.Errorf("the time lock must be greater than the height %d", h.height)
+	}
+	return h.library.PreRunEvent(h.transferEvent(h.tx.From(), h.address, createBody.Token, createBody.Amount))
+}
+
+func (h *HTLCRunner) PreClaimVerify() error {
+	funcBody, _ := h.contractBody.Function.(*htlc_func.HTLCClaim)
+	if funcBody == nil {
+		return errors.New("wrong contractV2 function")
+	}
+	if err := h.verifyClaim(funcBody.Preimage); err != nil {
+		return err
+	}
+	return h.library.PreRunEvent(h.transferEvent(h.address, h.swap.Recipient, h.swap.Token, h.swap.Amount))
+}
+
+func (h *HTLCRunner) PreRefundVerify() error {
+	if err := h.verifyRefund(); err != nil {
+		return err
+	}
+	return h.library.PreRunEvent(h.transferEvent(h.address, h.swap.Sender, h.swap.Token, h.swap.Amount))
+}
+
+func (h *HTLCRunner) Create() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = h.events
+		}
+		h.library.SetContractV2State(h.tx.Hash().String(), state)
+	}()
+
+	if err := h.PreCreateVerify(); err != nil {
+		ERR = err
+		return
+	}
+	createBody := h.contractBody.Function.(*htlc_func.HTLCCreateBody)
+	if err := h.runEvent(h.transferEvent(h.tx.From(), h.address, createBody.Token, createBody.Amount)); err != nil {
+		ERR = err
+		return
+	}
+	h.library.SetContractV2(&contractv2.ContractV2{
+		Address:    h.address,
+		CreateHash: h.tx.Hash(),
+		Type:       h.contractBody.Type,
+		Body: &htlc.Swap{
+			Sender:    h.tx.From(),
+			Recipient: createBody.Recipient,
+			Token:     createBody.Token,
+			Amount:    createBody.Amount,
+			HashLock:  createBody.HashLock,
+			TimeLock:  createBody.TimeLock,
+			State:     htlc.Swap_Open,
+		},
+	})
+	h.library.AddHashLockSwap(createBody.HashLock, h.address)
+}
+
+// Claim sends the coins to the recipient, anyone who knows the
+// preimage can submit it
+func (h *HTLCRunner) Claim() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = h.events
+		}
+		h.library.SetContractV2State(h.tx.Hash().String(), state)
+	}()
+
+	funcBody := h.contractBody.Function.(*htlc_func.HTLCClaim)
+	if err := h.verifyClaim(funcBody.Preimage); err != nil {
+		ERR = err
+		return
+	}
+	if err := h.runEvent(h.transferEvent(h.address, h.swap.Recipient, h.swap.Token, h.swap.Amount)); err != nil {
+		ERR = err
+		return
+	}
+	h.swap.State = htlc.Swap_Claimed
+	h.swap.Preimage = funcBody.Preimage
+	h.update()
+}
+
+// Refund sends the coins back to the sender after the time lock, it can
+// be submitted by anyone
+func (h *HTLCRunner) Refund() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = h.events
+		}
+		h.library.SetContractV2State(h.tx.Hash().String(), state)
+	}()
+
+	if err := h.verifyRefund(); err != nil {
+		ERR = err
+		return
+	}
+	if err := h.runEvent(h.transferEvent(h.address, h.swap.Sender, h.swap.Token, h.swap.Amount)); err != nil {
+		ERR = err
+		return
+	}
+	h.swap.State = htlc.Swap_Refunded
+	h.update()
+}
+
+func (h *HTLCRunner) verifyClaim(preimage []byte) error {
+	if err := h.verifyOpen(); err != nil {
+		return err
+	}
+	if h.swap.Expired(h.height) {
+		return fmt.Errorf("swap %s expired at height %d", h.address.String(), h.swap.TimeLock)
+	}
+	if len(preimage) != htlc.PreimageSize {
+		return fmt.Errorf("the preimage must be %d bytes", htlc.PreimageSize)
+	}
+	if !h.swap.VerifyPreimage(preimage) {
+		return errors.New("wrong preimage")
+	}
+	return nil
+}
+
+func (h *HTLCRunner) verifyRefund() error {
+	if err := h.verifyOpen(); err != nil {
+		return err
+	}
+	if !h.swap.Expired(h.height) {
+		return fmt.Errorf("swap %s can not be refunded before height %d", h.address.String(), h.swap.TimeLock)
+	}
+	return nil
+}
+
+func (h *HTLCRunner) verifyOpen() error {
+	if h.swap == nil {
+		return fmt.Errorf("swap %s is not exist", h.address.String())
+	}
+	if h.swap.State != htlc.Swap_Open {
+		return fmt.Errorf("swap %s is %s", h.address.String(), h.swap.State.String())
+	}
+	return nil
+}
+
+func (h *HTLCRunner) update() {
+	h.header.Body = h.swap
+	h.library.SetContractV2(h.header)
+}
+
+func (h *HTLCRunner) transferEvent(from, to, token hasharry.Address, amount uint64) *types.Event {
+	return &types.Event{
+		EventType: types.Event_Transfer,
+		From:      from,
+		To:        to,
+		Token:     token,
+		Amount:    amount,
+		Height:    h.height,
+	}
+}
+
+func (h *HTLCRunner) runEvent(event *types.Event) error {
+	if err := h.library.PreRunEvent(event); err != nil {
+		return err
+	}
+	h.library.RunEvent(event)
+	h.events = append(h.events, event)
+	return nil
+}
+
+func HTLCAddress(net, from string, nonce uint64) (string, error) {
+	bytes := append([]byte(from), codec.Uint64toBytes(nonce)...)
+	return ut.GenerateContractV2Address(net, bytes)
+}
@@ -0,0 +1,232 @@
+package htlc_runner
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/runner/library"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/functionbody/htlc_func"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/services/accountstate"
+	"github.com/uworldao/UWORLD/services/contractstate"
+)
+
+const (
+	testAmount   uint64 = 10 * param.AtomsPerCoin
+	testTimeLock uint64 = 100
+)
+
+var (
+	testSender    = hasharry.StringToAddress("UWDM1qcsk7UUNANMPKSpALJW7AqpDCy7tdoN")
+	testRecipient = hasharry.StringToAddress("UWDR5oWfEjnGcEnQJrknuNW3LxZeWSpZUPJ5")
+	testClaimer   = hasharry.StringToAddress("UWDP1EbJ1mpT4sDD1p6TWhvNXBjxRiqLjW7F")
+	testPreimage  = []byte("the preimage of the test swaps..")
+)
+
+// Account and contract states of the swaps, the sender has coins
+type testStates struct {
+	dir      string
+	account  *accountstate.AccountState
+	contract *contractstate.ContractState
+	library  *library.RunnerLibrary
+}
+
+func newTestStates(t *testing.T) *testStates {
+	dir, err := ioutil.TempDir("", "htlc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testStates{dir: dir}
+	if s.account, err = accountstate.NewAccountState(dir); err != nil {
+		t.Fatal(err)
+	}
+	if s.contract, err = contractstate.NewContractState(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.account.InitTrie(hasharry.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.contract.InitTrie(hasharry.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.account.Mint(testSender, param.Token, 100*param.AtomsPerCoin, 0); err != nil {
+		t.Fatal(err)
+	}
+	s.library = library.NewRunnerLibrary(s.account, s.contract)
+	return s
+}
+
+func (s *testStates) close() {
+	s.account.Close()
+	s.contract.Close()
+	os.RemoveAll(s.dir)
+}
+
+// The coins received up to the height can be spent
+func (s *testStates) confirm(height uint64) {
+	s.account.UpdateConfirmedHeight(height)
+	s.contract.UpdateConfirmedHeight(height)
+}
+
+func (s *testStates) balance(address hasharry.Address) uint64 {
+	return s.account.GetAccountState(address).GetHolding(param.Token.String())
+}
+
+func newTestHTLCTx(t *testing.T, from, contract hasharry.Address, nonce uint64, function contractv2.FunctionType, body types.IFunction) types.ITransaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			From:       from,
+			Nonce:      nonce,
+			Fees:       param.Fees,
+			Time:       1600000000,
+			SignScript: &types.SignScript{},
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     contract,
+			Type:         contractv2.HTLC_,
+			FunctionType: function,
+			Function:     body,
+		},
+	}
+	if err := tx.SetHash(); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+// Create a swap of the sender locked by the hash of the preimage at
+// height 1 and confirm it
+func (s *testStates) createSwap(t *testing.T, nonce uint64, preimage []byte) hasharry.Address {
+	address, err := HTLCAddress(param.Net, testSender.String(), nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashLock := sha256.Sum256(preimage)
+	tx := newTestHTLCTx(t, testSender, hasharry.StringToAddress(address), nonce, contractv2.HTLC_Create,
+		&htlc_func.HTLCCreateBody{
+			Recipient: testRecipient,
+			Token:     param.Token,
+			Amount:    testAmount,
+			HashLock:  hasharry.BytesToHash(hashLock[:]),
+			TimeLock:  testTimeLock,
+		})
+	s.run(t, tx, 1, true)
+	s.confirm(1)
+	return hasharry.StringToAddress(address)
+}
+
+// Run the transaction at the height and check whether it succeeds
+func (s *testStates) run(t *testing.T, tx types.ITransaction, height uint64, success bool) {
+	t.Helper()
+	runner := NewHTLCRunner(s.library, tx, height)
+	switch tx.GetTxBody().(*types.TxContractV2Body).FunctionType {
+	case contractv2.HTLC_Create:
+		runner.Create()
+	case contractv2.HTLC_Claim:
+		runner.Claim()
+	case contractv2.HTLC_Refund:
+		runner.Refund()
+	}
+	state := s.contract.GetContractV2State(tx.Hash().String())
+	if state == nil {
+		t.Fatal("no contract state")
+	}
+	if ok := state.State == types.Contract_Success; ok != success {
+		t.Fatalf("success %v, want %v: %s", ok, success, state.Error)
+	}
+}
+
+func (s *testStates) swap(t *testing.T, address hasharry.Address) *htlc.Swap {
+	header := s.contract.GetContractV2(address.String())
+	if header == nil {
+		t.Fatal("no swap")
+	}
+	return header.Body.(*htlc.Swap)
+}
+
+func claimTx(t *testing.T, swap hasharry.Address, nonce uint64, preimage []byte) types.ITransaction {
+	return newTestHTLCTx(t, testClaimer, swap, nonce, contractv2.HTLC_Claim, &htlc_func.HTLCClaim{Preimage: preimage})
+}
+
+func TestClaimBeforeExpiry(t *testing.T) {
+	s := newTestStates(t)
+	defer s.close()
+	swap := s.createSwap(t, 1, testPreimage)
+
+	s.run(t, claimTx(t, swap, 1, []byte("not the preimage of the swaps..")), 2, false)
+	s.run(t, claimTx(t, swap, 2, testPreimage), testTimeLock-1, true)
+	s.confirm(testTimeLock - 1)
+
+	if s.balance(testRecipient) != testAmount {
+		t.Fatalf("recipient balance %d, want %d", s.balance(testRecipient), testAmount)
+	}
+	if s.balance(swap) != 0 {
+		t.Fatalf("swap balance %d after the claim", s.balance(swap))
+	}
+	if state := s.swap(t, swap); state.State != htlc.Swap_Claimed || string(state.Preimage) != string(testPreimage) {
+		t.Fatalf("swap %s, the preimage is not revealed", state.State.String())
+	}
+}
+
+// The hash lock of a short preimage can not be claimed, the swap of the
+// other chain would not take the preimage
+func TestClaimShortPreimage(t *testing.T) {
+	s := newTestStates(t)
+	defer s.close()
+	short := testPreimage[:31]
+	swap := s.createSwap(t, 1, short)
+
+	s.run(t, claimTx(t, swap, 1, short), 2, false)
+	if state := s.swap(t, swap); state.State != htlc.Swap_Open {
+		t.Fatalf("swap %s, want open", state.State.String())
+	}
+}
+
+func TestDoubleClaim(t *testing.T) {
+	s := newTestStates(t)
+	defer s.close()
+	swap := s.createSwap(t, 1, testPreimage)
+
+	s.run(t, claimTx(t, swap, 1, testPreimage), 2, true)
+	s.confirm(2)
+	s.run(t, claimTx(t, swap, 2, testPreimage), 3, false)
+	s.run(t, newTestHTLCTx(t, testSender, swap, 2, contractv2.HTLC_Refund, &htlc_func.HTLCRefund{}), testTimeLock, false)
+	s.confirm(testTimeLock)
+	if s.balance(testRecipient) != testAmount {
+		t.Fatalf("recipient balance %d, want %d", s.balance(testRecipient), testAmount)
+	}
+}
+
+func TestRefundAtTimeLock(t *testing.T) {
+	s := newTestStates(t)
+	defer s.close()
+	swap := s.createSwap(t, 1, testPreimage)
+	balance := s.balance(testSender)
+
+	refund := func(nonce uint64) types.ITransaction {
+		return newTestHTLCTx(t, testClaimer, swap, nonce, contractv2.HTLC_Refund, &htlc_func.HTLCRefund{})
+	}
+	s.run(t, refund(1), testTimeLock-1, false)
+	// The swap expires at the time lock, a claim is refused from there
+	s.run(t, claimTx(t, swap, 2, testPreimage), testTimeLock, false)
+	s.run(t, refund(3), testTimeLock, true)
+	s.confirm(testTimeLock)
+
+	if s.balance(testSender) != balance+testAmount {
+		t.Fatalf("sender balance %d, want %d", s.balance(testSender), balance+testAmount)
+	}
+	if s.balance(testRecipient) != 0 {
+		t.Fatalf("recipient balance %d after the refund", s.balance(testRecipient))
+	}
+	if state := s.swap(t, swap); state.State != htlc.Swap_Refunded {
+		t.Fatalf("swap %s, want refunded", state.State.String())
+	}
+	s.run(t, refund(4), testTimeLock+1, false)
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
 	"github.com/uworldao/UWORLD/core/types/contractv2/exchange"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
+	"github.com/uworldao/UWORLD/core/types/contractv2/token"
 	"strings"
 )
 
@@ -23,11 +26,41 @@ func NewRunnerLibrary(aState _interface.IAccountState, cState _interface.IContra
 func (r *RunnerLibrary) ContractSymbol(token hasharry.Address) (string, error) {
 	token0Record := r.cState.GetContract(token.String())
 	if token0Record == nil {
+		if tk := r.GetToken(token); tk != nil {
+			return tk.Symbol, nil
+		}
 		return "", fmt.Errorf("%s is not exist", token.String())
 	}
 	return token0Record.CoinAbbr, nil
 }
 
+// TokenExist returns whether the address is a token issued by a
+// contract transaction or a token contract
+func (r *RunnerLibrary) TokenExist(token hasharry.Address) bool {
+	if r.cState.GetContract(token.String()) != nil {
+		return true
+	}
+	return r.GetToken(token) != nil
+}
+
+func (r *RunnerLibrary) GetToken(address hasharry.Address) *token.Token {
+	contract := r.GetContractV2(address.String())
+	if contract == nil || contract.Type != contractv2.Token_ {
+		return nil
+	}
+	tk, _ := contract.Body.(*token.Token)
+	return tk
+}
+
+// Amount the spender may still transfer from the owner of the token
+func (r *RunnerLibrary) GetAllowance(token, owner, spender hasharry.Address) uint64 {
+	return r.cState.GetAllowance(token, owner, spender)
+}
+
+func (r *RunnerLibrary) SetAllowance(token, owner, spender hasharry.Address, amount uint64) {
+	r.cState.SetAllowance(token, owner, spender, amount)
+}
+
 func (r *RunnerLibrary) GetContract(contractAddr string) *types.Contract {
 	return r.cState.GetContract(contractAddr)
 }
@@ -76,6 +109,66 @@ func (r *RunnerLibrary) RunEvent(event *types.Event) {
 	}
 }
 
+func (r *RunnerLibrary) GetCollection(address hasharry.Address) *nft.Collection {
+	contract := r.GetContractV2(address.String())
+	if contract == nil || contract.Type != contractv2.NFT_ {
+		return nil
+	}
+	collection, _ := contract.Body.(*nft.Collection)
+	return collection
+}
+
+func (r *RunnerLibrary) GetNFT(collection hasharry.Address, id uint64) *nft.Token {
+	return r.cState.GetNFT(collection, id)
+}
+
+// SetNFT stores the token and moves its owner entry from the previous
+// owner to its owner
+func (r *RunnerLibrary) SetNFT(token *nft.Token, previous hasharry.Address) {
+	if !previous.IsEqual(token.Owner) {
+		if !previous.IsEqual(hasharry.Address{}) {
+			r.cState.RemoveOwnerNFT(previous, token.Key())
+		}
+		r.cState.AddOwnerNFT(token.Owner, token.Key())
+	}
+	r.cState.SetNFT(token)
+}
+
+func (r *RunnerLibrary) BurnNFT(token *nft.Token) {
+	r.cState.RemoveOwnerNFT(token.Owner, token.Key())
+	r.cState.DeleteNFT(token.Collection, token.Id)
+}
+
+func (r *RunnerLibrary) GetCollectionNFTs(collection hasharry.Address, start, count uint64) []*nft.Token {
+	return r.cState.GetCollectionNFTs(collection, start, count)
+}
+
+func (r *RunnerLibrary) GetOwnerNFTCount(owner hasharry.Address) uint64 {
+	return r.cState.GetOwnerNFTCount(owner)
+}
+
+func (r *RunnerLibrary) GetOwnerNFTs(owner hasharry.Address, start, count uint64) []nft.TokenKey {
+	return r.cState.GetOwnerNFTs(owner, start, count)
+}
+
+func (r *RunnerLibrary) GetSwap(address hasharry.Address) *htlc.Swap {
+	contract := r.GetContractV2(address.String())
+	if contract == nil || contract.Type != contractv2.HTLC_ {
+		return nil
+	}
+	swap, _ := contract.Body.(*htlc.Swap)
+	return swap
+}
+
+// AddHashLockSwap adds the swap to the index of its hash lock
+func (r *RunnerLibrary) AddHashLockSwap(hashLock hasharry.Hash, address hasharry.Address) {
+	r.cState.SetHashLockSwaps(hashLock, append(r.cState.GetHashLockSwaps(hashLock), address))
+}
+
+func (r *RunnerLibrary) GetHashLockSwaps(hashLock hasharry.Hash) htlc.HashLockSwaps {
+	return r.cState.GetHashLockSwaps(hashLock)
+}
+
 func (r *RunnerLibrary) GetPair(pairAddress hasharry.Address) (*exchange.Pair, error) {
 	pairContract := r.GetContractV2(pairAddress.String())
 	if pairContract != nil {
@@ -0,0 +1,287 @@
+package nft_runner
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/codec"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/runner/library"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
+	"github.com/uworldao/UWORLD/core/types/functionbody/nft_func"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+type NFTRunner struct {
+	library      *library.RunnerLibrary
+	header       *contractv2.ContractV2
+	collection   *nft.Collection
+	address      hasharry.Address
+	tx           types.ITransaction
+	contractBody *types.TxContractV2Body
+	events       []*types.Event
+	height       uint64
+}
+
+func NewNFTRunner(lib *library.RunnerLibrary, tx types.ITransaction, height uint64) *NFTRunner {
+	var collection *nft.Collection
+	address := tx.GetTxBody().GetContract()
+	header := lib.GetContractV2(address.String())
+	if header != nil {
+		collection, _ = header.Body.(*nft.Collection)
+	}
+
+	contractBody := tx.GetTxBody().(*types.TxContractV2Body)
+	return &NFTRunner{library: lib,
+		header:       header,
+		address:      address,
+		tx:           tx,
+		collection:   collection,
+		contractBody: contractBody,
+		events:       make([]*types.Event, 0),
+		height:       height,
+	}
+}
+
+func (n *NFTRunner) PreCreateVerify() error {
+	if n.header != nil || n.library.GetContract(n.address.String()) != nil {
+		return fmt.Errorf("contract %s already exist", n.address.String())
+	}
+	address, err := CollectionAddress(param.Net, n.tx.From().String(), n.tx.GetNonce())
+	if err != nil {
+		return err
+	}
+	if address != n.address.String() {
+		return errors.New("wrong collection contract address")
+	}
+	return nil
+}
+
+func (n *NFTRunner) PreMintVerify() error {
+	if err := n.verifyExist(); err != nil {
+		return err
+	}
+	if !n.collection.Creator.IsEqual(n.tx.From()) {
+		return errors.New("forbidden")
+	}
+	return nil
+}
+
+func (n *NFTRunner) PreTransferVerify() error {
+	funcBody, _ := n.contractBody.Function.(*nft_func.NFTTransfer)
+	if funcBody == nil {
+		return errors.New("wrong contractV2 function")
+	}
+	_, err := n.spendable(funcBody.Id)
+	return err
+}
+
+func (n *NFTRunner) PreApproveVerify() error {
+	funcBody, _ := n.contractBody.Function.(*nft_func.NFTApprove)
+	if funcBody == nil {
+		return errors.New("wrong contractV2 function")
+	}
+	_, err := n.owned(funcBody.Id)
+	return err
+}
+
+func (n *NFTRunner) PreBurnVerify() error {
+	funcBody, _ := n.contractBody.Function.(*nft_func.NFTBurn)
+	if funcBody == nil {
+		return errors.New("wrong contractV2 function")
+	}
+	_, err := n.spendable(funcBody.Id)
+	return err
+}
+
+func (n *NFTRunner) Create() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = n.events
+		}
+		n.library.SetContractV2State(n.tx.Hash().String(), state)
+	}()
+
+	if err := n.PreCreateVerify(); err != nil {
+		ERR = err
+		return
+	}
+	createBody := n.contractBody.Function.(*nft_func.NFTCreateBody)
+	n.library.SetContractV2(&contractv2.ContractV2{
+		Address:    n.address,
+		CreateHash: n.tx.Hash(),
+		Type:       n.contractBody.Type,
+		Body:       nft.NewCollection(createBody.Name, createBody.Symbol, n.tx.From()),
+	})
+}
+
+func (n *NFTRunner) Mint() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = n.events
+		}
+		n.library.SetContractV2State(n.tx.Hash().String(), state)
+	}()
+
+	if err := n.PreMintVerify(); err != nil {
+		ERR = err
+		return
+	}
+	funcBody := n.contractBody.Function.(*nft_func.NFTMint)
+	token := &nft.Token{
+		Collection:   n.address,
+		Id:           n.collection.NextId,
+		Owner:        funcBody.To,
+		URI:          funcBody.URI,
+		MetadataHash: funcBody.MetadataHash,
+	}
+	n.collection.NextId++
+	n.collection.Supply++
+	n.library.SetNFT(token, hasharry.Address{})
+	n.event(types.Event_NFTMint, hasharry.StringToAddress("mint"), token.Owner, token.Id)
+	n.update()
+}
+
+func (n *NFTRunner) Transfer() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = n.events
+		}
+		n.library.SetContractV2State(n.tx.Hash().String(), state)
+	}()
+
+	funcBody := n.contractBody.Function.(*nft_func.NFTTransfer)
+	token, err := n.spendable(funcBody.Id)
+	if err != nil {
+		ERR = err
+		return
+	}
+	from := token.Owner
+	token.Owner = funcBody.To
+	token.Approved = hasharry.Address{}
+	n.library.SetNFT(token, from)
+	n.event(types.Event_NFTTransfer, from, token.Owner, token.Id)
+}
+
+func (n *NFTRunner) Approve() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = n.events
+		}
+		n.library.SetContractV2State(n.tx.Hash().String(), state)
+	}()
+
+	funcBody := n.contractBody.Function.(*nft_func.NFTApprove)
+	token, err := n.owned(funcBody.Id)
+	if err != nil {
+		ERR = err
+		return
+	}
+	token.Approved = funcBody.Spender
+	n.library.SetNFT(token, token.Owner)
+}
+
+func (n *NFTRunner) Burn() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = n.events
+		}
+		n.library.SetContractV2State(n.tx.Hash().String(), state)
+	}()
+
+	funcBody := n.contractBody.Function.(*nft_func.NFTBurn)
+	token, err := n.spendable(funcBody.Id)
+	if err != nil {
+		ERR = err
+		return
+	}
+	n.library.BurnNFT(token)
+	n.collection.Supply--
+	n.event(types.Event_NFTBurn, token.Owner, hasharry.StringToAddress("burn"), token.Id)
+	n.update()
+}
+
+// The token of the id, the sender must be its owner or approved
+func (n *NFTRunner) spendable(id uint64) (*nft.Token, error) {
+	if err := n.verifyExist(); err != nil {
+		return nil, err
+	}
+	token := n.library.GetNFT(n.address, id)
+	if token == nil {
+		return nil, fmt.Errorf("token %d of %s is not exist", id, n.address.String())
+	}
+	if !token.CanSpend(n.tx.From()) {
+		return nil, errors.New("forbidden")
+	}
+	return token, nil
+}
+
+// The token of the id, the sender must be its owner
+func (n *NFTRunner) owned(id uint64) (*nft.Token, error) {
+	if err := n.verifyExist(); err != nil {
+		return nil, err
+	}
+	token := n.library.GetNFT(n.address, id)
+	if token == nil {
+		return nil, fmt.Errorf("token %d of %s is not exist", id, n.address.String())
+	}
+	if !token.Owner.IsEqual(n.tx.From()) {
+		return nil, errors.New("forbidden")
+	}
+	return token, nil
+}
+
+func (n *NFTRunner) verifyExist() error {
+	if n.collection == nil {
+		return fmt.Errorf("collection %s is not exist", n.address.String())
+	}
+	return nil
+}
+
+func (n *NFTRunner) update() {
+	n.header.Body = n.collection
+	n.library.SetContractV2(n.header)
+}
+
+func (n *NFTRunner) event(eventType types.EventType, from, to hasharry.Address, id uint64) {
+	n.events = append(n.events, &types.Event{
+		EventType: eventType,
+		From:      from,
+		To:        to,
+		Token:     n.address,
+		Amount:    id,
+		Height:    n.height,
+	})
+}
+
+func CollectionAddress(net, from string, nonce uint64) (string, error) {
+	bytes := append([]byte(from), codec.Uint64toBytes(nonce)...)
+	return ut.GenerateContractV2Address(net, bytes)
+}
@@ -0,0 +1,327 @@
+package token_runner
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/codec"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/runner/library"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/token"
+	"github.com/uworldao/UWORLD/core/types/functionbody/token_func"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+type TokenRunner struct {
+	library      *library.RunnerLibrary
+	tkHeader     *contractv2.ContractV2
+	token        *token.Token
+	address      hasharry.Address
+	tx           types.ITransaction
+	contractBody *types.TxContractV2Body
+	events       []*types.Event
+	height       uint64
+}
+
+func NewTokenRunner(lib *library.RunnerLibrary, tx types.ITransaction, height uint64) *TokenRunner {
+	var tk *token.Token
+	address := tx.GetTxBody().GetContract()
+	tkHeader := lib.GetContractV2(address.String())
+	if tkHeader != nil {
+		tk, _ = tkHeader.Body.(*token.Token)
+	}
+
+	contractBody := tx.GetTxBody().(*types.TxContractV2Body)
+	return &TokenRunner{library: lib,
+		tkHeader:     tkHeader,
+		address:      address,
+		tx:           tx,
+		token:        tk,
+		contractBody: contractBody,
+		events:       make([]*types.Event, 0),
+		height:       height,
+	}
+}
+
+func (t *TokenRunner) PreInitVerify() error {
+	if t.tkHeader != nil || t.library.GetContract(t.address.String()) != nil {
+		return fmt.Errorf("contract %s already exist", t.address.String())
+	}
+	address, err := TokenAddress(param.Net, t.tx.From().String(), t.tx.GetNonce())
+	if err != nil {
+		return err
+	}
+	if address != t.address.String() {
+		return errors.New("wrong token contract address")
+	}
+	return nil
+}
+
+func (t *TokenRunner) PreApproveVerify() error {
+	return t.verifyExist()
+}
+
+func (t *TokenRunner) PreTransferFromVerify() error {
+	if err := t.verifyExist(); err != nil {
+		return err
+	}
+	funcBody, _ := t.contractBody.Function.(*token_func.TokenTransferFrom)
+	if funcBody == nil {
+		return errors.New("wrong contractV2 function")
+	}
+	if allowance := t.library.GetAllowance(t.address, funcBody.Owner, t.tx.From()); allowance < funcBody.Amount {
+		return fmt.Errorf("the allowance %d is less than %d", allowance, funcBody.Amount)
+	}
+	if balance := t.library.GetBalance(funcBody.Owner, t.address); balance < funcBody.Amount {
+		return fmt.Errorf("insufficient balance %s", funcBody.Owner.String())
+	}
+	return nil
+}
+
+func (t *TokenRunner) PreBurnVerify() error {
+	if err := t.verifyExist(); err != nil {
+		return err
+	}
+	funcBody, _ := t.contractBody.Function.(*token_func.TokenBurn)
+	if funcBody == nil {
+		return errors.New("wrong contractV2 function")
+	}
+	if balance := t.library.GetBalance(t.tx.From(), t.address); balance < funcBody.Amount {
+		return fmt.Errorf("insufficient balance %s", t.tx.From().String())
+	}
+	return nil
+}
+
+func (t *TokenRunner) PreMintVerify() error {
+	if err := t.verifyExist(); err != nil {
+		return err
+	}
+	funcBody, _ := t.contractBody.Function.(*token_func.TokenMint)
+	if funcBody == nil {
+		return errors.New("wrong contractV2 function")
+	}
+	if err := t.token.VerifyOwner(t.tx.From()); err != nil {
+		return err
+	}
+	return t.verifySupply(funcBody.Amount)
+}
+
+func (t *TokenRunner) Init() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = t.events
+		}
+		t.library.SetContractV2State(t.tx.Hash().String(), state)
+	}()
+
+	if err := t.PreInitVerify(); err != nil {
+		ERR = err
+		return
+	}
+	initBody := t.contractBody.Function.(*token_func.TokenInitBody)
+	var owner hasharry.Address
+	if initBody.Mintable {
+		owner = t.tx.From()
+	}
+	tk := token.NewToken(initBody.Name, initBody.Symbol, initBody.Decimals, owner)
+	if initBody.Amount > 0 {
+		tk.TotalSupply = initBody.Amount
+		t.mintEvent(initBody.Receiver, initBody.Amount)
+		if err := t.runEvents(); err != nil {
+			ERR = err
+			return
+		}
+	}
+	t.library.SetContractV2(&contractv2.ContractV2{
+		Address:    t.address,
+		CreateHash: t.tx.Hash(),
+		Type:       t.contractBody.Type,
+		Body:       tk,
+	})
+}
+
+func (t *TokenRunner) Approve() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = t.events
+		}
+		t.library.SetContractV2State(t.tx.Hash().String(), state)
+	}()
+
+	if err := t.verifyExist(); err != nil {
+		ERR = err
+		return
+	}
+	funcBody, _ := t.contractBody.Function.(*token_func.TokenApprove)
+	t.library.SetAllowance(t.address, t.tx.From(), funcBody.Spender, funcBody.Amount)
+}
+
+func (t *TokenRunner) TransferFrom() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = t.events
+		}
+		t.library.SetContractV2State(t.tx.Hash().String(), state)
+	}()
+
+	if err := t.verifyExist(); err != nil {
+		ERR = err
+		return
+	}
+	funcBody, _ := t.contractBody.Function.(*token_func.TokenTransferFrom)
+	allowance := t.library.GetAllowance(t.address, funcBody.Owner, t.tx.From())
+	if allowance < funcBody.Amount {
+		ERR = fmt.Errorf("the allowance %d is less than %d", allowance, funcBody.Amount)
+		return
+	}
+	t.transferEvent(funcBody.Owner, funcBody.To, funcBody.Amount)
+	if err := t.runEvents(); err != nil {
+		ERR = err
+		return
+	}
+	t.library.SetAllowance(t.address, funcBody.Owner, t.tx.From(), allowance-funcBody.Amount)
+}
+
+func (t *TokenRunner) Burn() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = t.events
+		}
+		t.library.SetContractV2State(t.tx.Hash().String(), state)
+	}()
+
+	if err := t.verifyExist(); err != nil {
+		ERR = err
+		return
+	}
+	funcBody, _ := t.contractBody.Function.(*token_func.TokenBurn)
+	if funcBody.Amount > t.token.TotalSupply {
+		ERR = errors.New("burn more than the total supply")
+		return
+	}
+	t.burnEvent(t.tx.From(), funcBody.Amount)
+	if err := t.runEvents(); err != nil {
+		ERR = err
+		return
+	}
+	t.token.TotalSupply -= funcBody.Amount
+	t.update()
+}
+
+func (t *TokenRunner) Mint() {
+	var ERR error
+	state := &types.ContractV2State{State: types.Contract_Success}
+	defer func() {
+		if ERR != nil {
+			state.State = types.Contract_Failed
+			state.Error = ERR.Error()
+		} else {
+			state.Event = t.events
+		}
+		t.library.SetContractV2State(t.tx.Hash().String(), state)
+	}()
+
+	if err := t.PreMintVerify(); err != nil {
+		ERR = err
+		return
+	}
+	funcBody, _ := t.contractBody.Function.(*token_func.TokenMint)
+	t.mintEvent(funcBody.To, funcBody.Amount)
+	if err := t.runEvents(); err != nil {
+		ERR = err
+		return
+	}
+	t.token.TotalSupply += funcBody.Amount
+	t.update()
+}
+
+func (t *TokenRunner) verifyExist() error {
+	if t.token == nil {
+		return fmt.Errorf("token %s is not exist", t.address.String())
+	}
+	return nil
+}
+
+func (t *TokenRunner) verifySupply(amount uint64) error {
+	if t.token.TotalSupply+amount > param.MaxAllContractCoin || t.token.TotalSupply+amount < amount {
+		return fmt.Errorf("the total supply cannot exceed %.8f", float64(param.MaxAllContractCoin)/float64(param.AtomsPerCoin))
+	}
+	return nil
+}
+
+func (t *TokenRunner) update() {
+	t.tkHeader.Body = t.token
+	t.library.SetContractV2(t.tkHeader)
+}
+
+func (t *TokenRunner) transferEvent(from, to hasharry.Address, amount uint64) {
+	t.events = append(t.events, &types.Event{
+		EventType: types.Event_Transfer,
+		From:      from,
+		To:        to,
+		Token:     t.address,
+		Amount:    amount,
+		Height:    t.height,
+	})
+}
+
+func (t *TokenRunner) mintEvent(to hasharry.Address, amount uint64) {
+	t.events = append(t.events, &types.Event{
+		EventType: types.Event_Mint,
+		From:      hasharry.StringToAddress("mint"),
+		To:        to,
+		Token:     t.address,
+		Amount:    amount,
+		Height:    t.height,
+	})
+}
+
+func (t *TokenRunner) burnEvent(from hasharry.Address, amount uint64) {
+	t.events = append(t.events, &types.Event{
+		EventType: types.Event_Burn,
+		From:      from,
+		To:        hasharry.StringToAddress("burn"),
+		Token:     t.address,
+		Amount:    amount,
+		Height:    t.height,
+	})
+}
+
+func (t *TokenRunner) runEvents() error {
+	for _, event := range t.events {
+		if err := t.library.PreRunEvent(event); err != nil {
+			return err
+		}
+	}
+	for _, event := range t.events {
+		t.library.RunEvent(event)
+	}
+	return nil
+}
+
+func TokenAddress(net, from string, nonce uint64) (string, error) {
+	bytes := append([]byte(from), codec.Uint64toBytes(nonce)...)
+	return ut.GenerateContractV2Address(net, bytes)
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"bytes"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/interface"
+	runner2 "github.com/uworldao/UWORLD/core/runner"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+	"sort"
+	"time"
+)
+
+// Run the transaction against copies of the head states as if it was
+// packed into the next block. Nothing is committed and the tx pool is
+// not touched, a transaction that fails is reported by the Error of
+// the result.
+func (blc *BlockChain) SimulateTransaction(tx types.ITransaction) (*types.Simulation, error) {
+	blc.mutex.RLock()
+	height := blc.currentHeight + 1
+	stateRoot, contractRoot := blc.stateRoot, blc.contractRoot
+	confirmedHeight := blc.confirmedHeight
+	blc.mutex.RUnlock()
+
+	accountState, err := blc.accountState.StateAt(stateRoot, confirmedHeight)
+	if err != nil {
+		return nil, err
+	}
+	contractState, err := blc.contractState.StateAt(contractRoot, confirmedHeight)
+	if err != nil {
+		return nil, err
+	}
+	runner := runner2.NewContractRunner(accountState, contractState)
+	simulation := &types.Simulation{
+		TxHash:  tx.Hash(),
+		Height:  height,
+		Fees:    tx.GetFees(),
+		Changes: make([]*types.BalanceChange, 0),
+	}
+	if err := blc.simulateTx(accountState, contractState, runner, tx, height); err != nil {
+		simulation.Error = err.Error()
+		return simulation, nil
+	}
+	if tx.GetTxType() == types.ContractV2_ {
+		simulation.State = contractState.GetContractV2State(tx.Hash().String())
+		if simulation.State != nil && simulation.State.State == types.Contract_Failed {
+			simulation.Error = simulation.State.Error
+		}
+	}
+
+	// Balances before the transaction are read from another copy
+	// of the head state
+	origin, err := blc.accountState.StateAt(stateRoot, confirmedHeight)
+	if err != nil {
+		return nil, err
+	}
+	addresses := txAddresses(tx, contractState)
+	addresses[param.FeeAddress] = true
+	addresses[param.EaterAddress] = true
+	simulation.Changes = balanceChanges(origin, accountState, addresses)
+	return simulation, nil
+}
+
+func (blc *BlockChain) simulateTx(accountState _interface.IAccountState, contractState _interface.IContractState, runner *runner2.ContractRunner,
+	tx types.ITransaction, height uint64) error {
+	if err := tx.VerifyTx(height); err != nil {
+		return err
+	}
+	if err := blc.consensus.VerifyTx(tx, height); err != nil {
+		return err
+	}
+	if err := accountState.VerifyPendingState(tx, make(map[hasharry.Address]types.IAccount), height); err != nil {
+		return err
+	}
+	if err := contractState.VerifyState(tx); err != nil {
+		return err
+	}
+	if err := runner.Verify(tx, height-1); err != nil {
+		return err
+	}
+	if err := applyTx(accountState, contractState, runner, tx, height, uint64(time.Now().Unix())); err != nil {
+		return err
+	}
+	txs := types.Transactions{tx}
+	if err := accountState.UpdateFees(txs.SumFees(), height); err != nil {
+		return err
+	}
+	return accountState.UpdateConsumption(txs.SumConsumption(), height)
+}
+
+// Compare the coin accounts of the addresses in two states, only
+// the coins that changed are returned
+func balanceChanges(before, after _interface.IAccountState, addresses map[hasharry.Address]bool) []*types.BalanceChange {
+	sorted := make([]hasharry.Address, 0, len(addresses))
+	for address, _ := range addresses {
+		sorted = append(sorted, address)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Bytes(), sorted[j].Bytes()) < 0
+	})
+
+	changes := make([]*types.BalanceChange, 0)
+	for _, address := range sorted {
+		beforeAcc := before.GetAccountState(address).(*types.Account)
+		afterAcc := after.GetAccountState(address).(*types.Account)
+		contracts := make([]string, 0)
+		exist := make(map[string]bool)
+		for _, coins := range []*types.Coins{beforeAcc.Coins, afterAcc.Coins} {
+			if coins == nil {
+				continue
+			}
+			for _, coin := range *coins {
+				if !exist[coin.Contract] {
+					exist[coin.Contract] = true
+					contracts = append(contracts, coin.Contract)
+				}
+			}
+		}
+		for _, lock := range afterAcc.Locks {
+			if !exist[lock.Contract] {
+				exist[lock.Contract] = true
+				contracts = append(contracts, lock.Contract)
+			}
+		}
+		for _, contract := range contracts {
+			beforeCoin, _ := beforeAcc.Coins.Get(contract)
+			afterCoin, _ := afterAcc.Coins.Get(contract)
+			change := &types.BalanceChange{
+				Address:   address,
+				Contract:  contract,
+				Balance:   int64(afterCoin.Balance) - int64(beforeCoin.Balance),
+				LockedIn:  int64(afterCoin.LockIn) - int64(beforeCoin.LockIn),
+				LockedOut: int64(afterCoin.LockOut) - int64(beforeCoin.LockOut),
+				Locked:    int64(afterAcc.GetLocked(contract)) - int64(beforeAcc.GetLocked(contract)),
+			}
+			if change.Balance != 0 || change.LockedIn != 0 || change.LockedOut != 0 || change.Locked != 0 {
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes
+}
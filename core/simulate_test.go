@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+)
+
+func findChange(changes []*types.BalanceChange, address hasharry.Address) *types.BalanceChange {
+	for _, change := range changes {
+		if change.Address == address && change.Contract == param.Token.String() {
+			return change
+		}
+	}
+	return &types.BalanceChange{}
+}
+
+func TestSimulateTransaction(t *testing.T) {
+	c := newTestChain(t)
+	defer c.remove()
+	c.mine(testGenesisTime + 10)
+	stateRoot, contractRoot, consensusRoot := c.TireRoot()
+	sent, received := c.balance(testSender), c.balance(testReceiver)
+
+	amount := uint64(5 * param.AtomsPerCoin)
+	tx := newTestTransfer(t, 1, testReceiver, amount)
+	sim, err := c.SimulateTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.Error != "" || sim.Height != 2 || sim.TxHash != tx.Hash() {
+		t.Fatalf("simulation at %d failed: %s", sim.Height, sim.Error)
+	}
+	// The coins received in the block are locked until it is confirmed
+	if change := findChange(sim.Changes, testSender); change.Balance != -int64(amount) {
+		t.Fatalf("sender balance change %d, want %d", change.Balance, -int64(amount))
+	}
+	if change := findChange(sim.Changes, testReceiver); change.LockedIn != int64(amount-param.Fees) {
+		t.Fatalf("receiver locked in %d, want %d", change.LockedIn, amount-param.Fees)
+	}
+	if change := findChange(sim.Changes, param.FeeAddress); change.LockedIn != int64(param.Fees) {
+		t.Fatalf("fees locked in %d, want %d", change.LockedIn, param.Fees)
+	}
+
+	// A failed simulation is reported by the result
+	sim, err = c.SimulateTransaction(newTestTransfer(t, 1, testReceiver, testAlloc+1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sim.Error == "" || len(sim.Changes) != 0 {
+		t.Fatal("a transfer of more than the balance succeeds")
+	}
+
+	// The head state is not changed and takes the transaction again
+	if s, ct, cs := c.TireRoot(); s != stateRoot || ct != contractRoot || cs != consensusRoot {
+		t.Fatal("the roots of the chain changed")
+	}
+	if c.balance(testSender) != sent || c.balance(testReceiver) != received {
+		t.Fatal("the balances of the chain changed")
+	}
+	if nonce := c.accountState.GetAccountState(testSender).GetNonce(); nonce != 0 {
+		t.Fatalf("sender nonce %d, want 0", nonce)
+	}
+	c.mine(testGenesisTime+20, tx)
+	if want := received + amount - param.Fees; c.balance(testReceiver) != want {
+		t.Fatalf("balance %d, want %d", c.balance(testReceiver), want)
+	}
+}
@@ -0,0 +1,320 @@
+package core
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/crypto/hash"
+	"github.com/uworldao/UWORLD/database/triedb"
+	log "github.com/uworldao/UWORLD/log/log15"
+	"io"
+	"os"
+)
+
+// A snapshot is a gzip stream of rlp items: the snapshot meta, the
+// headers from the genesis to the height after the snapshot, and then
+// the nodes of the state, contract and consensus tries until the end.
+// The tries are only taken at a confirmed height, so the snapshot can
+// not be rolled back by a fork.
+
+const snapshotVersion = 1
+
+// Number of nodes between two progress logs
+const snapshotProgress = 100000
+
+// Number of headers stored in one batch when restoring
+const snapshotHeaderBatch = 1000
+
+// Number of trie nodes written to the node store in one batch when restoring
+const snapshotNodeBatch = 10000
+
+type snapshotMeta struct {
+	Version         uint32
+	Height          uint64
+	ConfirmedHeight uint64
+	StateRoot       hasharry.Hash
+	ContractRoot    hasharry.Hash
+	ConsensusRoot   hasharry.Hash
+}
+
+// Write the headers and the state of the confirmed height to the
+// snapshot file
+func (blc *BlockChain) ExportSnapshot(path string) error {
+	height := blc.GetConfirmedHeight()
+	if height >= blc.GetLastHeight() {
+		return fmt.Errorf("confirmed height %d has no next block, the roots of the state can not be verified", height)
+	}
+	stateRoot, contractRoot, consensusRoot, err := blc.RootsAt(height)
+	if err != nil {
+		return err
+	}
+	confirmedHeight, err := blc.storage.GetHistoryConfirmedHeight(height)
+	if err != nil {
+		return err
+	}
+	meta := &snapshotMeta{
+		Version:         snapshotVersion,
+		Height:          height,
+		ConfirmedHeight: confirmedHeight,
+		StateRoot:       stateRoot,
+		ContractRoot:    contractRoot,
+		ConsensusRoot:   consensusRoot,
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	zw := gzip.NewWriter(writer)
+
+	if err := rlp.Encode(zw, meta); err != nil {
+		return err
+	}
+	for h := uint64(0); h <= height+1; h++ {
+		header, err := blc.storage.GetHeaderByHeight(h)
+		if err != nil {
+			return fmt.Errorf("get header %d failed! %s", h, err.Error())
+		}
+		if err := rlp.Encode(zw, header); err != nil {
+			return err
+		}
+	}
+
+	var nodes uint64
+	write := func(hash hasharry.Hash, node []byte) error {
+		nodes++
+		if nodes%snapshotProgress == 0 {
+			log.Info("Exporting snapshot", "nodes", nodes)
+		}
+		return rlp.Encode(zw, node)
+	}
+	if err := blc.accountState.DumpTrie(stateRoot, write); err != nil {
+		return fmt.Errorf("dump state trie failed! %s", err.Error())
+	}
+	if err := blc.contractState.DumpTrie(contractRoot, write); err != nil {
+		return fmt.Errorf("dump contract trie failed! %s", err.Error())
+	}
+	if err := blc.consensus.DumpTrie(consensusRoot, write); err != nil {
+		return fmt.Errorf("dump consensus trie failed! %s", err.Error())
+	}
+
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	// The operators restoring the snapshot check it against this hash
+	checkpoint, err := blc.storage.GetHeaderByHeight(height + 1)
+	if err != nil {
+		return err
+	}
+	log.Info("Export snapshot completed", "height", height, "nodes", nodes, "file", path, "checkpoint", checkpoint.Hash.String())
+	return nil
+}
+
+// Restore the chain from the snapshot file. It is only allowed on a
+// chain that has nothing but the genesis block. The headers must link
+// from the local genesis block to the header after the snapshot height,
+// whose hash is the checkpoint given by a trusted source, and the tries
+// must match the roots of that header. Every header must be signed by a
+// winner of its term in the restored consensus state. Block bodies below
+// the snapshot are not available, the chain continues from the snapshot height.
+// The trie nodes are kept in a leveldb in nodesDir until the tries are
+// built from them, the directory is removed when the restore is done.
+func (blc *BlockChain) RestoreSnapshot(path, nodesDir string, checkpoint hasharry.Hash) error {
+	if blc.GetLastHeight() != 0 {
+		return fmt.Errorf("the chain already has blocks up to %d, snapshot can only be restored on an empty chain", blc.GetLastHeight())
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	stream := rlp.NewStream(zr, 0)
+
+	meta := &snapshotMeta{}
+	if err := stream.Decode(meta); err != nil {
+		return fmt.Errorf("read snapshot meta failed! %s", err.Error())
+	}
+	if meta.Version != snapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", meta.Version)
+	}
+	if meta.ConfirmedHeight > meta.Height {
+		return errors.New("wrong snapshot confirmed height")
+	}
+
+	headers, err := readSnapshotHeaders(stream, meta.Height+1)
+	if err != nil {
+		return err
+	}
+	genesis, err := blc.storage.GetHeaderByHeight(0)
+	if err != nil {
+		return err
+	}
+	if headers[0].Hash != genesis.Hash {
+		return fmt.Errorf("snapshot genesis %s is different from the local genesis %s", headers[0].Hash.String(), genesis.Hash.String())
+	}
+	next := headers[meta.Height+1]
+	if next.Hash != checkpoint {
+		return fmt.Errorf("snapshot header %d %s is different from the checkpoint %s", next.Height, next.Hash.String(), checkpoint.String())
+	}
+	if next.StateRoot != meta.StateRoot || next.ContractRoot != meta.ContractRoot || next.ConsensusRoot != meta.ConsensusRoot {
+		return errors.New("snapshot roots are different from the roots of the header")
+	}
+
+	if err := os.RemoveAll(nodesDir); err != nil {
+		return err
+	}
+	nodes := triedb.NewTrieDB(nodesDir)
+	if err := nodes.Open(); err != nil {
+		return err
+	}
+	defer os.RemoveAll(nodesDir)
+	defer nodes.Close()
+	count, err := writeSnapshotNodes(stream, nodes)
+	if err != nil {
+		return err
+	}
+	read := func(hash hasharry.Hash) ([]byte, error) {
+		node, err := nodes.Get(hash.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("snapshot is missing trie node %s", hash.String())
+		}
+		return node, nil
+	}
+	log.Info("Restoring snapshot tries", "height", meta.Height, "nodes", count)
+	if err := blc.accountState.SyncTrie(meta.StateRoot, read); err != nil {
+		return fmt.Errorf("restore state trie failed! %s", err.Error())
+	}
+	if err := blc.contractState.SyncTrie(meta.ContractRoot, read); err != nil {
+		return fmt.Errorf("restore contract trie failed! %s", err.Error())
+	}
+	if err := blc.consensus.SyncTrie(meta.ConsensusRoot, read); err != nil {
+		return fmt.Errorf("restore consensus trie failed! %s", err.Error())
+	}
+	if err := blc.verifySnapshotSigners(headers[1:meta.Height+1], meta.ConsensusRoot); err != nil {
+		return err
+	}
+
+	for start := uint64(1); start <= meta.Height; start += snapshotHeaderBatch {
+		end := start + snapshotHeaderBatch
+		if end > meta.Height+1 {
+			end = meta.Height + 1
+		}
+		if err := blc.storage.ImportHeaders(headers[start:end]); err != nil {
+			return err
+		}
+	}
+	blc.storage.UpdateHistoryConfirmedHeight(meta.Height, meta.ConfirmedHeight)
+
+	// The tip is moved last, an interrupted restore leaves the chain
+	// at the genesis block
+	if err := blc.storage.UpdateTip(meta.Height, meta.StateRoot, meta.ContractRoot, meta.ConsensusRoot); err != nil {
+		return err
+	}
+
+	blc.mutex.Lock()
+	defer blc.mutex.Unlock()
+	if err := blc.initTries(meta.StateRoot, meta.ContractRoot, meta.ConsensusRoot); err != nil {
+		return err
+	}
+	blc.currentHeight = meta.Height
+	blc.consensus.SetConfirmedHeader(headers[meta.ConfirmedHeight])
+	blc.confirmedHeight = meta.ConfirmedHeight
+	blc.accountState.UpdateConfirmedHeight(meta.ConfirmedHeight)
+	log.Info("Restore snapshot completed", "height", meta.Height, "confirmed", meta.ConfirmedHeight)
+	return nil
+}
+
+// Check that the signer of every header is a winner of its term in the
+// consensus state of the snapshot, the consensus trie is opened at the
+// root of the snapshot and set back to the current root when done
+func (blc *BlockChain) verifySnapshotSigners(headers []*types.Header, consensusRoot hasharry.Hash) error {
+	if err := blc.consensus.InitTrie(consensusRoot); err != nil {
+		return err
+	}
+	defer blc.consensus.InitTrie(blc.consensusRoot)
+
+	winners := make(map[uint64]map[hasharry.Address]bool)
+	for _, header := range headers {
+		signers, ok := winners[header.Term]
+		if !ok {
+			signers = make(map[hasharry.Address]bool)
+			if termWinners := blc.consensus.GetTermWinners(header.Term); termWinners != nil {
+				for _, winner := range termWinners.Candidates {
+					signers[winner.Signer] = true
+				}
+			}
+			winners[header.Term] = signers
+		}
+		if !signers[header.Signer] {
+			return fmt.Errorf("snapshot header %d is signed by %s which is not a winner of term %d", header.Height, header.Signer.String(), header.Term)
+		}
+	}
+	return nil
+}
+
+// Write the trie nodes until the end of the snapshot to the node store,
+// keyed by their hashes
+func writeSnapshotNodes(stream *rlp.Stream, nodes *triedb.TrieDB) (uint64, error) {
+	var count uint64
+	batch := nodes.NewBatch()
+	for {
+		var node []byte
+		if err := stream.Decode(&node); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return count, fmt.Errorf("read snapshot nodes failed! %s", err.Error())
+		}
+		batch.Put(hash.Hash(node).Bytes(), node)
+		count++
+		if batch.Len() >= snapshotNodeBatch {
+			if err := batch.Write(); err != nil {
+				return count, err
+			}
+			batch.Reset()
+		}
+		if count%snapshotProgress == 0 {
+			log.Info("Reading snapshot nodes", "nodes", count)
+		}
+	}
+	return count, batch.Write()
+}
+
+// Read the headers from the genesis to the height and check that
+// every header links to its parent and is signed by its signer
+func readSnapshotHeaders(stream *rlp.Stream, height uint64) ([]*types.Header, error) {
+	headers := make([]*types.Header, 0, height+1)
+	for h := uint64(0); h <= height; h++ {
+		header := &types.Header{}
+		if err := stream.Decode(header); err != nil {
+			return nil, fmt.Errorf("read snapshot header %d failed! %s", h, err.Error())
+		}
+		if header.Height != h {
+			return nil, fmt.Errorf("snapshot header %d has height %d", h, header.Height)
+		}
+		if h > 0 {
+			if header.ParentHash != headers[h-1].Hash {
+				return nil, fmt.Errorf("snapshot header %d does not link to its parent", h)
+			}
+			if err := header.VerifySignature(); err != nil {
+				return nil, fmt.Errorf("snapshot header %d is invalid! %s", h, err.Error())
+			}
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
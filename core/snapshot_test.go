@@ -0,0 +1,108 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/param"
+)
+
+// A chain of three blocks and its snapshot at the confirmed height 2,
+// with the hash of the header 3 as the checkpoint
+func newTestSnapshot(t *testing.T) (*testChain, string, hasharry.Hash) {
+	c := newTestChain(t)
+	c.mine(testGenesisTime+10, newTestTransfer(t, 1, testReceiver, 1*param.AtomsPerCoin))
+	c.mine(testGenesisTime+20, newTestTransfer(t, 2, testReceiver, 2*param.AtomsPerCoin))
+	c.mine(testGenesisTime + 30)
+	if c.GetConfirmedHeight() != 2 {
+		t.Fatalf("confirmed height %d, want 2", c.GetConfirmedHeight())
+	}
+	path := filepath.Join(c.dir, "state.snap")
+	if err := c.ExportSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := c.GetHeaderByHeight(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, path, checkpoint.Hash
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	c, path, checkpoint := newTestSnapshot(t)
+	defer c.remove()
+
+	r := newTestChain(t)
+	defer r.remove()
+	nodesDir := filepath.Join(r.dir, "restore")
+	if err := r.RestoreSnapshot(path, nodesDir, checkpoint); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(nodesDir); !os.IsNotExist(err) {
+		t.Fatal("the node store is not removed")
+	}
+	// The block 2 confirmed its parent
+	if r.GetLastHeight() != 2 || r.GetConfirmedHeight() != 1 {
+		t.Fatalf("height %d confirmed %d, want 2 and 1", r.GetLastHeight(), r.GetConfirmedHeight())
+	}
+	next, err := c.GetBlockByHeight(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ct, cs := r.TireRoot(); s != next.StateRoot || ct != next.ContractRoot || cs != next.ConsensusRoot {
+		t.Fatal("the restored roots are not the roots of the checkpoint header")
+	}
+	if want := 3*param.AtomsPerCoin - 2*param.Fees; r.balance(testReceiver) != want {
+		t.Fatalf("balance %d, want %d", r.balance(testReceiver), want)
+	}
+	// The restored chain takes the blocks after the snapshot
+	if err := r.InsertChain(next); err != nil {
+		t.Fatal(err)
+	}
+	r.mine(testGenesisTime+40, newTestTransfer(t, 3, testReceiver, 1*param.AtomsPerCoin))
+}
+
+func TestRestoreSnapshotCheckpoint(t *testing.T) {
+	c, path, _ := newTestSnapshot(t)
+	defer c.remove()
+
+	r := newTestChain(t)
+	defer r.remove()
+	parent, err := c.GetHeaderByHeight(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RestoreSnapshot(path, filepath.Join(r.dir, "restore"), parent.Hash); err == nil {
+		t.Fatal("a snapshot that does not end at the checkpoint is restored")
+	}
+	if r.GetLastHeight() != 0 {
+		t.Fatalf("last height %d after a refused snapshot", r.GetLastHeight())
+	}
+}
+
+func TestRestoreSnapshotSigners(t *testing.T) {
+	c, path, checkpoint := newTestSnapshot(t)
+	defer c.remove()
+
+	// The blocks of the snapshot are not signed by the authority of
+	// this chain
+	dir, err := ioutil.TempDir("", "blockchain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := openTestChainOf(t, dir, testSender)
+	defer r.remove()
+	stateRoot, contractRoot, consensusRoot := r.TireRoot()
+	if err := r.RestoreSnapshot(path, filepath.Join(r.dir, "restore"), checkpoint); err == nil {
+		t.Fatal("a snapshot with headers of a signer that is not a winner is restored")
+	}
+	if r.GetLastHeight() != 0 {
+		t.Fatalf("last height %d after a refused snapshot", r.GetLastHeight())
+	}
+	if s, ct, cs := r.TireRoot(); s != stateRoot || ct != contractRoot || cs != consensusRoot {
+		t.Fatal("the state of a refused snapshot is used")
+	}
+}
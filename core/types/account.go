@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/param"
+	"math"
+	"math/bits"
 )
 
 // Account information, not sending a transaction or sending an
@@ -19,6 +21,9 @@ type Account struct {
 	Coins           *Coins
 	OutJournal      *outJournal
 	InJournal       *inJournal
+	// Locked transfers, the accounts without locks are encoded
+	// as before they were added
+	Locks []*Lock `rlp:"tail"`
 }
 
 func NewAccount(stateKey hasharry.Address) *Account {
@@ -65,7 +70,7 @@ func (a *Account) Update(confirmedHeight uint64) error {
 			} else {
 				return errors.New("locked out amount not enough when update account journal")
 			}
-			a.OutJournal.Remove(out.Height)
+			a.OutJournal.Remove(out.Height, out.Nonce, out.Contract)
 
 		} else {
 			return errors.New("locked out amount not enough when update account journal")
@@ -99,6 +104,7 @@ func (a *Account) Update(confirmedHeight uint64) error {
 			return errors.New("locked in amount not enough when update account Journal")
 		}
 	}
+	a.releaseLocks(confirmedHeight)
 	a.ConfirmedHeight = confirmedHeight
 	a.ConfirmedNonce = confirmedNonce
 	a.ConfirmedTime = confirmedTime
@@ -116,6 +122,9 @@ func (a *Account) IsExist() bool {
 // Determine whether the account needs to be updated. If both
 // the transfer-out and transfer-in are 0, no update is required.
 func (a *Account) IsNeedUpdate() bool {
+	if len(a.Locks) != 0 {
+		return true
+	}
 	for _, coinContract := range *a.Coins {
 		if coinContract.LockOut != 0 || coinContract.LockIn != 0 {
 			return true
@@ -340,6 +349,138 @@ func (a *Account) TransferV2ChangeTo(re *Receiver, contract hasharry.Address, bl
 	return nil
 }
 
+// Add the coins of a locked transfer to the locks, a lock with the
+// same schedule from the same block is merged
+func (a *Account) LockedTransferChangeTo(re *Receiver, lock *Lock) error {
+	if !a.IsExist() {
+		a.Address = re.Address
+	}
+	for _, l := range a.Locks {
+		if !l.IsBond() && l.Contract == lock.Contract && l.Start == lock.Start && l.End == lock.End && l.Height == lock.Height {
+			l.Amount += lock.Amount
+			l.Total += lock.Total
+			return nil
+		}
+	}
+	a.Locks = append(a.Locks, lock)
+	return nil
+}
+
+// Pay the fees of a candidate transaction. A login locks the bond from
+// the balance and a logout schedules the release of the bonds.
+func (a *Account) CandidateChangeFrom(tx ITransaction, blockHeight uint64) error {
+	if err := a.ContractChangeFrom(tx, blockHeight); err != nil {
+		return err
+	}
+	switch tx.GetTxType() {
+	case LoginCandidate_:
+		if param.CandidateBond == 0 {
+			return nil
+		}
+		due := a.bondDue()
+		uwd, ok := a.Coins.Get(param.Token.String())
+		if !ok || uwd.Balance < due {
+			return ErrNotEnoughBalance
+		}
+		uwd.Balance -= due
+		a.Coins.Set(uwd)
+		// A candidate logging in again keeps one bond, the bond that is
+		// unbonding or was slashed is bonded again and topped up
+		if bond := a.bondLock(); bond != nil {
+			bond.Amount += due
+			bond.Total = bond.Amount
+			bond.Start = bondedHeight
+			bond.End = bondedHeight
+			bond.Height = blockHeight
+			return nil
+		}
+		a.Locks = append(a.Locks, &Lock{
+			Contract: param.Token.String(),
+			Amount:   param.CandidateBond,
+			Total:    param.CandidateBond,
+			Start:    bondedHeight,
+			End:      bondedHeight,
+			Height:   blockHeight,
+			Kind:     []LockKind{Lock_Bond},
+		})
+	case LogoutCandidate_:
+		release := blockHeight + param.UnbondingPeriod
+		for _, lock := range a.Locks {
+			if lock.IsBonded() {
+				lock.Start = release
+				lock.End = release
+			}
+		}
+	}
+	return nil
+}
+
+// The bond lock of the account, bonded or unbonding
+func (a *Account) bondLock() *Lock {
+	for _, lock := range a.Locks {
+		if lock.IsBond() {
+			return lock
+		}
+	}
+	return nil
+}
+
+// Coins a login locks from the balance, the bond lock of an earlier
+// login is only topped up to the bond
+func (a *Account) bondDue() uint64 {
+	bond := a.bondLock()
+	if bond == nil {
+		return param.CandidateBond
+	}
+	if bond.Amount >= param.CandidateBond {
+		return 0
+	}
+	return param.CandidateBond - bond.Amount
+}
+
+// Burn the percentage of the bonds, the bonds that are still in the
+// unbonding period are slashed too
+func (a *Account) SlashBond(percent uint64) {
+	for _, lock := range a.Locks {
+		if lock.IsBond() {
+			slash := lock.Amount * percent / 100
+			lock.Amount -= slash
+			lock.Total -= slash
+		}
+	}
+}
+
+// Move the released coins of the locks to the balance, the locks of
+// the unconfirmed blocks are not released
+func (a *Account) releaseLocks(confirmedHeight uint64) {
+	locks := make([]*Lock, 0, len(a.Locks))
+	for _, lock := range a.Locks {
+		if lock.Height <= confirmedHeight {
+			if released := lock.Amount - lock.Locked(confirmedHeight); released != 0 {
+				coinAccount, ok := a.Coins.Get(lock.Contract)
+				if !ok {
+					coinAccount = &CoinAccount{
+						Contract: lock.Contract,
+						Balance:  0,
+						LockOut:  0,
+						LockIn:   0,
+					}
+				}
+				coinAccount.Balance += released
+				a.Coins.Set(coinAccount)
+				lock.Amount -= released
+			}
+		}
+		if lock.Amount != 0 {
+			locks = append(locks, lock)
+		}
+	}
+	if len(locks) == 0 {
+		locks = nil
+	}
+	a.Locks = locks
+}
+
 func (a *Account) TransferOut(token hasharry.Address, amount, height uint64) error {
 	tokenInfo, ok := a.Coins.Get(token.String())
 	if !ok {
@@ -536,7 +677,7 @@ func (a *Account) VerifyTxState(tx ITransaction) error {
 		} else {
 			return a.verifyCoinTxBalance(tx)
 		}
-	case TransferV2_:
+	case TransferV2_, LockedTransfer_:
 		if tx.GetTxBody().GetContract() == param.Token {
 			return a.verifyTransferV2TokenBalance(tx)
 		} else {
@@ -544,6 +685,12 @@ func (a *Account) VerifyTxState(tx ITransaction) error {
 		}
 	case Contract_:
 		return a.verifyFees(tx)
+	case LoginCandidate_:
+		tokenAccount, ok := a.Coins.Get(param.Token.String())
+		if !ok || tokenAccount.Balance < tx.GetFees()+a.bondDue() {
+			return ErrNotEnoughBalance
+		}
+		return nil
 	default:
 		if tx.GetTxBody().GetAmount() != 0 {
 			return ErrTxAmount
@@ -639,6 +786,39 @@ func (a *Account) GetLockedOut(contract string) uint64 {
 	return 0
 }
 
+// Amount of the coins of the contract that are not released
+func (a *Account) GetLocked(contract string) uint64 {
+	var locked uint64
+	for _, lock := range a.Locks {
+		if lock.Contract == contract {
+			locked += lock.Amount
+		}
+	}
+	return locked
+}
+
+// Balance, coins not confirmed yet and locked coins of the contract.
+// The confirmation and the release move coins between them, so the sum
+// does not depend on the confirmed height.
+func (a *Account) GetHolding(contract string) uint64 {
+	holding := a.GetLocked(contract)
+	if coinAccount, ok := a.Coins.Get(contract); ok {
+		holding += coinAccount.Balance + coinAccount.LockIn
+	}
+	return holding
+}
+
+// Amount of the bonds of a candidate that has not logged out
+func (a *Account) GetBond() uint64 {
+	var bond uint64
+	for _, lock := range a.Locks {
+		if lock.IsBonded() {
+			bond += lock.Amount
+		}
+	}
+	return bond
+}
+
 func (a *Account) GetNonce() uint64 {
 	return a.Nonce
 }
@@ -670,6 +850,9 @@ func (a *Account) IsEmpty() bool {
 	if !a.OutJournal.IsEmpty() {
 		return false
 	}
+	if len(a.Locks) != 0 {
+		return false
+	}
 	for _, coin := range *a.Coins {
 		if coin.Balance != 0 || coin.LockOut != 0 || coin.LockIn != 0 {
 			return false
@@ -678,6 +861,60 @@ func (a *Account) IsEmpty() bool {
 	return true
 }
 
+type LockKind uint32
+
+const (
+	Lock_Transfer LockKind = iota
+	// Bond of a candidate, it is locked until the unbonding
+	// period after the logout
+	Lock_Bond
+)
+
+// Never released, the schedule of a bond until the logout
+const bondedHeight = math.MaxUint64
+
+// Coins of a locked transfer in the account of the receiver, Amount
+// is the part of Total that is not released yet
+type Lock struct {
+	Contract string
+	Amount   uint64
+	Total    uint64
+	Start    uint64
+	End      uint64
+	// Height of the block of the transfer
+	Height uint64
+	// Kind of the lock, empty for a locked transfer so these locks
+	// are encoded as before the bonds were added
+	Kind []LockKind `rlp:"tail"`
+}
+
+func (l *Lock) IsBond() bool {
+	return len(l.Kind) != 0 && l.Kind[0] == Lock_Bond
+}
+
+// Whether the lock is a bond of a candidate that has not logged out
+func (l *Lock) IsBonded() bool {
+	return l.IsBond() && l.Start == bondedHeight
+}
+
+// Amount of the coins that are still locked at the height
+func (l *Lock) Locked(height uint64) uint64 {
+	if height < l.Start {
+		return l.Total
+	}
+	if height >= l.End {
+		return 0
+	}
+	// Total * (height - Start) / (End - Start) without overflow, the
+	// quotient is less than Total
+	hi, lo := bits.Mul64(l.Total, height-l.Start)
+	released, _ := bits.Div64(hi, lo, l.End-l.Start)
+	if l.Total-released > l.Amount {
+		return l.Amount
+	}
+	return l.Total - released
+}
+
 type CoinAccount struct {
 	Contract string
 	Balance  uint64
@@ -727,18 +964,16 @@ func (j *outJournal) Add(height uint64, contract hasharry.Address, amount, fees,
 	})
 }
 
-func (j *outJournal) Get(height uint64) *txOut {
-	out, ok := j.Outs.Get(height)
+func (j *outJournal) Get(height, nonce uint64) *txOut {
+	out, ok := j.Outs.Get(height, nonce)
 	if ok {
 		return out
 	}
 	return nil
 }
 
-func (j *outJournal) Remove(height uint64) uint64 {
-	tx, _ := j.Outs.Get(height)
-	j.Outs.Remove(height)
-	return tx.Amount
+func (j *outJournal) Remove(height, nonce uint64, contract string) uint64 {
+	return j.Outs.Remove(height, nonce, contract)
 }
 
 func (j *outJournal) IsExist(height uint64) bool {
@@ -789,12 +1024,16 @@ type txOut struct {
 	Height   uint64
 }
 
+// Transfer logs of an account. A sender can have several transactions
+// in one block, so a log is kept for each height, nonce and contract.
+// Transfers made by contracts have no nonce, they are added to the
+// first log of the same height and contract.
 type TxOutList []*txOut
 
-func (t *TxOutList) Get(height uint64) (*txOut, bool) {
-	for _, outIn := range *t {
-		if outIn.Height == height {
-			return outIn, true
+func (t *TxOutList) Get(height, nonce uint64) (*txOut, bool) {
+	for _, out := range *t {
+		if out.Height == height && out.Nonce == nonce {
+			return out, true
 		}
 	}
 	return &txOut{}, false
@@ -802,7 +1041,8 @@ func (t *TxOutList) Get(height uint64) (*txOut, bool) {
 
 func (t *TxOutList) Set(txOut *txOut) {
 	for i, out := range *t {
-		if out.Height == txOut.Height && out.Contract == txOut.Contract {
+		if out.Height == txOut.Height && out.Contract == txOut.Contract &&
+			(out.Nonce == txOut.Nonce || out.Nonce == 0 || txOut.Nonce == 0) {
 			(*t)[i].Amount += txOut.Amount
 			(*t)[i].Fees += txOut.Fees
 			return
@@ -811,13 +1051,14 @@ func (t *TxOutList) Set(txOut *txOut) {
 	*t = append(*t, txOut)
 }
 
-func (t *TxOutList) Remove(height uint64) {
+func (t *TxOutList) Remove(height, nonce uint64, contract string) uint64 {
 	for i, out := range *t {
-		if out.Height == height {
+		if out.Height == height && out.Nonce == nonce && out.Contract == contract {
 			*t = append((*t)[0:i], (*t)[i+1:]...)
-			return
+			return out.Amount
 		}
 	}
+	return 0
 }
 
 // Account transfer log
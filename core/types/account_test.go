@@ -0,0 +1,278 @@
+package types
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/param"
+)
+
+func newTestTransfer(from hasharry.Address, nonce, amount, fees uint64) *Transaction {
+	return &Transaction{
+		TxHead: &TransactionHead{
+			TxType: Transfer_,
+			From:   from,
+			Nonce:  nonce,
+			Fees:   fees,
+			Time:   nonce,
+		},
+		TxBody: &TransferBody{
+			Contract: param.Token,
+			To:       hasharry.StringToAddress("UWDReceiver"),
+			Amount:   amount,
+		},
+	}
+}
+
+func TestTransferChangeFromSequential(t *testing.T) {
+	from := hasharry.StringToAddress("UWDSender")
+	account := NewAccount(from)
+	account.Coins.Set(&CoinAccount{Contract: param.Token.String(), Balance: 100})
+
+	for nonce, amount := range []uint64{10, 20, 30} {
+		if err := account.TransferChangeFrom(newTestTransfer(from, uint64(nonce)+1, amount, 1), 5); err != nil {
+			t.Fatalf("transfer nonce %d: %v", nonce+1, err)
+		}
+	}
+	if err := account.TransferChangeFrom(newTestTransfer(from, 5, 1, 1), 5); err != ErrNonce {
+		t.Fatalf("expected nonce error, got %v", err)
+	}
+	if len(*account.OutJournal.Outs) != 3 {
+		t.Fatalf("expected a journal for each transaction, got %d", len(*account.OutJournal.Outs))
+	}
+	if account.GetBalance(param.Token.String()) != 40 {
+		t.Fatalf("wrong balance %d", account.GetBalance(param.Token.String()))
+	}
+
+	if err := account.Update(5); err != nil {
+		t.Fatal(err)
+	}
+	coin, _ := account.Coins.Get(param.Token.String())
+	if coin.LockOut != 0 {
+		t.Fatalf("locked out %d after confirmation", coin.LockOut)
+	}
+	if !account.OutJournal.IsEmpty() {
+		t.Fatal("journal is not empty after confirmation")
+	}
+	if account.ConfirmedNonce != 3 {
+		t.Fatalf("confirmed nonce %d, expected 3", account.ConfirmedNonce)
+	}
+}
+
+func TestAccountLocks(t *testing.T) {
+	to := hasharry.StringToAddress("UWDReceiver")
+	account := NewAccount(to)
+	body := &LockedTransferBody{Contract: param.Token, To: to, Amount: 100, Start: 10, End: 20}
+	re := body.ToAddress().ReceiverList()[0]
+	account.LockedTransferChangeTo(re, body.Lock(5))
+	account.LockedTransferChangeTo(re, body.Lock(5))
+	cliff := &LockedTransferBody{Contract: param.Token, To: to, Amount: 50, Start: 12, End: 12}
+	account.LockedTransferChangeTo(re, cliff.Lock(6))
+	if len(account.Locks) != 2 || account.GetLocked(param.Token.String()) != 250 {
+		t.Fatalf("wrong locks %d locked %d", len(account.Locks), account.GetLocked(param.Token.String()))
+	}
+
+	for _, c := range []struct {
+		height, balance uint64
+	}{{4, 0}, {10, 0}, {11, 20}, {12, 90}, {15, 150}, {25, 250}} {
+		if err := account.Update(c.height); err != nil {
+			t.Fatal(err)
+		}
+		balance := account.GetBalance(param.Token.String())
+		if balance != c.balance || balance+account.GetLocked(param.Token.String()) != 250 {
+			t.Fatalf("height %d balance %d locked %d, expected balance %d", c.height, balance,
+				account.GetLocked(param.Token.String()), c.balance)
+		}
+	}
+	if account.Locks != nil || account.IsNeedUpdate() {
+		t.Fatal("released locks are kept")
+	}
+}
+
+func TestAccountLocksEncoding(t *testing.T) {
+	// Account as it was encoded before the locks were added
+	type oldAccount struct {
+		Address         hasharry.Address
+		Nonce           uint64
+		Time            uint64
+		ConfirmedHeight uint64
+		ConfirmedNonce  uint64
+		ConfirmedTime   uint64
+		Coins           *Coins
+		OutJournal      *outJournal
+		InJournal       *inJournal
+	}
+	account := NewAccount(hasharry.StringToAddress("UWDReceiver"))
+	account.Coins.Set(&CoinAccount{Contract: param.Token.String(), Balance: 100})
+	old := &oldAccount{account.Address, 0, 0, 0, 0, 0, account.Coins, account.OutJournal, account.InJournal}
+	accountBytes, _ := rlp.EncodeToBytes(account)
+	oldBytes, _ := rlp.EncodeToBytes(old)
+	if !bytes.Equal(accountBytes, oldBytes) {
+		t.Fatal("an account without locks is encoded differently")
+	}
+
+	account.Locks = []*Lock{{Contract: param.Token.String(), Amount: 5, Total: 10, Start: 1, End: 3, Height: 1}}
+	accountBytes, _ = rlp.EncodeToBytes(account)
+	var decoded *Account
+	if err := rlp.DecodeBytes(accountBytes, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Locks) != 1 || len(decoded.Locks[0].Kind) != 0 {
+		t.Fatalf("wrong decoded locks %v", decoded.Locks)
+	}
+	// The empty tail is decoded as an empty slice
+	lock := *decoded.Locks[0]
+	lock.Kind = nil
+	if !reflect.DeepEqual(&lock, account.Locks[0]) {
+		t.Fatalf("wrong decoded lock %v", lock)
+	}
+
+	account.Locks = append(account.Locks, &Lock{Contract: param.Token.String(), Amount: 7, Total: 7,
+		Start: bondedHeight, End: bondedHeight, Height: 2, Kind: []LockKind{Lock_Bond}})
+	accountBytes, _ = rlp.EncodeToBytes(account)
+	decoded = nil
+	if err := rlp.DecodeBytes(accountBytes, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Locks) != 2 || decoded.Locks[0].IsBond() || !decoded.Locks[1].IsBonded() || decoded.GetBond() != 7 {
+		t.Fatalf("wrong decoded bond %v", decoded.Locks)
+	}
+}
+
+func newTestCandidateTx(from hasharry.Address, txType TransactionType, nonce uint64) *Transaction {
+	return &Transaction{
+		TxHead: &TransactionHead{
+			TxType: txType,
+			From:   from,
+			Nonce:  nonce,
+			Fees:   1,
+			Time:   nonce,
+		},
+		TxBody: &LogoutTransactionBody{},
+	}
+}
+
+func TestCandidateBond(t *testing.T) {
+	oldBond, oldPeriod := param.CandidateBond, param.UnbondingPeriod
+	defer func() { param.CandidateBond, param.UnbondingPeriod = oldBond, oldPeriod }()
+	param.CandidateBond, param.UnbondingPeriod = 50, 10
+
+	from := hasharry.StringToAddress("UWDCandidate")
+	account := NewAccount(from)
+	account.Coins.Set(&CoinAccount{Contract: param.Token.String(), Balance: 50})
+	if err := account.VerifyTxState(newTestCandidateTx(from, LoginCandidate_, 1)); err != ErrNotEnoughBalance {
+		t.Fatalf("the bond and the fees are not verified, %v", err)
+	}
+	account.Coins.Set(&CoinAccount{Contract: param.Token.String(), Balance: 100})
+	if err := account.CandidateChangeFrom(newTestCandidateTx(from, LoginCandidate_, 1), 5); err != nil {
+		t.Fatal(err)
+	}
+	if account.GetBalance(param.Token.String()) != 49 || account.GetBond() != 50 || account.GetLocked(param.Token.String()) != 50 {
+		t.Fatalf("balance %d bond %d after login", account.GetBalance(param.Token.String()), account.GetBond())
+	}
+	if err := account.Update(1000); err != nil {
+		t.Fatal(err)
+	}
+	if account.GetBond() != 50 {
+		t.Fatal("the bond is released before the logout")
+	}
+
+	if err := account.CandidateChangeFrom(newTestCandidateTx(from, LogoutCandidate_, 2), 1001); err != nil {
+		t.Fatal(err)
+	}
+	if account.GetBond() != 0 || account.GetLocked(param.Token.String()) != 50 {
+		t.Fatal("the bond is not unbonding after the logout")
+	}
+	for _, c := range []struct {
+		height, balance uint64
+	}{{1010, 48}, {1011, 98}} {
+		if err := account.Update(c.height); err != nil {
+			t.Fatal(err)
+		}
+		if balance := account.GetBalance(param.Token.String()); balance != c.balance {
+			t.Fatalf("height %d balance %d, expected %d", c.height, balance, c.balance)
+		}
+	}
+	if account.Locks != nil {
+		t.Fatal("the released bond is kept")
+	}
+}
+
+func TestCandidateLoginAgain(t *testing.T) {
+	oldBond, oldPeriod := param.CandidateBond, param.UnbondingPeriod
+	defer func() { param.CandidateBond, param.UnbondingPeriod = oldBond, oldPeriod }()
+	param.CandidateBond, param.UnbondingPeriod = 50, 10
+
+	from := hasharry.StringToAddress("UWDCandidate")
+	account := NewAccount(from)
+	account.Coins.Set(&CoinAccount{Contract: param.Token.String(), Balance: 100})
+	for nonce, txType := range []TransactionType{LoginCandidate_, LogoutCandidate_, LoginCandidate_} {
+		if err := account.CandidateChangeFrom(newTestCandidateTx(from, txType, uint64(nonce+1)), 5); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(account.Locks) != 1 || account.GetBond() != 50 || account.GetBalance(param.Token.String()) != 47 {
+		t.Fatalf("locks %d bond %d balance %d after logging in again", len(account.Locks),
+			account.GetBond(), account.GetBalance(param.Token.String()))
+	}
+
+	// A slashed bond is topped up
+	account.SlashBond(10)
+	if err := account.VerifyTxState(newTestCandidateTx(from, LoginCandidate_, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if err := account.CandidateChangeFrom(newTestCandidateTx(from, LoginCandidate_, 4), 6); err != nil {
+		t.Fatal(err)
+	}
+	if len(account.Locks) != 1 || account.GetBond() != 50 || account.GetBalance(param.Token.String()) != 41 {
+		t.Fatalf("locks %d bond %d balance %d after the top up", len(account.Locks),
+			account.GetBond(), account.GetBalance(param.Token.String()))
+	}
+}
+
+func TestSlashBond(t *testing.T) {
+	oldBond, oldPeriod := param.CandidateBond, param.UnbondingPeriod
+	defer func() { param.CandidateBond, param.UnbondingPeriod = oldBond, oldPeriod }()
+	param.CandidateBond, param.UnbondingPeriod = 50, 10
+
+	from := hasharry.StringToAddress("UWDCandidate")
+	account := NewAccount(from)
+	account.Coins.Set(&CoinAccount{Contract: param.Token.String(), Balance: 100})
+	account.Locks = []*Lock{{Contract: param.Token.String(), Amount: 20, Total: 20, Start: 100, End: 200}}
+	if err := account.CandidateChangeFrom(newTestCandidateTx(from, LoginCandidate_, 1), 5); err != nil {
+		t.Fatal(err)
+	}
+	account.SlashBond(10)
+	if account.GetBond() != 45 || account.GetLocked(param.Token.String()) != 65 {
+		t.Fatalf("bond %d locked %d after the slash", account.GetBond(), account.GetLocked(param.Token.String()))
+	}
+	// The unbonding bond is slashed too
+	if err := account.CandidateChangeFrom(newTestCandidateTx(from, LogoutCandidate_, 2), 6); err != nil {
+		t.Fatal(err)
+	}
+	account.SlashBond(10)
+	if account.GetLocked(param.Token.String()) != 61 || account.GetBalance(param.Token.String()) != 48 {
+		t.Fatalf("locked %d balance %d after the slash", account.GetLocked(param.Token.String()), account.GetBalance(param.Token.String()))
+	}
+}
+
+func TestAccountHolding(t *testing.T) {
+	account := NewAccount(hasharry.StringToAddress("UWDVoter"))
+	account.Coins.Set(&CoinAccount{Contract: param.Token.String(), Balance: 100})
+	account.ContractChangeTo(&Receiver{Address: account.Address, Amount: 30}, param.Token, 5)
+	account.Locks = []*Lock{{Contract: param.Token.String(), Amount: 20, Total: 20, Start: 8, End: 8, Height: 5}}
+	for _, height := range []uint64{4, 5, 8} {
+		if err := account.Update(height); err != nil {
+			t.Fatal(err)
+		}
+		if holding := account.GetHolding(param.Token.String()); holding != 150 {
+			t.Fatalf("holding %d at the confirmed height %d", holding, height)
+		}
+	}
+	if account.GetBalance(param.Token.String()) != 150 {
+		t.Fatal("the coins are not confirmed and released")
+	}
+}
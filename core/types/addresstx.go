@@ -0,0 +1,12 @@
+package types
+
+import "github.com/uworldao/UWORLD/common/hasharry"
+
+// Entry of the address transaction history index, a
+// transaction that sent, received or moved tokens of the address
+type AddressTx struct {
+	Address hasharry.Address
+	TxHash  hasharry.Hash
+	Height  uint64
+	TxIndex uint32
+}
@@ -14,6 +14,11 @@ type Candidate struct {
 	Signer hasharry.Address
 	PeerId string
 	Weight uint64
+	// Bond of the candidate, it is read from the account and not stored
+	Stake uint64 `rlp:"-"`
+	// Commission rate of the candidate, it is read from the consensus
+	// and not stored
+	Commission uint64 `rlp:"-"`
 }
 
 type Candidates struct {
@@ -6,6 +6,9 @@ import (
 	"github.com/uworldao/UWORLD/common/encode/rlp"
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types/contractv2/exchange"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
+	"github.com/uworldao/UWORLD/core/types/contractv2/token"
 )
 
 type ContractType uint
@@ -14,6 +17,9 @@ type FunctionType uint
 const (
 	Exchange_ ContractType = 0
 	Pair_                  = 1
+	Token_                 = 2
+	NFT_                   = 3
+	HTLC_                  = 4
 )
 
 const (
@@ -25,6 +31,22 @@ const (
 
 	Pair_AddLiquidity    = 100000
 	Pair_RemoveLiquidity = 100001
+
+	Token_Init         = 200000
+	Token_Approve      = 200001
+	Token_TransferFrom = 200002
+	Token_Burn         = 200003
+	Token_Mint         = 200004
+
+	NFT_Create   = 300000
+	NFT_Mint     = 300001
+	NFT_Transfer = 300002
+	NFT_Approve  = 300003
+	NFT_Burn     = 300004
+
+	HTLC_Create = 400000
+	HTLC_Claim  = 400001
+	HTLC_Refund = 400002
 )
 
 type ContractV2 struct {
@@ -96,6 +118,27 @@ func DecodeContractV2(bytes []byte) (*ContractV2, error) {
 		}
 		contract.Body = pair
 		return contract, err
+	case Token_:
+		tk, err := token.DecodeToToken(rlpContract.Body)
+		if err != nil {
+			return nil, err
+		}
+		contract.Body = tk
+		return contract, err
+	case NFT_:
+		collection, err := nft.DecodeToCollection(rlpContract.Body)
+		if err != nil {
+			return nil, err
+		}
+		contract.Body = collection
+		return contract, err
+	case HTLC_:
+		swap, err := htlc.DecodeToSwap(rlpContract.Body)
+		if err != nil {
+			return nil, err
+		}
+		contract.Body = swap
+		return contract, err
 	}
 	return nil, errors.New("decoding failure")
 }
@@ -0,0 +1,97 @@
+package htlc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+)
+
+// Length of a preimage. The swaps of other chains take 32 bytes, a
+// longer preimage of the same hash lock could be claimed here but not
+// on the other chain.
+const PreimageSize = 32
+
+type SwapState uint8
+
+const (
+	Swap_Open     SwapState = 0
+	Swap_Claimed  SwapState = 1
+	Swap_Refunded SwapState = 2
+)
+
+func (s SwapState) String() string {
+	switch s {
+	case Swap_Open:
+		return "open"
+	case Swap_Claimed:
+		return "claimed"
+	case Swap_Refunded:
+		return "refunded"
+	}
+	return "unknown"
+}
+
+// Swap is a hashed time locked transfer. The coins are held by the swap
+// address, the recipient can claim them with the preimage of the hash
+// lock before the time lock height, after it the sender can refund them.
+type Swap struct {
+	Sender    hasharry.Address
+	Recipient hasharry.Address
+	Token     hasharry.Address
+	Amount    uint64
+	HashLock  hasharry.Hash
+	TimeLock  uint64
+	State     SwapState
+	Preimage  []byte
+}
+
+// Whether the preimage has 32 bytes and its sha256 hash is the hash lock
+func (s *Swap) VerifyPreimage(preimage []byte) bool {
+	if len(preimage) != PreimageSize {
+		return false
+	}
+	hash := sha256.Sum256(preimage)
+	return bytes.Equal(hash[:], s.HashLock.Bytes())
+}
+
+// A swap can be claimed before the time lock height
+func (s *Swap) Expired(height uint64) bool {
+	return height >= s.TimeLock
+}
+
+func (s *Swap) Bytes() []byte {
+	bytes, _ := rlp.EncodeToBytes(s)
+	return bytes
+}
+
+func DecodeToSwap(bytes []byte) (*Swap, error) {
+	var s *Swap
+	if err := rlp.DecodeBytes(bytes, &s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Addresses of the swaps with the same hash lock, in the order of
+// creation. Anyone can create a swap with a known hash lock, so the
+// index keeps all of them instead of the first one.
+type HashLockSwaps []hasharry.Address
+
+func (h HashLockSwaps) Bytes() []byte {
+	bytes, _ := rlp.EncodeToBytes([]hasharry.Address(h))
+	return bytes
+}
+
+func DecodeToHashLockSwaps(bytes []byte) (HashLockSwaps, error) {
+	var swaps []hasharry.Address
+	if err := rlp.DecodeBytes(bytes, &swaps); err != nil {
+		return nil, err
+	}
+	return swaps, nil
+}
+
+// Key of the hash lock index in the contract trie
+func HashLockTrieKey(hashLock hasharry.Hash) []byte {
+	return []byte("htlc-" + hashLock.String())
+}
@@ -0,0 +1,43 @@
+package htlc
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+)
+
+func TestSwapVerifyPreimage(t *testing.T) {
+	preimage := []byte("a secret preimage of 32 bytes...")
+	hash := sha256.Sum256(preimage)
+	swap := &Swap{
+		Sender:    hasharry.StringToAddress("UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw"),
+		Recipient: hasharry.StringToAddress("UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh"),
+		Amount:    10,
+		HashLock:  hasharry.BytesToHash(hash[:]),
+		TimeLock:  100,
+	}
+	if !swap.VerifyPreimage(preimage) || swap.VerifyPreimage([]byte("a secret preimage of 32 bytes..,")) || swap.VerifyPreimage(nil) {
+		t.Fatal("wrong preimage verification")
+	}
+	// The hash of a short preimage is the hash lock, but other chains
+	// would not take it
+	short := []byte("secret")
+	shortHash := sha256.Sum256(short)
+	if (&Swap{HashLock: hasharry.BytesToHash(shortHash[:])}).VerifyPreimage(short) {
+		t.Fatal("a preimage that is not 32 bytes is accepted")
+	}
+	if swap.Expired(99) || !swap.Expired(100) {
+		t.Fatal("wrong expiry")
+	}
+
+	swap.State = Swap_Claimed
+	swap.Preimage = preimage
+	decoded, err := DecodeToSwap(swap.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.State != Swap_Claimed || string(decoded.Preimage) != string(preimage) || !decoded.HashLock.IsEqual(swap.HashLock) {
+		t.Fatalf("wrong decoded swap %+v", decoded)
+	}
+}
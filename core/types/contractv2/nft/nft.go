@@ -0,0 +1,119 @@
+package nft
+
+import (
+	"github.com/uworldao/UWORLD/common/codec"
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+)
+
+// Collection of non-fungible tokens, only the creator can mint. The
+// tokens are stored in the contract trie apart from the collection,
+// the ids start from 1.
+type Collection struct {
+	Name    string
+	Symbol  string
+	Creator hasharry.Address
+	NextId  uint64
+	Supply  uint64
+}
+
+func NewCollection(name, symbol string, creator hasharry.Address) *Collection {
+	return &Collection{
+		Name:    name,
+		Symbol:  symbol,
+		Creator: creator,
+		NextId:  1,
+		Supply:  0,
+	}
+}
+
+func (c *Collection) Bytes() []byte {
+	bytes, _ := rlp.EncodeToBytes(c)
+	return bytes
+}
+
+func DecodeToCollection(bytes []byte) (*Collection, error) {
+	var c *Collection
+	if err := rlp.DecodeBytes(bytes, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Token is a non-fungible token of a collection. Approved may transfer
+// or burn the token once, it is cleared by a transfer.
+type Token struct {
+	Collection   hasharry.Address
+	Id           uint64
+	Owner        hasharry.Address
+	Approved     hasharry.Address
+	URI          string
+	MetadataHash hasharry.Hash
+}
+
+func (t *Token) Key() TokenKey {
+	return TokenKey{Collection: t.Collection, Id: t.Id}
+}
+
+// Whether the sender is the owner or the approved address of the token
+func (t *Token) CanSpend(sender hasharry.Address) bool {
+	if t.Owner.IsEqual(sender) {
+		return true
+	}
+	return !t.Approved.IsEqual(hasharry.Address{}) && t.Approved.IsEqual(sender)
+}
+
+func (t *Token) Bytes() []byte {
+	bytes, _ := rlp.EncodeToBytes(t)
+	return bytes
+}
+
+func DecodeToToken(bytes []byte) (*Token, error) {
+	var t *Token
+	if err := rlp.DecodeBytes(bytes, &t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+type TokenKey struct {
+	Collection hasharry.Address
+	Id         uint64
+}
+
+func (k TokenKey) Bytes() []byte {
+	bytes, _ := rlp.EncodeToBytes(k)
+	return bytes
+}
+
+func DecodeToTokenKey(bytes []byte) (TokenKey, error) {
+	var k TokenKey
+	err := rlp.DecodeBytes(bytes, &k)
+	return k, err
+}
+
+// Keys of the tokens and of the owner entries in the contract trie,
+// they can not be the bytes of an address or a transaction hash. The
+// ids are big endian, so iterating a prefix returns the tokens of a
+// collection, or the keys of an owner, ordered by collection and id.
+func TokenTrieKey(collection hasharry.Address, id uint64) []byte {
+	return append(TokenTriePrefix(collection), codec.Uint64toBytes(id)...)
+}
+
+func TokenTriePrefix(collection hasharry.Address) []byte {
+	return []byte("nft-" + collection.String() + "-")
+}
+
+func OwnerTokenTrieKey(owner hasharry.Address, key TokenKey) []byte {
+	prefix := append(OwnerTriePrefix(owner), key.Collection.Bytes()...)
+	return append(prefix, codec.Uint64toBytes(key.Id)...)
+}
+
+func OwnerTriePrefix(owner hasharry.Address) []byte {
+	return []byte("nftowner-" + owner.String() + "-")
+}
+
+// Key of the number of tokens of the owner
+func OwnerCountTrieKey(owner hasharry.Address) []byte {
+	return []byte("nftcount-" + owner.String())
+}
@@ -0,0 +1,50 @@
+package nft
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+)
+
+func TestTrieKeys(t *testing.T) {
+	c0 := hasharry.StringToAddress("UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL")
+	c1 := hasharry.StringToAddress("UWTVVy54xoUmAKFQvJRqpPwVuyjag4RZwg6W")
+	owner := hasharry.StringToAddress("UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw")
+
+	if bytes.Compare(TokenTrieKey(c0, 2), TokenTrieKey(c0, 10)) >= 0 {
+		t.Fatal("token keys are not ordered by id")
+	}
+	if !bytes.HasPrefix(TokenTrieKey(c0, 1), TokenTriePrefix(c0)) || bytes.HasPrefix(TokenTrieKey(c1, 1), TokenTriePrefix(c0)) {
+		t.Fatal("wrong prefix of the tokens of a collection")
+	}
+	k0, k1 := TokenKey{c0, 10}, TokenKey{c0, 2}
+	if bytes.Compare(OwnerTokenTrieKey(owner, k1), OwnerTokenTrieKey(owner, k0)) >= 0 {
+		t.Fatal("owner keys are not ordered by id")
+	}
+	if !bytes.HasPrefix(OwnerTokenTrieKey(owner, k0), OwnerTriePrefix(owner)) {
+		t.Fatal("wrong prefix of the tokens of an owner")
+	}
+	decoded, err := DecodeToTokenKey(k0.Bytes())
+	if err != nil || decoded != k0 {
+		t.Fatalf("wrong decoded key %v, %v", decoded, err)
+	}
+}
+
+func TestTokenCanSpend(t *testing.T) {
+	owner := hasharry.StringToAddress("UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw")
+	spender := hasharry.StringToAddress("UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh")
+
+	token := &Token{Id: 1, Owner: owner, URI: "ipfs://test"}
+	if !token.CanSpend(owner) || token.CanSpend(spender) || token.CanSpend(hasharry.Address{}) {
+		t.Fatal("wrong spender without approval")
+	}
+	token.Approved = spender
+	decoded, err := DecodeToToken(token.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.CanSpend(spender) || decoded.URI != token.URI {
+		t.Fatalf("wrong decoded token %+v", decoded)
+	}
+}
@@ -0,0 +1,78 @@
+package token
+
+import (
+	"errors"
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+)
+
+type RlpToken struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	TotalSupply uint64
+	Owner       hasharry.Address
+}
+
+// Token is a fungible token, the balances are coins of the token
+// address in the accounts of the holders. Owner is empty if no more
+// tokens can be minted. The allowances are kept in the contract trie
+// under AllowanceTrieKey.
+type Token struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	TotalSupply uint64
+	Owner       hasharry.Address
+}
+
+func NewToken(name, symbol string, decimals uint8, owner hasharry.Address) *Token {
+	return &Token{
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: decimals,
+		Owner:    owner,
+	}
+}
+
+func (t *Token) Mintable() bool {
+	return !t.Owner.IsEqual(hasharry.Address{})
+}
+
+func (t *Token) VerifyOwner(sender hasharry.Address) error {
+	if !t.Mintable() {
+		return errors.New("the token can not be minted")
+	}
+	if !t.Owner.IsEqual(sender) {
+		return errors.New("forbidden")
+	}
+	return nil
+}
+
+func (t *Token) Bytes() []byte {
+	rlpToken := &RlpToken{
+		Name:        t.Name,
+		Symbol:      t.Symbol,
+		Decimals:    t.Decimals,
+		TotalSupply: t.TotalSupply,
+		Owner:       t.Owner,
+	}
+	bytes, _ := rlp.EncodeToBytes(rlpToken)
+	return bytes
+}
+
+func DecodeToToken(bytes []byte) (*Token, error) {
+	var rlpToken *RlpToken
+	if err := rlp.DecodeBytes(bytes, &rlpToken); err != nil {
+		return nil, err
+	}
+	token := NewToken(rlpToken.Name, rlpToken.Symbol, rlpToken.Decimals, rlpToken.Owner)
+	token.TotalSupply = rlpToken.TotalSupply
+	return token, nil
+}
+
+// Key of the amount the spender may still transfer from the owner in
+// the contract trie, an approval rewrites only its own key
+func AllowanceTrieKey(token, owner, spender hasharry.Address) []byte {
+	return []byte("allowance-" + token.String() + "-" + owner.String() + "-" + spender.String())
+}
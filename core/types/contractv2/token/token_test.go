@@ -0,0 +1,32 @@
+package token
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+)
+
+func TestTokenEncode(t *testing.T) {
+	owner := hasharry.StringToAddress("UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw")
+	spender0 := hasharry.StringToAddress("UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh")
+	spender1 := hasharry.StringToAddress("UWDXn3sEJXvKuhxcDbgFYA2STW4j1Sw6cuvq")
+	address := hasharry.StringToAddress("UWTXEqvUWik48uAHcJXZiyyWMy4GLtpGuttL")
+
+	tk := NewToken("Test token", "TT", 8, owner)
+	tk.TotalSupply = 1000
+	decoded, err := DecodeToToken(tk.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.TotalSupply != 1000 || !decoded.Mintable() || decoded.Symbol != "TT" {
+		t.Fatalf("wrong decoded token %+v", decoded)
+	}
+	if bytes.Equal(AllowanceTrieKey(address, owner, spender0), AllowanceTrieKey(address, owner, spender1)) ||
+		bytes.Equal(AllowanceTrieKey(address, owner, spender0), AllowanceTrieKey(address, spender0, owner)) {
+		t.Fatal("allowances share a key")
+	}
+	if NewToken("Fixed", "FX", 0, hasharry.Address{}).VerifyOwner(owner) == nil {
+		t.Fatal("a token without owner can be minted")
+	}
+}
@@ -5,8 +5,17 @@ import (
 	"github.com/uworldao/UWORLD/common/encode/rlp"
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/param"
 )
 
+// Contract types added by a fork, they are unknown before the
+// activation height of the fork
+var contractV2Forks = map[contractv2.ContractType]param.Fork{
+	contractv2.Token_: param.FungibleToken,
+	contractv2.NFT_:   param.NonFungibleToken,
+	contractv2.HTLC_:  param.HTLC,
+}
+
 type IFunction interface {
 	Verify() error
 }
@@ -85,6 +94,44 @@ func (c *TxContractV2Body) checkType() error {
 			return nil
 		}
 		return errors.New("invalid contract function type")
+	case contractv2.Token_:
+		switch c.FunctionType {
+		case contractv2.Token_Init:
+			return nil
+		case contractv2.Token_Approve:
+			return nil
+		case contractv2.Token_TransferFrom:
+			return nil
+		case contractv2.Token_Burn:
+			return nil
+		case contractv2.Token_Mint:
+			return nil
+		}
+		return errors.New("invalid contract function type")
+	case contractv2.NFT_:
+		switch c.FunctionType {
+		case contractv2.NFT_Create:
+			return nil
+		case contractv2.NFT_Mint:
+			return nil
+		case contractv2.NFT_Transfer:
+			return nil
+		case contractv2.NFT_Approve:
+			return nil
+		case contractv2.NFT_Burn:
+			return nil
+		}
+		return errors.New("invalid contract function type")
+	case contractv2.HTLC_:
+		switch c.FunctionType {
+		case contractv2.HTLC_Create:
+			return nil
+		case contractv2.HTLC_Claim:
+			return nil
+		case contractv2.HTLC_Refund:
+			return nil
+		}
+		return errors.New("invalid contract function type")
 	}
 	return errors.New("invalid contract type")
 }
@@ -120,6 +167,12 @@ const (
 	Event_Transfer EventType = 0
 	Event_Mint     EventType = 1
 	Event_Burn     EventType = 2
+
+	// Events of non-fungible tokens, Token is the collection and Amount
+	// is the id of the token. They do not change the balances.
+	Event_NFTMint     EventType = 3
+	Event_NFTTransfer EventType = 4
+	Event_NFTBurn     EventType = 5
 )
 
 type Event struct {
@@ -130,3 +183,7 @@ type Event struct {
 	Amount    uint64
 	Height    uint64
 }
+
+func (e *Event) IsNFT() bool {
+	return e.EventType >= Event_NFTMint && e.EventType <= Event_NFTBurn
+}
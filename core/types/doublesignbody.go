@@ -0,0 +1,86 @@
+package types
+
+import (
+	"errors"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/param"
+)
+
+// Evidence of a signer that signed two different blocks for the same
+// height and slot. The signer is jailed and part of its bond is burned.
+// One of the headers must be known to the chain, which keeps the evidence
+// on its own network.
+type DoubleSignBody struct {
+	Header1 *Header
+	Header2 *Header
+}
+
+func (ds *DoubleSignBody) ToAddress() *Receivers {
+	recis := NewReceivers()
+	if ds.Header1 != nil {
+		recis.Add(ds.Header1.Signer, 0)
+	}
+	return recis
+}
+
+func (ds *DoubleSignBody) GetAmount() uint64 {
+	return 0
+}
+
+func (ds *DoubleSignBody) GetContract() hasharry.Address {
+	return param.Token
+}
+
+func (ds *DoubleSignBody) GetName() string {
+	return ""
+}
+
+func (ds *DoubleSignBody) GetAbbr() string {
+	return ""
+}
+
+func (ds *DoubleSignBody) GetIncreaseSwitch() bool {
+	return false
+}
+
+func (ds *DoubleSignBody) GetDescription() string {
+	return ""
+}
+
+func (ds *DoubleSignBody) GetPeerId() []byte {
+	return nil
+}
+
+// The signer of the evidence
+func (ds *DoubleSignBody) Signer() hasharry.Address {
+	return ds.Header1.Signer
+}
+
+// The height at which the signer signed twice
+func (ds *DoubleSignBody) Height() uint64 {
+	return ds.Header1.Height
+}
+
+func (ds *DoubleSignBody) VerifyBody(from hasharry.Address) error {
+	if ds.Header1 == nil || ds.Header2 == nil {
+		return errors.New("missing block header")
+	}
+	if ds.Header1.Height != ds.Header2.Height {
+		return errors.New("the headers are not at the same height")
+	}
+	if ds.Header1.Term != ds.Header2.Term || ds.Header1.Time/param.BlockInterval != ds.Header2.Time/param.BlockInterval {
+		return errors.New("the headers are not in the same slot")
+	}
+	if !ds.Header1.Signer.IsEqual(ds.Header2.Signer) {
+		return errors.New("the headers have different signers")
+	}
+	if ds.Header1.Hash.IsEqual(ds.Header2.Hash) {
+		return errors.New("the headers are the same")
+	}
+	for _, header := range []*Header{ds.Header1, ds.Header2} {
+		if err := header.VerifySignature(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
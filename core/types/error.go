@@ -11,6 +11,7 @@ var (
 	ErrSigner           = errors.New("inconsistent signer")
 	ErrNoSignature      = errors.New("no signature")
 	ErrWrongSignature   = errors.New("wrong signature")
+	ErrMultiSigInactive = errors.New("multi-signature is not active")
 	ErrTxNonceRepeat    = errors.New("the nonce value is repeated, increase the nonce value")
 	ErrCoinBase         = errors.New("wrong coin base reward")
 	ErrAddress          = errors.New("wrong address")
@@ -25,4 +26,6 @@ var (
 	ErrContractAddr     = errors.New("wrong contract address")
 	ErrTxHead           = errors.New("transaction head cant be nil")
 	ErrTxBody           = errors.New("transaction body cant be nil")
+	ErrTxExpired        = errors.New("the transaction has expired")
+	ErrTxExpiryInactive = errors.New("transaction expiry is not active")
 )
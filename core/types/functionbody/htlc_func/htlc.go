@@ -0,0 +1,57 @@
+package htlc_func
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+type HTLCCreateBody struct {
+	Recipient hasharry.Address
+	Token     hasharry.Address
+	Amount    uint64
+	HashLock  hasharry.Hash
+	TimeLock  uint64
+}
+
+func (h *HTLCCreateBody) Verify() error {
+	if ok := ut.CheckUWDAddress(param.Net, h.Recipient.String()); !ok {
+		return errors.New("wrong recipient address")
+	}
+	if !h.Token.IsEqual(param.Token) {
+		if ok := ut.IsValidContractAddress(param.Net, h.Token.String()); !ok {
+			return errors.New("wrong token address")
+		}
+	}
+	if h.Amount == 0 {
+		return errors.New("wrong amount")
+	}
+	if h.HashLock.IsEqual(hasharry.Hash{}) {
+		return errors.New("hash lock is required")
+	}
+	return nil
+}
+
+type HTLCClaim struct {
+	Preimage []byte
+}
+
+func (h *HTLCClaim) Verify() error {
+	if len(h.Preimage) == 0 {
+		return errors.New("preimage is required")
+	}
+	if len(h.Preimage) != htlc.PreimageSize {
+		return fmt.Errorf("the preimage must be %d bytes", htlc.PreimageSize)
+	}
+	return nil
+}
+
+type HTLCRefund struct {
+}
+
+func (h *HTLCRefund) Verify() error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package nft_func
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+const (
+	MaxCollectionName = 32
+	MaxURI            = 256
+)
+
+type NFTCreateBody struct {
+	Name   string
+	Symbol string
+}
+
+func (n *NFTCreateBody) Verify() error {
+	if len(n.Name) > MaxCollectionName {
+		return fmt.Errorf("the maximum length of collection name shall not exceed %d", MaxCollectionName)
+	}
+	return ut.CheckAbbr(n.Symbol)
+}
+
+type NFTMint struct {
+	To           hasharry.Address
+	URI          string
+	MetadataHash hasharry.Hash
+}
+
+func (n *NFTMint) Verify() error {
+	if ok := ut.CheckUWDAddress(param.Net, n.To.String()); !ok {
+		return errors.New("wrong to address")
+	}
+	if len(n.URI) > MaxURI {
+		return fmt.Errorf("the maximum length of uri shall not exceed %d", MaxURI)
+	}
+	if n.URI == "" && n.MetadataHash.IsEqual(hasharry.Hash{}) {
+		return errors.New("uri or metadata hash is required")
+	}
+	return nil
+}
+
+type NFTTransfer struct {
+	Id uint64
+	To hasharry.Address
+}
+
+func (n *NFTTransfer) Verify() error {
+	if ok := ut.CheckUWDAddress(param.Net, n.To.String()); !ok {
+		return errors.New("wrong to address")
+	}
+	return nil
+}
+
+// Approve the spender to transfer or burn the token, an empty spender
+// removes the approval
+type NFTApprove struct {
+	Id      uint64
+	Spender hasharry.Address
+}
+
+func (n *NFTApprove) Verify() error {
+	if n.Spender.IsEqual(hasharry.Address{}) {
+		return nil
+	}
+	if ok := ut.CheckUWDAddress(param.Net, n.Spender.String()); !ok {
+		return errors.New("wrong spender address")
+	}
+	return nil
+}
+
+type NFTBurn struct {
+	Id uint64
+}
+
+func (n *NFTBurn) Verify() error {
+	return nil
+}
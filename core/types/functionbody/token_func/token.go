@@ -0,0 +1,107 @@
+package token_func
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+// The same limits as the tokens of contract transactions
+const (
+	MaxTokenName     = 32
+	MaxTokenDecimals = 8
+)
+
+type TokenInitBody struct {
+	Name     string
+	Symbol   string
+	Decimals uint8
+	Amount   uint64
+	Receiver hasharry.Address
+	Mintable bool
+}
+
+func (t *TokenInitBody) Verify() error {
+	if len(t.Name) > MaxTokenName {
+		return fmt.Errorf("the maximum length of token name shall not exceed %d", MaxTokenName)
+	}
+	if err := ut.CheckAbbr(t.Symbol); err != nil {
+		return err
+	}
+	if t.Decimals > MaxTokenDecimals {
+		return fmt.Errorf("the maximum decimals shall not exceed %d", MaxTokenDecimals)
+	}
+	if t.Amount > param.MaxContractCoin {
+		return fmt.Errorf("the amount of tokens cannot exceed %.8f", float64(param.MaxContractCoin)/float64(param.AtomsPerCoin))
+	}
+	if t.Amount == 0 && !t.Mintable {
+		return errors.New("wrong amount")
+	}
+	if ok := ut.CheckUWDAddress(param.Net, t.Receiver.String()); !ok {
+		return errors.New("wrong receiver address")
+	}
+	return nil
+}
+
+type TokenApprove struct {
+	Spender hasharry.Address
+	Amount  uint64
+}
+
+// Contracts can not send transactions, so only an account can be a spender
+func (t *TokenApprove) Verify() error {
+	if ok := ut.CheckUWDAddress(param.Net, t.Spender.String()); !ok {
+		return errors.New("wrong spender address")
+	}
+	return nil
+}
+
+type TokenTransferFrom struct {
+	Owner  hasharry.Address
+	To     hasharry.Address
+	Amount uint64
+}
+
+func (t *TokenTransferFrom) Verify() error {
+	if ok := ut.CheckUWDAddress(param.Net, t.Owner.String()); !ok {
+		return errors.New("wrong owner address")
+	}
+	if ok := ut.CheckUWDAddress(param.Net, t.To.String()); !ok {
+		return errors.New("wrong to address")
+	}
+	if t.Amount == 0 {
+		return errors.New("wrong amount")
+	}
+	return nil
+}
+
+type TokenBurn struct {
+	Amount uint64
+}
+
+func (t *TokenBurn) Verify() error {
+	if t.Amount == 0 {
+		return errors.New("wrong amount")
+	}
+	return nil
+}
+
+type TokenMint struct {
+	To     hasharry.Address
+	Amount uint64
+}
+
+func (t *TokenMint) Verify() error {
+	if ok := ut.CheckUWDAddress(param.Net, t.To.String()); !ok {
+		return errors.New("wrong to address")
+	}
+	if t.Amount == 0 {
+		return errors.New("wrong amount")
+	}
+	if t.Amount > param.MaxContractCoin {
+		return fmt.Errorf("the amount of tokens cannot exceed %.8f", float64(param.MaxContractCoin)/float64(param.AtomsPerCoin))
+	}
+	return nil
+}
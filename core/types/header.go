@@ -3,9 +3,11 @@ package types
 import (
 	"bytes"
 	"encoding/gob"
+	"errors"
 	"github.com/uworldao/UWORLD/common/encode/rlp"
 	hash2 "github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/crypto/hash"
+	"github.com/uworldao/UWORLD/param"
 )
 
 const BlockVersion = 1
@@ -47,6 +49,28 @@ func (h *Header) SetHash() {
 	h.Hash = hash.Hash(h.ToBytes())
 }
 
+// Verify that the hash is the hash of the unsigned header
+// and that it is signed by the signer of the header
+func (h *Header) VerifySignature() error {
+	if h.SignScript == nil {
+		return ErrNoSignature
+	}
+	unsigned := *h
+	unsigned.Hash = hash2.Hash{}
+	unsigned.SignScript = nil
+	unsigned.SetHash()
+	if unsigned.Hash != h.Hash {
+		return errors.New("wrong hash")
+	}
+	if !VerifySigner(param.Net, h.Signer, h.SignScript.PubKey) {
+		return errors.New("wrong signer")
+	}
+	if !Verify(h.Hash, h.SignScript) {
+		return errors.New("wrong signature")
+	}
+	return nil
+}
+
 func (h *Header) Serialize() ([]byte, error) {
 	var buff bytes.Buffer
 	encode := gob.NewEncoder(&buff)
@@ -15,9 +15,11 @@ type IAccount interface {
 	TransferChangeFrom(ITransaction, uint64) error
 	TransferV2ChangeFrom(ITransaction, uint64) error
 	ContractChangeFrom(ITransaction, uint64) error
+	CandidateChangeFrom(ITransaction, uint64) error
 	TransferChangeTo(*Receiver, uint64, hasharry.Address, uint64) error
 	TransferV2ChangeTo(*Receiver, hasharry.Address, uint64) error
 	ContractChangeTo(*Receiver, hasharry.Address, uint64)
+	LockedTransferChangeTo(*Receiver, *Lock) error
 	FeesChange(uint64, uint64)
 	ConsumptionChange(uint64, uint64)
 	TransferOut(token hasharry.Address, amount, height uint64) error
@@ -25,6 +27,9 @@ type IAccount interface {
 	VerifyTxState(ITransaction) error
 	VerifyNonce(uint64) error
 	IsEmpty() bool
+	GetBond() uint64
+	GetHolding(string) uint64
+	SlashBond(uint64)
 }
 
 type IChainAddress interface {
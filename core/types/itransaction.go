@@ -8,8 +8,8 @@ import (
 type ITransaction interface {
 	Size() uint64
 	IsCoinBase() bool
-	VerifyTx() error
-	VerifyCoinBaseTx(height, sumFees uint64) error
+	VerifyTx(height uint64) error
+	VerifyCoinBaseTx(height, sumFees, rate uint64) error
 	EncodeToBytes() ([]byte, error)
 	SignTx(key *secp256k1.PrivateKey) error
 	SetHash() error
@@ -21,6 +21,8 @@ type ITransaction interface {
 	GetFees() uint64
 	GetNonce() uint64
 	GetTime() uint64
+	GetValidUntilHeight() uint64
+	Expired(height uint64) bool
 	GetTxType() TransactionType
 	GetSignScript() *SignScript
 	GetTxHead() *TransactionHead
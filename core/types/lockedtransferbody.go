@@ -0,0 +1,85 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+// Transfer of coins that are locked in the account of the receiver.
+// Nothing is released before the start height, then the coins are
+// released linearly until the end height. All coins are released at
+// the start height if the end height is equal to it.
+type LockedTransferBody struct {
+	Contract hasharry.Address
+	To       hasharry.Address
+	Amount   uint64
+	Start    uint64
+	End      uint64
+}
+
+func (lb *LockedTransferBody) ToAddress() *Receivers {
+	recis := NewReceivers()
+	recis.Add(lb.To, lb.Amount)
+	return recis
+}
+
+func (lb *LockedTransferBody) GetAmount() uint64 {
+	return lb.Amount
+}
+
+func (lb *LockedTransferBody) GetContract() hasharry.Address {
+	return lb.Contract
+}
+
+func (lb *LockedTransferBody) GetName() string {
+	return ""
+}
+
+func (lb *LockedTransferBody) GetAbbr() string {
+	return ""
+}
+
+func (lb *LockedTransferBody) GetIncreaseSwitch() bool {
+	return false
+}
+
+func (lb *LockedTransferBody) GetDescription() string {
+	return ""
+}
+
+func (lb *LockedTransferBody) GetPeerId() []byte {
+	return nil
+}
+
+// The lock the transfer adds to the account of the receiver
+func (lb *LockedTransferBody) Lock(height uint64) *Lock {
+	return &Lock{
+		Contract: lb.Contract.String(),
+		Amount:   lb.Amount,
+		Total:    lb.Amount,
+		Start:    lb.Start,
+		End:      lb.End,
+		Height:   height,
+	}
+}
+
+func (lb *LockedTransferBody) VerifyBody(from hasharry.Address) error {
+	if !lb.Contract.IsEqual(param.Token) {
+		if !ut.IsValidContractAddress(param.Net, lb.Contract.String()) {
+			return errors.New("token address verification failed")
+		}
+	}
+	if !ut.CheckUWDAddress(param.Net, lb.To.String()) {
+		return ErrAddress
+	}
+	if lb.Amount < param.MinAllowedAmount {
+		return fmt.Errorf("the minimum amount of the transaction must not be less than %d", param.MinAllowedAmount)
+	}
+	if lb.End < lb.Start {
+		return errors.New("the end height must not be less than the start height")
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bytes"
+	"errors"
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/param"
 )
@@ -47,6 +49,11 @@ func (lit *LoginTransactionBody) ToAddress() *Receivers {
 	return NewReceivers()
 }
 
+// The peer id is stored without its length, a shorter id would
+// be padded with zeros
 func (lit *LoginTransactionBody) VerifyBody(from hasharry.Address) error {
+	if bytes.IndexByte(lit.PeerId[:], 0) != -1 {
+		return errors.New("wrong peer id")
+	}
 	return nil
 }
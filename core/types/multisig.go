@@ -0,0 +1,170 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/crypto/ecc/schnorr"
+	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+type MultiSigType uint8
+
+const (
+	// Every signer gives an ecdsa signature of the transaction hash
+	MultiSig_Script MultiSigType = iota
+	// The signers give one schnorr signature, it is verified with the
+	// sum of the public keys of the signers, each weighted with its
+	// MuSig coefficient in the public keys of the script
+	MultiSig_Schnorr
+)
+
+// Maximum number of public keys of a multi-signature account
+const MaxMultiSigKeys = 15
+
+// Signature script of a multi-signature account. The public keys and m
+// decide the address, Signers are the indexes of the public keys that
+// signed, in ascending order.
+type MultiSigScript struct {
+	Type       MultiSigType
+	M          uint32
+	PubKeys    [][]byte
+	Signers    []uint32
+	Signatures [][]byte
+}
+
+// Address of the multi-signature account of the script
+func (m *MultiSigScript) Address(network string) (hasharry.Address, error) {
+	address, err := ut.GenerateMultiSigAddress(network, uint8(m.Type), m.M, m.PubKeys)
+	if err != nil {
+		return hasharry.Address{}, err
+	}
+	return hasharry.StringToAddress(address), nil
+}
+
+// Index of the public key in the script, -1 if it is not a signer
+func (m *MultiSigScript) IndexOf(pubKey []byte) int {
+	for i, key := range m.PubKeys {
+		if bytes.Equal(key, pubKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add the ecdsa signature of the signer, the signers are kept in order
+func (m *MultiSigScript) AddSignature(index uint32, signature []byte) {
+	i := 0
+	for ; i < len(m.Signers); i++ {
+		if m.Signers[i] == index {
+			m.Signatures[i] = signature
+			return
+		}
+		if m.Signers[i] > index {
+			break
+		}
+	}
+	m.Signers = append(m.Signers, 0)
+	copy(m.Signers[i+1:], m.Signers[i:])
+	m.Signers[i] = index
+	m.Signatures = append(m.Signatures, nil)
+	copy(m.Signatures[i+1:], m.Signatures[i:])
+	m.Signatures[i] = signature
+}
+
+// Public keys of the signers
+func (m *MultiSigScript) SignerKeys() ([]*secp256k1.PublicKey, error) {
+	keys := make([]*secp256k1.PublicKey, 0, len(m.Signers))
+	for _, index := range m.Signers {
+		if int(index) >= len(m.PubKeys) {
+			return nil, fmt.Errorf("signer %d does not exist", index)
+		}
+		key, err := secp256k1.ParsePubKey(m.PubKeys[index])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (m *MultiSigScript) verifyScript() error {
+	if m.Type != MultiSig_Script && m.Type != MultiSig_Schnorr {
+		return errors.New("unknown multi-signature type")
+	}
+	if len(m.PubKeys) == 0 || len(m.PubKeys) > MaxMultiSigKeys {
+		return fmt.Errorf("the number of public keys must be in the range of 1 and %d", MaxMultiSigKeys)
+	}
+	if m.M == 0 || int(m.M) > len(m.PubKeys) {
+		return errors.New("wrong number of required signatures")
+	}
+	exist := make(map[string]bool)
+	for _, key := range m.PubKeys {
+		if exist[string(key)] {
+			return errors.New("repeated public key")
+		}
+		exist[string(key)] = true
+	}
+	if len(m.Signers) < int(m.M) {
+		return fmt.Errorf("%d signatures are required", m.M)
+	}
+	for i, index := range m.Signers {
+		if i > 0 && index <= m.Signers[i-1] {
+			return errors.New("signers must be in ascending order")
+		}
+	}
+	return nil
+}
+
+// VerifyMultiSig verifies that the script belongs to the signer and at
+// least m of its keys signed the hash
+func VerifyMultiSig(network string, hash hasharry.Hash, signer hasharry.Address, script *MultiSigScript) error {
+	if err := script.verifyScript(); err != nil {
+		return err
+	}
+	address, err := script.Address(network)
+	if err != nil || !address.IsEqual(signer) {
+		return ErrSigner
+	}
+	keys, err := script.SignerKeys()
+	if err != nil {
+		return err
+	}
+	switch script.Type {
+	case MultiSig_Script:
+		if len(script.Signatures) != len(keys) {
+			return ErrWrongSignature
+		}
+		for i, key := range keys {
+			signature, err := secp256k1.ParseSignature(script.Signatures[i], secp256k1.S256())
+			if err != nil {
+				return ErrWrongSignature
+			}
+			if !signature.Verify(hash.Bytes(), key) {
+				return ErrSignature
+			}
+		}
+	case MultiSig_Schnorr:
+		if len(script.Signatures) != 1 {
+			return ErrWrongSignature
+		}
+		signature, err := schnorr.ParseSignature(script.Signatures[0])
+		if err != nil {
+			return ErrWrongSignature
+		}
+		signers := make([][]byte, len(script.Signers))
+		for i, index := range script.Signers {
+			signers[i] = script.PubKeys[index]
+		}
+		pubKey, err := schnorr.CombinePubkeysMuSig(script.PubKeys, signers)
+		if err != nil {
+			return ErrSignature
+		}
+		if !schnorr.Verify(pubKey, hash.Bytes(), signature.R, signature.S) {
+			return ErrSignature
+		}
+	}
+	return nil
+}
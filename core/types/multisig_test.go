@@ -0,0 +1,156 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/crypto/ecc/schnorr"
+	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
+	"github.com/uworldao/UWORLD/param"
+)
+
+func newMultiSigKeys(t *testing.T, n int) ([]*secp256k1.PrivateKey, [][]byte) {
+	privs := make([]*secp256k1.PrivateKey, n)
+	pubKeys := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		priv, err := secp256k1.GeneratePrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		privs[i] = priv
+		pubKeys[i] = priv.PubKey().SerializeCompressed()
+	}
+	return privs, pubKeys
+}
+
+func TestVerifyMultiSigScript(t *testing.T) {
+	privs, pubKeys := newMultiSigKeys(t, 3)
+	hash := hasharry.BytesToHash([]byte("multisig"))
+	script := &MultiSigScript{Type: MultiSig_Script, M: 2, PubKeys: pubKeys}
+	address, err := script.Address(param.Net)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []uint32{2, 0} {
+		signature, err := privs[i].Sign(hash.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		script.AddSignature(i, signature.Serialize())
+	}
+	if script.Signers[0] != 0 || script.Signers[1] != 2 {
+		t.Fatalf("signers not in order %v", script.Signers)
+	}
+	if err := VerifyMultiSig(param.Net, hash, address, script); err != nil {
+		t.Fatal(err)
+	}
+
+	other := hasharry.BytesToHash([]byte("other"))
+	if err := VerifyMultiSig(param.Net, other, address, script); err == nil {
+		t.Fatal("signature of another hash verified")
+	}
+	one := &MultiSigScript{Type: MultiSig_Script, M: 2, PubKeys: pubKeys, Signers: script.Signers[:1], Signatures: script.Signatures[:1]}
+	if err := VerifyMultiSig(param.Net, hash, address, one); err == nil {
+		t.Fatal("less than m signatures verified")
+	}
+	repeated := &MultiSigScript{Type: MultiSig_Script, M: 2, PubKeys: pubKeys, Signers: []uint32{0, 0}, Signatures: [][]byte{script.Signatures[0], script.Signatures[0]}}
+	if err := VerifyMultiSig(param.Net, hash, address, repeated); err == nil {
+		t.Fatal("repeated signer verified")
+	}
+	lower := &MultiSigScript{Type: MultiSig_Script, M: 1, PubKeys: pubKeys, Signers: script.Signers, Signatures: script.Signatures}
+	if err := VerifyMultiSig(param.Net, hash, address, lower); err != ErrSigner {
+		t.Fatalf("script of another address verified, %v", err)
+	}
+}
+
+func signMultiSigSchnorr(t *testing.T, hash hasharry.Hash, script *MultiSigScript, privs []*secp256k1.PrivateKey) []byte {
+	curve := secp256k1.S256()
+	privNonces := make([]*secp256k1.PrivateKey, 0)
+	pubNonces := make([]*secp256k1.PublicKey, 0)
+	for _, i := range script.Signers {
+		privNonce, pubNonce, err := schnorr.GenerateNoncePair(curve, hash.Bytes(), privs[i], nil, schnorr.BlakeVersionStringRFC6979)
+		if err != nil {
+			t.Fatal(err)
+		}
+		privNonces = append(privNonces, privNonce)
+		pubNonces = append(pubNonces, pubNonce)
+	}
+	partials := make([]*schnorr.Signature, 0)
+	for j, i := range script.Signers {
+		others := make([]*secp256k1.PublicKey, 0)
+		for k, pubNonce := range pubNonces {
+			if k != j {
+				others = append(others, pubNonce)
+			}
+		}
+		partial, err := schnorr.PartialSign(curve, hash.Bytes(), privs[i], privNonces[j], schnorr.CombinePubkeys(others))
+		if err != nil {
+			t.Fatal(err)
+		}
+		partials = append(partials, partial)
+	}
+	signature, err := schnorr.CombineSigs(curve, partials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signature.Serialize()
+}
+
+func TestVerifyMultiSigSchnorr(t *testing.T) {
+	privs, pubKeys := newMultiSigKeys(t, 3)
+	hash := hasharry.BytesToHash([]byte("multisig"))
+	script := &MultiSigScript{Type: MultiSig_Schnorr, M: 2, PubKeys: pubKeys, Signers: []uint32{0, 2}}
+	address, err := script.Address(param.Net)
+	if err != nil {
+		t.Fatal(err)
+	}
+	musigPrivs := make([]*secp256k1.PrivateKey, len(privs))
+	for i, priv := range privs {
+		musigPrivs[i] = schnorr.MuSigPrivkey(pubKeys, priv, pubKeys[i])
+	}
+	script.Signatures = [][]byte{signMultiSigSchnorr(t, hash, script, musigPrivs)}
+	if err := VerifyMultiSig(param.Net, hash, address, script); err != nil {
+		t.Fatal(err)
+	}
+	script.Signers = []uint32{0, 1}
+	if err := VerifyMultiSig(param.Net, hash, address, script); err != ErrSignature {
+		t.Fatalf("signature of other signers verified, %v", err)
+	}
+
+	// A signature of the plain sum of the keys is not accepted, so a key
+	// chosen to cancel the others cannot sign alone
+	script.Signers = []uint32{0, 2}
+	script.Signatures = [][]byte{signMultiSigSchnorr(t, hash, script, privs)}
+	if err := VerifyMultiSig(param.Net, hash, address, script); err != ErrSignature {
+		t.Fatalf("signature without key coefficients verified, %v", err)
+	}
+}
+
+func TestSignScriptEncode(t *testing.T) {
+	single := &SignScript{Signature: []byte{1, 2}, PubKey: []byte{3, 4}}
+	old := &struct {
+		Signature []byte
+		PubKey    []byte
+	}{single.Signature, single.PubKey}
+	singleBytes, _ := rlp.EncodeToBytes(single)
+	oldBytes, _ := rlp.EncodeToBytes(old)
+	if !bytes.Equal(singleBytes, oldBytes) {
+		t.Fatal("encoding of a single signature changed")
+	}
+
+	_, pubKeys := newMultiSigKeys(t, 2)
+	multi := &SignScript{MultiSig: []*MultiSigScript{{Type: MultiSig_Schnorr, M: 2, PubKeys: pubKeys, Signers: []uint32{0, 1}, Signatures: [][]byte{{5}}}}}
+	multiBytes, err := rlp.EncodeToBytes(multi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded *SignScript
+	if err := rlp.DecodeBytes(multiBytes, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.IsMultiSig() || decoded.MultiSig[0].Type != MultiSig_Schnorr || len(decoded.MultiSig[0].PubKeys) != 2 {
+		t.Fatalf("wrong decoded script %+v", decoded)
+	}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+)
+
+type ReceiptStatus uint32
+
+const (
+	Receipt_Success ReceiptStatus = 0
+	Receipt_Failed  ReceiptStatus = 1
+)
+
+// Result of executing a transaction in a block. The fees are paid to
+// the fee address and the consumption to the eater address for the
+// whole block, so they are not in the changes.
+type Receipt struct {
+	TxHash      hasharry.Hash
+	Status      ReceiptStatus
+	Fees        uint64
+	Consumption uint64
+	Changes     []*ReceiptChange
+	Events      []*Event
+	Error       string
+}
+
+// Change of the balance and locked in amount of a coin account, only
+// one of In and Out is not 0
+type ReceiptChange struct {
+	Address  hasharry.Address
+	Contract string
+	In       uint64
+	Out      uint64
+}
+
+type Receipts []*Receipt
+
+func (r Receipts) Bytes() []byte {
+	bytes, _ := rlp.EncodeToBytes(r)
+	return bytes
+}
+
+func DecodeReceipts(bytes []byte) (Receipts, error) {
+	var r Receipts
+	err := rlp.DecodeBytes(bytes, &r)
+	return r, err
+}
+
+// Get the receipt of the transaction
+func (r Receipts) Get(hash hasharry.Hash) *Receipt {
+	for _, receipt := range r {
+		if receipt.TxHash.IsEqual(hash) {
+			return receipt
+		}
+	}
+	return nil
+}
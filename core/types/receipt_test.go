@@ -0,0 +1,55 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/param"
+)
+
+func TestReceiptsEncode(t *testing.T) {
+	from := hasharry.StringToAddress("UWDSender")
+	to := hasharry.StringToAddress("UWDReceiver")
+	receipts := Receipts{
+		{
+			TxHash: hasharry.BytesToHash([]byte{1}),
+			Status: Receipt_Success,
+			Fees:   1,
+			Changes: []*ReceiptChange{
+				{Address: from, Contract: param.Token.String(), Out: 10},
+				{Address: to, Contract: param.Token.String(), In: 9},
+			},
+			Events: []*Event{
+				{EventType: Event_Transfer, From: from, To: to, Token: param.Token, Amount: 9, Height: 5},
+			},
+		},
+		{
+			TxHash:  hasharry.BytesToHash([]byte{2}),
+			Status:  Receipt_Failed,
+			Changes: []*ReceiptChange{},
+			Events:  []*Event{},
+			Error:   "failed",
+		},
+	}
+	decoded, err := DecodeReceipts(receipts.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 {
+		t.Fatalf("expected 2 receipts, got %d", len(decoded))
+	}
+	first := decoded.Get(hasharry.BytesToHash([]byte{1}))
+	if first == nil || first.Fees != 1 || len(first.Changes) != 2 || len(first.Events) != 1 {
+		t.Fatalf("wrong first receipt %+v", first)
+	}
+	if first.Changes[0].Out != 10 || first.Changes[1].In != 9 || first.Events[0].To != to {
+		t.Fatalf("wrong changes of the first receipt")
+	}
+	second := decoded.Get(hasharry.BytesToHash([]byte{2}))
+	if second == nil || second.Status != Receipt_Failed || second.Error != "failed" {
+		t.Fatalf("wrong second receipt %+v", second)
+	}
+	if decoded.Get(hasharry.BytesToHash([]byte{3})) != nil {
+		t.Fatal("unknown receipt found")
+	}
+}
@@ -0,0 +1,102 @@
+package types
+
+import (
+	"errors"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/param"
+)
+
+// Maximum commission rate, the winner keeps the whole reward
+const MaxCommission = 100
+
+// The commission rate of a candidate in percent, it applies to the
+// blocks of the next terms. A candidate that never sets it keeps the
+// whole reward.
+type CommissionBody struct {
+	Rate uint64
+}
+
+func (cb *CommissionBody) ToAddress() *Receivers {
+	return NewReceivers()
+}
+
+func (cb *CommissionBody) GetAmount() uint64 {
+	return 0
+}
+
+func (cb *CommissionBody) GetContract() hasharry.Address {
+	return param.Token
+}
+
+func (cb *CommissionBody) GetName() string {
+	return ""
+}
+
+func (cb *CommissionBody) GetAbbr() string {
+	return ""
+}
+
+func (cb *CommissionBody) GetIncreaseSwitch() bool {
+	return false
+}
+
+func (cb *CommissionBody) GetDescription() string {
+	return ""
+}
+
+func (cb *CommissionBody) GetPeerId() []byte {
+	return nil
+}
+
+func (cb *CommissionBody) VerifyBody(from hasharry.Address) error {
+	if cb.Rate > MaxCommission {
+		return errors.New("commission rate must not be greater than 100")
+	}
+	return nil
+}
+
+// Claim of the rewards accrued to a voter, the amount is minted
+// to the sender
+type ClaimRewardBody struct {
+	Amount uint64
+}
+
+func (cr *ClaimRewardBody) ToAddress() *Receivers {
+	return NewReceivers()
+}
+
+// The sender pays no amount
+func (cr *ClaimRewardBody) GetAmount() uint64 {
+	return 0
+}
+
+func (cr *ClaimRewardBody) GetContract() hasharry.Address {
+	return param.Token
+}
+
+func (cr *ClaimRewardBody) GetName() string {
+	return ""
+}
+
+func (cr *ClaimRewardBody) GetAbbr() string {
+	return ""
+}
+
+func (cr *ClaimRewardBody) GetIncreaseSwitch() bool {
+	return false
+}
+
+func (cr *ClaimRewardBody) GetDescription() string {
+	return ""
+}
+
+func (cr *ClaimRewardBody) GetPeerId() []byte {
+	return nil
+}
+
+func (cr *ClaimRewardBody) VerifyBody(from hasharry.Address) error {
+	if cr.Amount == 0 {
+		return errors.New("no reward to claim")
+	}
+	return nil
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
 	"github.com/uworldao/UWORLD/core/types/functionbody/exchange_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/htlc_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/nft_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/token_func"
 )
 
 type RlpTransaction struct {
@@ -42,6 +45,13 @@ func (rt *RlpTransaction) TranslateToTransaction() *Transaction {
 			TxHead: rt.TxHead,
 			TxBody: nt,
 		}
+	case LockedTransfer_:
+		var lt *LockedTransferBody
+		rlp.DecodeBytes(rt.TxBody, &lt)
+		return &Transaction{
+			TxHead: rt.TxHead,
+			TxBody: lt,
+		}
 	case Contract_:
 		var ct *ContractBody
 		rlp.DecodeBytes(rt.TxBody, &ct)
@@ -82,6 +92,58 @@ func (rt *RlpTransaction) TranslateToTransaction() *Transaction {
 			var create *exchange_func.ExchangeRemoveLiquidity
 			rlp.DecodeBytes(rlpCt.Function, &create)
 			ct.Function = create
+		case contractv2.Token_Init:
+			var init *token_func.TokenInitBody
+			rlp.DecodeBytes(rlpCt.Function, &init)
+			ct.Function = init
+		case contractv2.Token_Approve:
+			var approve *token_func.TokenApprove
+			rlp.DecodeBytes(rlpCt.Function, &approve)
+			ct.Function = approve
+		case contractv2.Token_TransferFrom:
+			var transfer *token_func.TokenTransferFrom
+			rlp.DecodeBytes(rlpCt.Function, &transfer)
+			ct.Function = transfer
+		case contractv2.Token_Burn:
+			var burn *token_func.TokenBurn
+			rlp.DecodeBytes(rlpCt.Function, &burn)
+			ct.Function = burn
+		case contractv2.Token_Mint:
+			var mint *token_func.TokenMint
+			rlp.DecodeBytes(rlpCt.Function, &mint)
+			ct.Function = mint
+		case contractv2.NFT_Create:
+			var create *nft_func.NFTCreateBody
+			rlp.DecodeBytes(rlpCt.Function, &create)
+			ct.Function = create
+		case contractv2.NFT_Mint:
+			var mint *nft_func.NFTMint
+			rlp.DecodeBytes(rlpCt.Function, &mint)
+			ct.Function = mint
+		case contractv2.NFT_Transfer:
+			var transfer *nft_func.NFTTransfer
+			rlp.DecodeBytes(rlpCt.Function, &transfer)
+			ct.Function = transfer
+		case contractv2.NFT_Approve:
+			var approve *nft_func.NFTApprove
+			rlp.DecodeBytes(rlpCt.Function, &approve)
+			ct.Function = approve
+		case contractv2.NFT_Burn:
+			var burn *nft_func.NFTBurn
+			rlp.DecodeBytes(rlpCt.Function, &burn)
+			ct.Function = burn
+		case contractv2.HTLC_Create:
+			var create *htlc_func.HTLCCreateBody
+			rlp.DecodeBytes(rlpCt.Function, &create)
+			ct.Function = create
+		case contractv2.HTLC_Claim:
+			var claim *htlc_func.HTLCClaim
+			rlp.DecodeBytes(rlpCt.Function, &claim)
+			ct.Function = claim
+		case contractv2.HTLC_Refund:
+			var refund *htlc_func.HTLCRefund
+			rlp.DecodeBytes(rlpCt.Function, &refund)
+			ct.Function = refund
 		}
 		rlp.DecodeBytes(rt.TxBody, &ct)
 		return &Transaction{
@@ -95,18 +157,41 @@ func (rt *RlpTransaction) TranslateToTransaction() *Transaction {
 			TxHead: rt.TxHead,
 			TxBody: nt,
 		}
-		/*case LogoutCandidate:
-			return &Transaction{
-				TxHead: rt.TxHead,
-				TxBody: &LogoutTransactionBody{},
-			}
-		case VoteToCandidate:
-			var nt *VoteTransactionBody
-			rlp.DecodeBytes(rt.TxBody, &nt)
-			return &Transaction{
-				TxHead: rt.TxHead,
-				TxBody: nt,
-			}*/
+	case LogoutCandidate_:
+		var nt *LogoutTransactionBody
+		rlp.DecodeBytes(rt.TxBody, &nt)
+		return &Transaction{
+			TxHead: rt.TxHead,
+			TxBody: nt,
+		}
+	case VoteToCandidate_:
+		var nt *VoteTransactionBody
+		rlp.DecodeBytes(rt.TxBody, &nt)
+		return &Transaction{
+			TxHead: rt.TxHead,
+			TxBody: nt,
+		}
+	case DoubleSign_:
+		var ds *DoubleSignBody
+		rlp.DecodeBytes(rt.TxBody, &ds)
+		return &Transaction{
+			TxHead: rt.TxHead,
+			TxBody: ds,
+		}
+	case SetCommission_:
+		var cb *CommissionBody
+		rlp.DecodeBytes(rt.TxBody, &cb)
+		return &Transaction{
+			TxHead: rt.TxHead,
+			TxBody: cb,
+		}
+	case ClaimReward_:
+		var cr *ClaimRewardBody
+		rlp.DecodeBytes(rt.TxBody, &cr)
+		return &Transaction{
+			TxHead: rt.TxHead,
+			TxBody: cr,
+		}
 	}
 	return nil
 }
@@ -31,6 +31,7 @@ type RpcEvent struct {
 	To        string  `json:"to"`
 	Token     string  `json:"token"`
 	Amount    float64 `json:"amount"`
+	TokenId   uint64  `json:"tokenid,omitempty"`
 	Height    uint64  `json:"height"`
 }
 
@@ -88,6 +89,132 @@ type RpcExchangeRemoveLiquidity struct {
 	Deadline   uint64  `json:"deadline"`
 }
 
+type RpcTokenInitBody struct {
+	Name     string  `json:"name"`
+	Symbol   string  `json:"symbol"`
+	Decimals uint8   `json:"decimals"`
+	Amount   float64 `json:"amount"`
+	Receiver string  `json:"receiver"`
+	Mintable bool    `json:"mintable"`
+}
+
+type RpcTokenApproveBody struct {
+	Spender string  `json:"spender"`
+	Amount  float64 `json:"amount"`
+}
+
+type RpcTokenTransferFromBody struct {
+	Owner  string  `json:"owner"`
+	To     string  `json:"to"`
+	Amount float64 `json:"amount"`
+}
+
+type RpcTokenBurnBody struct {
+	Amount float64 `json:"amount"`
+}
+
+type RpcTokenMintBody struct {
+	To     string  `json:"to"`
+	Amount float64 `json:"amount"`
+}
+
+type RpcToken struct {
+	Address     string  `json:"address"`
+	Name        string  `json:"name"`
+	Symbol      string  `json:"symbol"`
+	Decimals    uint8   `json:"decimals"`
+	TotalSupply float64 `json:"totalsupply"`
+	Owner       string  `json:"owner"`
+	Mintable    bool    `json:"mintable"`
+	CreateHash  string  `json:"createhash"`
+}
+
+type RpcTokenAllowance struct {
+	Token     string  `json:"token"`
+	Owner     string  `json:"owner"`
+	Spender   string  `json:"spender"`
+	Allowance float64 `json:"allowance"`
+}
+
+type RpcNFTCreateBody struct {
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+}
+
+type RpcNFTMintBody struct {
+	To           string `json:"to"`
+	URI          string `json:"uri"`
+	MetadataHash string `json:"metadatahash"`
+}
+
+type RpcNFTTransferBody struct {
+	Id uint64 `json:"id"`
+	To string `json:"to"`
+}
+
+type RpcNFTApproveBody struct {
+	Id      uint64 `json:"id"`
+	Spender string `json:"spender"`
+}
+
+type RpcNFTBurnBody struct {
+	Id uint64 `json:"id"`
+}
+
+type RpcNFTCollection struct {
+	Address    string `json:"address"`
+	Name       string `json:"name"`
+	Symbol     string `json:"symbol"`
+	Creator    string `json:"creator"`
+	Supply     uint64 `json:"supply"`
+	Minted     uint64 `json:"minted"`
+	CreateHash string `json:"createhash"`
+}
+
+type RpcNFT struct {
+	Collection   string `json:"collection"`
+	Id           uint64 `json:"id"`
+	Owner        string `json:"owner"`
+	Approved     string `json:"approved"`
+	URI          string `json:"uri"`
+	MetadataHash string `json:"metadatahash"`
+}
+
+type RpcNFTPage struct {
+	Address string    `json:"address"`
+	Start   uint64    `json:"start"`
+	Total   uint64    `json:"total"`
+	Tokens  []*RpcNFT `json:"tokens"`
+}
+
+type RpcHTLCCreateBody struct {
+	Recipient string  `json:"recipient"`
+	Token     string  `json:"token"`
+	Amount    float64 `json:"amount"`
+	HashLock  string  `json:"hashlock"`
+	TimeLock  uint64  `json:"timelock"`
+}
+
+type RpcHTLCClaimBody struct {
+	Preimage string `json:"preimage"`
+}
+
+type RpcHTLCRefundBody struct {
+}
+
+type RpcHTLC struct {
+	Address    string  `json:"address"`
+	Sender     string  `json:"sender"`
+	Recipient  string  `json:"recipient"`
+	Token      string  `json:"token"`
+	Amount     float64 `json:"amount"`
+	HashLock   string  `json:"hashlock"`
+	TimeLock   uint64  `json:"timelock"`
+	State      string  `json:"state"`
+	Preimage   string  `json:"preimage"`
+	CreateHash string  `json:"createhash"`
+}
+
 type RpcPair struct {
 	Address  string `json:"address"`
 	Token0   string `json:"token0"`
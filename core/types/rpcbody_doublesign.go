@@ -0,0 +1,9 @@
+package types
+
+// The headers are rlp encoded in hex. The time of a json header is
+// in the local time zone, the hash of the transaction would depend
+// on the node.
+type RpcDoubleSignBody struct {
+	Header1 string `json:"header1"`
+	Header2 string `json:"header2"`
+}
@@ -19,3 +19,11 @@ type RpcReceiver struct {
 	Address string `json:"address"`
 	Amount  uint64 `json:"amount"`
 }
+
+type RpcLockedTransferBody struct {
+	Contract string `json:"contract"`
+	To       string `json:"to"`
+	Amount   uint64 `json:"amount"`
+	Start    uint64 `json:"start"`
+	End      uint64 `json:"end"`
+}
@@ -0,0 +1,15 @@
+package types
+
+type RpcCommissionBody struct {
+	Rate uint64 `json:"rate"`
+}
+
+type RpcClaimRewardBody struct {
+	Amount uint64 `json:"amount"`
+}
+
+// Rewards accrued to an address and not claimed yet
+type RpcReward struct {
+	Address string `json:"address"`
+	Reward  uint64 `json:"reward"`
+}
@@ -1,10 +1,13 @@
 package types
 
 type RpcCandidate struct {
-	Signer   string `json:"address"`
-	PeerId   string `json:"peerid"`
-	Weight   uint64 `json:"votes"`
-	MntCount uint64 `json:"mntcount"`
+	Signer string `json:"address"`
+	PeerId string `json:"peerid"`
+	Weight uint64 `json:"votes"`
+	Stake  uint64 `json:"stake"`
+	// Percentage of the block rewards kept by the candidate
+	Commission uint64 `json:"commission"`
+	MntCount   uint64 `json:"mntcount"`
 }
 
 type RpcCandidates struct {
@@ -15,9 +18,11 @@ func TranslateCandidatesToRpcCandidates(candidates []*Candidate) *RpcCandidates
 	rpcCandidates := &RpcCandidates{Candidates: make([]*RpcCandidate, 0)}
 	for _, candidate := range candidates {
 		rpcCandidate := &RpcCandidate{
-			Signer: candidate.Signer.String(),
-			PeerId: candidate.PeerId,
-			Weight: candidate.Weight,
+			Signer:     candidate.Signer.String(),
+			PeerId:     candidate.PeerId,
+			Weight:     candidate.Weight,
+			Stake:      candidate.Stake,
+			Commission: candidate.Commission,
 		}
 		rpcCandidates.Candidates = append(rpcCandidates.Candidates, rpcCandidate)
 	}
@@ -1,25 +1,29 @@
 package types
 
 import (
+	"github.com/uworldao/UWORLD/common/hasharry"
 	"time"
 )
 
 type RpcHeader struct {
-	Hash          string    `json:"hash"`
-	ParentHash    string    `json:"parenthash"`
-	TxRoot        string    `json:"txroot"`
-	StateRoot     string    `json:"stateroot"`
-	ContractRoot  string    `json:"contractroot"`
-	ConsensusRoot string    `json:"consensusroot"`
-	Height        uint64    `json:"height"`
-	Time          time.Time `json:"time"`
-	Term          uint64    `json:"term"`
-	Signer        string    `json:"signer"`
+	Version       uint32         `json:"version"`
+	Hash          string         `json:"hash"`
+	ParentHash    string         `json:"parenthash"`
+	TxRoot        string         `json:"txroot"`
+	StateRoot     string         `json:"stateroot"`
+	ContractRoot  string         `json:"contractroot"`
+	ConsensusRoot string         `json:"consensusroot"`
+	Height        uint64         `json:"height"`
+	Time          time.Time      `json:"time"`
+	Term          uint64         `json:"term"`
+	SignScript    *RpcSignScript `json:"signscript,omitempty"`
+	Signer        string         `json:"signer"`
 }
 
 func TranslateHeaderToRpcHeader(header *Header) *RpcHeader {
 	signer := header.Signer.String()
-	return &RpcHeader{
+	rpcHeader := &RpcHeader{
+		Version:       header.Version,
 		Hash:          header.HashString(),
 		ParentHash:    header.ParentHashString(),
 		TxRoot:        header.TxRoot.String(),
@@ -31,4 +35,43 @@ func TranslateHeaderToRpcHeader(header *Header) *RpcHeader {
 		Term:          header.Term,
 		Signer:        signer,
 	}
+	if header.SignScript != nil {
+		rpcHeader.SignScript = TranslateSignScriptToRpcSignScript(header.SignScript)
+	}
+	return rpcHeader
+}
+
+// Rebuild the signed header, it has the same hash as the header
+// of the block
+func TranslateRpcHeaderToHeader(rpcHeader *RpcHeader) (*Header, error) {
+	header := &Header{
+		Version: rpcHeader.Version,
+		Height:  rpcHeader.Height,
+		Time:    uint64(rpcHeader.Time.Unix()),
+		Term:    rpcHeader.Term,
+		Signer:  hasharry.StringToAddress(rpcHeader.Signer),
+	}
+	var err error
+	if header.Hash, err = hasharry.StringToHash(rpcHeader.Hash); err != nil {
+		return nil, err
+	}
+	if header.ParentHash, err = hasharry.StringToHash(rpcHeader.ParentHash); err != nil {
+		return nil, err
+	}
+	if header.TxRoot, err = hasharry.StringToHash(rpcHeader.TxRoot); err != nil {
+		return nil, err
+	}
+	if header.StateRoot, err = hasharry.StringToHash(rpcHeader.StateRoot); err != nil {
+		return nil, err
+	}
+	if header.ContractRoot, err = hasharry.StringToHash(rpcHeader.ContractRoot); err != nil {
+		return nil, err
+	}
+	if header.ConsensusRoot, err = hasharry.StringToHash(rpcHeader.ConsensusRoot); err != nil {
+		return nil, err
+	}
+	if header.SignScript, err = TranslateRpcSignScriptToSignScript(rpcHeader.SignScript); err != nil {
+		return nil, err
+	}
+	return header, nil
 }
@@ -0,0 +1,43 @@
+package types
+
+type RpcReceipt struct {
+	Status      ReceiptStatus       `json:"status"`
+	Fees        float64             `json:"fees"`
+	Consumption float64             `json:"consumption"`
+	Changes     []*RpcReceiptChange `json:"changes"`
+	Events      []*RpcEvent         `json:"events"`
+	Error       string              `json:"error"`
+}
+
+type RpcReceiptChange struct {
+	Address  string  `json:"address"`
+	Contract string  `json:"contract"`
+	In       float64 `json:"in"`
+	Out      float64 `json:"out"`
+}
+
+func TranslateReceiptToRpcReceipt(receipt *Receipt) *RpcReceipt {
+	if receipt == nil {
+		return nil
+	}
+	rpcReceipt := &RpcReceipt{
+		Status:      receipt.Status,
+		Fees:        Amount(receipt.Fees).ToCoin(),
+		Consumption: Amount(receipt.Consumption).ToCoin(),
+		Changes:     make([]*RpcReceiptChange, 0, len(receipt.Changes)),
+		Events:      make([]*RpcEvent, 0, len(receipt.Events)),
+		Error:       receipt.Error,
+	}
+	for _, change := range receipt.Changes {
+		rpcReceipt.Changes = append(rpcReceipt.Changes, &RpcReceiptChange{
+			Address:  change.Address.String(),
+			Contract: change.Contract,
+			In:       Amount(change.In).ToCoin(),
+			Out:      Amount(change.Out).ToCoin(),
+		})
+	}
+	for _, e := range receipt.Events {
+		rpcReceipt.Events = append(rpcReceipt.Events, TranslateEventToRpcEvent(e))
+	}
+	return rpcReceipt
+}
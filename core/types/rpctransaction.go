@@ -4,9 +4,16 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/encode/rlp"
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
 	"github.com/uworldao/UWORLD/core/types/functionbody/exchange_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/htlc_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/nft_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/token_func"
 )
 
 type IRpcTransactionBody interface {
@@ -21,6 +28,9 @@ type RpcTransactionHead struct {
 	Time       uint64          `json:"time"`
 	Note       string          `json:"note"`
 	SignScript *RpcSignScript  `json:"signscript"`
+	// Omitted when the transaction does not expire, so the hashes of
+	// those transactions are unchanged
+	ValidUntilHeight uint64 `json:"validuntilheight,omitempty"`
 }
 
 type RpcTransaction struct {
@@ -33,11 +43,21 @@ type RpcTransactionConfirmed struct {
 	TxBody    IRpcTransactionBody `json:"txbody"`
 	Height    uint64              `json:"height"`
 	Confirmed bool                `json:"confirmed"`
+	Receipt   *RpcReceipt         `json:"receipt,omitempty"`
 }
 
 type RpcSignScript struct {
-	Signature string `json:"signature"`
-	PubKey    string `json:"pubkey"`
+	Signature string             `json:"signature"`
+	PubKey    string             `json:"pubkey"`
+	MultiSig  *RpcMultiSigScript `json:"multisig,omitempty"`
+}
+
+type RpcMultiSigScript struct {
+	Type       MultiSigType `json:"type"`
+	M          uint32       `json:"m"`
+	PubKeys    []string     `json:"pubkeys"`
+	Signers    []uint32     `json:"signers"`
+	Signatures []string     `json:"signatures"`
 }
 
 func (th *RpcTransactionHead) FromBytes() []byte {
@@ -78,6 +98,23 @@ func TranslateRpcTxToTx(rpcTx *RpcTransaction) (*Transaction, error) {
 			return nil, err
 		}
 		txBody, err = translateRpcTransferV2BodyToBody(body)
+	case LockedTransfer_:
+		body := &RpcLockedTransferBody{}
+		bytes, err := json.Marshal(rpcTx.TxBody)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(bytes, body)
+		if err != nil {
+			return nil, err
+		}
+		txBody = &LockedTransferBody{
+			Contract: hasharry.StringToAddress(body.Contract),
+			To:       hasharry.StringToAddress(body.To),
+			Amount:   body.Amount,
+			Start:    body.Start,
+			End:      body.End,
+		}
 	case Contract_:
 		body := &RpcContractBody{}
 		bytes, err := json.Marshal(rpcTx.TxBody)
@@ -91,12 +128,67 @@ func TranslateRpcTxToTx(rpcTx *RpcTransaction) (*Transaction, error) {
 		txBody, err = translateRpcContractBodyToBody(body)
 	case ContractV2_:
 		txBody, err = translateRpcContractV2BodyToBody(rpcTx.TxBody)
-		/*case types.LoginCandidate_:
-			txBody, err = translateRpcLoginBodyToBody(rpcTx.LoginBody)
-		case types.LogoutCandidate:
-			txBody = &types.LogoutTransactionBody{}
-		case types.VoteToCandidate:
-			txBody, err = translateRpcVoteBodyToBody(rpcTx.VoteBody)*/
+	case LoginCandidate_:
+		body := &RpcLoginTransactionBody{}
+		bytes, err := json.Marshal(rpcTx.TxBody)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(bytes, body)
+		if err != nil {
+			return nil, err
+		}
+		if txBody, err = translateRpcLoginBodyToBody(body); err != nil {
+			return nil, err
+		}
+	case LogoutCandidate_:
+		txBody = &LogoutTransactionBody{}
+	case VoteToCandidate_:
+		body := &RpcVoteTransactionBody{}
+		bytes, err := json.Marshal(rpcTx.TxBody)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(bytes, body)
+		if err != nil {
+			return nil, err
+		}
+		if txBody, err = translateRpcVoteBodyToBody(body); err != nil {
+			return nil, err
+		}
+	case DoubleSign_:
+		body := &RpcDoubleSignBody{}
+		bytes, err := json.Marshal(rpcTx.TxBody)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(bytes, body)
+		if err != nil {
+			return nil, err
+		}
+		if txBody, err = translateRpcDoubleSignBodyToBody(body); err != nil {
+			return nil, err
+		}
+	case SetCommission_:
+		body := &RpcCommissionBody{}
+		bytes, err := json.Marshal(rpcTx.TxBody)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(bytes, body); err != nil {
+			return nil, err
+		}
+		txBody = &CommissionBody{Rate: body.Rate}
+	case ClaimReward_:
+		body := &RpcClaimRewardBody{}
+		bytes, err := json.Marshal(rpcTx.TxBody)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(bytes, body); err != nil {
+			return nil, err
+		}
+		txBody = &ClaimRewardBody{Amount: body.Amount}
 	}
 	tx := &Transaction{
 		TxHead: &TransactionHead{
@@ -111,6 +203,7 @@ func TranslateRpcTxToTx(rpcTx *RpcTransaction) (*Transaction, error) {
 		},
 		TxBody: txBody,
 	}
+	tx.SetValidUntilHeight(rpcTx.TxHead.ValidUntilHeight)
 	return tx, nil
 }
 
@@ -118,17 +211,15 @@ func TranslateTxToRpcTx(tx *Transaction) (*RpcTransaction, error) {
 	var err error
 	rpcTx := &RpcTransaction{
 		TxHead: &RpcTransactionHead{
-			TxHash: tx.Hash().String(),
-			TxType: tx.GetTxType(),
-			From:   addressToString(tx.From()),
-			Nonce:  tx.GetNonce(),
-			Fees:   tx.GetFees(),
-			Time:   tx.GetTime(),
-			Note:   tx.GetNote(),
-			SignScript: &RpcSignScript{
-				Signature: hex.EncodeToString(tx.GetSignScript().Signature),
-				PubKey:    hex.EncodeToString(tx.GetSignScript().PubKey),
-			}},
+			TxHash:           tx.Hash().String(),
+			TxType:           tx.GetTxType(),
+			From:             addressToString(tx.From()),
+			Nonce:            tx.GetNonce(),
+			Fees:             tx.GetFees(),
+			Time:             tx.GetTime(),
+			Note:             tx.GetNote(),
+			SignScript:       TranslateSignScriptToRpcSignScript(tx.GetSignScript()),
+			ValidUntilHeight: tx.GetValidUntilHeight()},
 		TxBody: nil,
 	}
 	switch tx.GetTxType() {
@@ -150,6 +241,18 @@ func TranslateTxToRpcTx(tx *Transaction) (*RpcTransaction, error) {
 			})
 		}
 		rpcTx.TxBody = rpcBody
+	case LockedTransfer_:
+		body, ok := tx.GetTxBody().(*LockedTransferBody)
+		if !ok {
+			return nil, errors.New("wrong transaction body")
+		}
+		rpcTx.TxBody = &RpcLockedTransferBody{
+			Contract: body.Contract.String(),
+			To:       body.To.String(),
+			Amount:   body.Amount,
+			Start:    body.Start,
+			End:      body.End,
+		}
 	case Contract_:
 		rpcTx.TxBody = &RpcContractBody{
 			Contract:    tx.GetTxBody().GetContract().String(),
@@ -173,11 +276,33 @@ func TranslateTxToRpcTx(tx *Transaction) (*RpcTransaction, error) {
 		rpcTx.TxBody = &RpcLoginTransactionBody{
 			PeerId: string(tx.GetTxBody().GetPeerId()),
 		}
-		/*case types.LogoutCandidate:
-			rpcTx.LogoutBody = &RpcLogoutTransactionBody{}
-		case types.VoteToCandidate:
-			rpcTx.VoteBody = &RpcVoteTransactionBody{To: tx.GetTxBody().ToAddress().String()}
-		*/
+	case LogoutCandidate_:
+		rpcTx.TxBody = &RpcLogoutTransactionBody{}
+	case VoteToCandidate_:
+		rpcTx.TxBody = &RpcVoteTransactionBody{
+			To: tx.GetTxBody().ToAddress().ReceiverList()[0].Address.String(),
+		}
+	case DoubleSign_:
+		body, ok := tx.GetTxBody().(*DoubleSignBody)
+		if !ok {
+			return nil, errors.New("wrong transaction body")
+		}
+		rpcTx.TxBody = &RpcDoubleSignBody{
+			Header1: hex.EncodeToString(body.Header1.ToBytes()),
+			Header2: hex.EncodeToString(body.Header2.ToBytes()),
+		}
+	case SetCommission_:
+		body, ok := tx.GetTxBody().(*CommissionBody)
+		if !ok {
+			return nil, errors.New("wrong transaction body")
+		}
+		rpcTx.TxBody = &RpcCommissionBody{Rate: body.Rate}
+	case ClaimReward_:
+		body, ok := tx.GetTxBody().(*ClaimRewardBody)
+		if !ok {
+			return nil, errors.New("wrong transaction body")
+		}
+		rpcTx.TxBody = &RpcClaimRewardBody{Amount: body.Amount}
 	}
 
 	return rpcTx, nil
@@ -359,6 +484,268 @@ func translateRpcContractV2BodyToBody(rpcBody IRpcTransactionBody) (*TxContractV
 				Deadline:   remove.Deadline,
 			},
 		}, nil
+	case contractv2.Token_Init:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		init := &RpcTokenInitBody{}
+		err = json.Unmarshal(bytes, init)
+		if err != nil {
+			return nil, err
+		}
+		amount, _ := NewAmount(init.Amount)
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &token_func.TokenInitBody{
+				Name:     init.Name,
+				Symbol:   init.Symbol,
+				Decimals: init.Decimals,
+				Amount:   amount,
+				Receiver: hasharry.StringToAddress(init.Receiver),
+				Mintable: init.Mintable,
+			},
+		}, nil
+	case contractv2.Token_Approve:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		approve := &RpcTokenApproveBody{}
+		err = json.Unmarshal(bytes, approve)
+		if err != nil {
+			return nil, err
+		}
+		amount, _ := NewAmount(approve.Amount)
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &token_func.TokenApprove{
+				Spender: hasharry.StringToAddress(approve.Spender),
+				Amount:  amount,
+			},
+		}, nil
+	case contractv2.Token_TransferFrom:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		transfer := &RpcTokenTransferFromBody{}
+		err = json.Unmarshal(bytes, transfer)
+		if err != nil {
+			return nil, err
+		}
+		amount, _ := NewAmount(transfer.Amount)
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &token_func.TokenTransferFrom{
+				Owner:  hasharry.StringToAddress(transfer.Owner),
+				To:     hasharry.StringToAddress(transfer.To),
+				Amount: amount,
+			},
+		}, nil
+	case contractv2.Token_Burn:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		burn := &RpcTokenBurnBody{}
+		err = json.Unmarshal(bytes, burn)
+		if err != nil {
+			return nil, err
+		}
+		amount, _ := NewAmount(burn.Amount)
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &token_func.TokenBurn{
+				Amount: amount,
+			},
+		}, nil
+	case contractv2.Token_Mint:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		mint := &RpcTokenMintBody{}
+		err = json.Unmarshal(bytes, mint)
+		if err != nil {
+			return nil, err
+		}
+		amount, _ := NewAmount(mint.Amount)
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &token_func.TokenMint{
+				To:     hasharry.StringToAddress(mint.To),
+				Amount: amount,
+			},
+		}, nil
+	case contractv2.NFT_Create:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		create := &RpcNFTCreateBody{}
+		err = json.Unmarshal(bytes, create)
+		if err != nil {
+			return nil, err
+		}
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &nft_func.NFTCreateBody{
+				Name:   create.Name,
+				Symbol: create.Symbol,
+			},
+		}, nil
+	case contractv2.NFT_Mint:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		mint := &RpcNFTMintBody{}
+		err = json.Unmarshal(bytes, mint)
+		if err != nil {
+			return nil, err
+		}
+		var metadataHash hasharry.Hash
+		if mint.MetadataHash != "" {
+			metadataHash, err = hasharry.StringToHash(mint.MetadataHash)
+			if err != nil {
+				return nil, errors.New("wrong metadata hash")
+			}
+		}
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &nft_func.NFTMint{
+				To:           hasharry.StringToAddress(mint.To),
+				URI:          mint.URI,
+				MetadataHash: metadataHash,
+			},
+		}, nil
+	case contractv2.NFT_Transfer:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		transfer := &RpcNFTTransferBody{}
+		err = json.Unmarshal(bytes, transfer)
+		if err != nil {
+			return nil, err
+		}
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &nft_func.NFTTransfer{
+				Id: transfer.Id,
+				To: hasharry.StringToAddress(transfer.To),
+			},
+		}, nil
+	case contractv2.NFT_Approve:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		approve := &RpcNFTApproveBody{}
+		err = json.Unmarshal(bytes, approve)
+		if err != nil {
+			return nil, err
+		}
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &nft_func.NFTApprove{
+				Id:      approve.Id,
+				Spender: hasharry.StringToAddress(approve.Spender),
+			},
+		}, nil
+	case contractv2.NFT_Burn:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		burn := &RpcNFTBurnBody{}
+		err = json.Unmarshal(bytes, burn)
+		if err != nil {
+			return nil, err
+		}
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &nft_func.NFTBurn{
+				Id: burn.Id,
+			},
+		}, nil
+	case contractv2.HTLC_Create:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		create := &RpcHTLCCreateBody{}
+		err = json.Unmarshal(bytes, create)
+		if err != nil {
+			return nil, err
+		}
+		hashLock, err := hasharry.StringToHash(create.HashLock)
+		if err != nil {
+			return nil, errors.New("wrong hash lock")
+		}
+		amount, _ := NewAmount(create.Amount)
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &htlc_func.HTLCCreateBody{
+				Recipient: hasharry.StringToAddress(create.Recipient),
+				Token:     hasharry.StringToAddress(create.Token),
+				Amount:    amount,
+				HashLock:  hashLock,
+				TimeLock:  create.TimeLock,
+			},
+		}, nil
+	case contractv2.HTLC_Claim:
+		bytes, err := json.Marshal(body.Function)
+		if err != nil {
+			return nil, err
+		}
+		claim := &RpcHTLCClaimBody{}
+		err = json.Unmarshal(bytes, claim)
+		if err != nil {
+			return nil, err
+		}
+		preimage, err := hex.DecodeString(claim.Preimage)
+		if err != nil {
+			return nil, errors.New("wrong preimage")
+		}
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function: &htlc_func.HTLCClaim{
+				Preimage: preimage,
+			},
+		}, nil
+	case contractv2.HTLC_Refund:
+		return &TxContractV2Body{
+			Contract:     hasharry.StringToAddress(body.Contract),
+			Type:         body.Type,
+			FunctionType: body.FunctionType,
+			Function:     &htlc_func.HTLCRefund{},
+		}, nil
 	}
 	return nil, errors.New("wrong transaction body")
 }
@@ -367,17 +754,15 @@ func TranslateContractV2TxToRpcTx(tx *Transaction, state *ContractV2State) (*Rpc
 	var err error
 	rpcTx := &RpcTransaction{
 		TxHead: &RpcTransactionHead{
-			TxHash: tx.Hash().String(),
-			TxType: tx.GetTxType(),
-			From:   addressToString(tx.From()),
-			Nonce:  tx.GetNonce(),
-			Fees:   tx.GetFees(),
-			Time:   tx.GetTime(),
-			Note:   tx.GetNote(),
-			SignScript: &RpcSignScript{
-				Signature: hex.EncodeToString(tx.GetSignScript().Signature),
-				PubKey:    hex.EncodeToString(tx.GetSignScript().PubKey),
-			}},
+			TxHash:           tx.Hash().String(),
+			TxType:           tx.GetTxType(),
+			From:             addressToString(tx.From()),
+			Nonce:            tx.GetNonce(),
+			Fees:             tx.GetFees(),
+			Time:             tx.GetTime(),
+			Note:             tx.GetNote(),
+			SignScript:       TranslateSignScriptToRpcSignScript(tx.GetSignScript()),
+			ValidUntilHeight: tx.GetValidUntilHeight()},
 		TxBody: nil,
 	}
 	switch tx.GetTxType() {
@@ -396,28 +781,7 @@ func TranslateContractV2TxToRpcTx(tx *Transaction, state *ContractV2State) (*Rpc
 }
 
 func translateToRpcContractV2WithState(body *TxContractV2Body, contractState *ContractV2State) (*RpcContractV2BodyWithState, error) {
-	var state *RpcContractState = &RpcContractState{
-		StateCode: Contract_Wait,
-		Events:    make([]*RpcEvent, 0),
-		Error:     "",
-	}
-	if contractState != nil {
-		state.StateCode = contractState.State
-		state.Error = contractState.Error
-		if contractState.Event != nil {
-			for _, e := range contractState.Event {
-				state.Events = append(state.Events, &RpcEvent{
-					EventType: int(e.EventType),
-					From:      e.From.String(),
-					To:        e.To.String(),
-					Token:     e.Token.String(),
-					Amount:    Amount(e.Amount).ToCoin(),
-					Height:    e.Height,
-				})
-			}
-		}
-	}
-
+	state := TranslateContractV2State(contractState)
 	funcBody, err := rpcFunction(body)
 	if err != nil {
 		return nil, err
@@ -526,14 +890,160 @@ func rpcFunction(body *TxContractV2Body) (IRCFunction, error) {
 			AmountBMin: Amount(funcBody.AmountBMin).ToCoin(),
 			Deadline:   funcBody.Deadline,
 		}
+	case contractv2.Token_Init:
+		funcBody, ok := body.Function.(*token_func.TokenInitBody)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcTokenInitBody{
+			Name:     funcBody.Name,
+			Symbol:   funcBody.Symbol,
+			Decimals: funcBody.Decimals,
+			Amount:   Amount(funcBody.Amount).ToCoin(),
+			Receiver: funcBody.Receiver.String(),
+			Mintable: funcBody.Mintable,
+		}
+	case contractv2.Token_Approve:
+		funcBody, ok := body.Function.(*token_func.TokenApprove)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcTokenApproveBody{
+			Spender: funcBody.Spender.String(),
+			Amount:  Amount(funcBody.Amount).ToCoin(),
+		}
+	case contractv2.Token_TransferFrom:
+		funcBody, ok := body.Function.(*token_func.TokenTransferFrom)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcTokenTransferFromBody{
+			Owner:  funcBody.Owner.String(),
+			To:     funcBody.To.String(),
+			Amount: Amount(funcBody.Amount).ToCoin(),
+		}
+	case contractv2.Token_Burn:
+		funcBody, ok := body.Function.(*token_func.TokenBurn)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcTokenBurnBody{
+			Amount: Amount(funcBody.Amount).ToCoin(),
+		}
+	case contractv2.Token_Mint:
+		funcBody, ok := body.Function.(*token_func.TokenMint)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcTokenMintBody{
+			To:     funcBody.To.String(),
+			Amount: Amount(funcBody.Amount).ToCoin(),
+		}
+	case contractv2.NFT_Create:
+		funcBody, ok := body.Function.(*nft_func.NFTCreateBody)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcNFTCreateBody{
+			Name:   funcBody.Name,
+			Symbol: funcBody.Symbol,
+		}
+	case contractv2.NFT_Mint:
+		funcBody, ok := body.Function.(*nft_func.NFTMint)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcNFTMintBody{
+			To:           funcBody.To.String(),
+			URI:          funcBody.URI,
+			MetadataHash: hashToString(funcBody.MetadataHash),
+		}
+	case contractv2.NFT_Transfer:
+		funcBody, ok := body.Function.(*nft_func.NFTTransfer)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcNFTTransferBody{
+			Id: funcBody.Id,
+			To: funcBody.To.String(),
+		}
+	case contractv2.NFT_Approve:
+		funcBody, ok := body.Function.(*nft_func.NFTApprove)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcNFTApproveBody{
+			Id:      funcBody.Id,
+			Spender: funcBody.Spender.String(),
+		}
+	case contractv2.NFT_Burn:
+		funcBody, ok := body.Function.(*nft_func.NFTBurn)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcNFTBurnBody{
+			Id: funcBody.Id,
+		}
+	case contractv2.HTLC_Create:
+		funcBody, ok := body.Function.(*htlc_func.HTLCCreateBody)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcHTLCCreateBody{
+			Recipient: funcBody.Recipient.String(),
+			Token:     funcBody.Token.String(),
+			Amount:    Amount(funcBody.Amount).ToCoin(),
+			HashLock:  funcBody.HashLock.String(),
+			TimeLock:  funcBody.TimeLock,
+		}
+	case contractv2.HTLC_Claim:
+		funcBody, ok := body.Function.(*htlc_func.HTLCClaim)
+		if !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcHTLCClaimBody{
+			Preimage: hex.EncodeToString(funcBody.Preimage),
+		}
+	case contractv2.HTLC_Refund:
+		if _, ok := body.Function.(*htlc_func.HTLCRefund); !ok {
+			return nil, errors.New("wrong function body")
+		}
+		function = &RpcHTLCRefundBody{}
 	}
 	return function, nil
 }
 
+func TranslateSignScriptToRpcSignScript(signScript *SignScript) *RpcSignScript {
+	rpcSignScript := &RpcSignScript{
+		Signature: hex.EncodeToString(signScript.Signature),
+		PubKey:    hex.EncodeToString(signScript.PubKey),
+	}
+	if signScript.IsMultiSig() {
+		multiSig := signScript.MultiSig[0]
+		rpcSignScript.MultiSig = &RpcMultiSigScript{
+			Type:       multiSig.Type,
+			M:          multiSig.M,
+			PubKeys:    make([]string, len(multiSig.PubKeys)),
+			Signers:    multiSig.Signers,
+			Signatures: make([]string, len(multiSig.Signatures)),
+		}
+		for i, pubKey := range multiSig.PubKeys {
+			rpcSignScript.MultiSig.PubKeys[i] = hex.EncodeToString(pubKey)
+		}
+		for i, signature := range multiSig.Signatures {
+			rpcSignScript.MultiSig.Signatures[i] = hex.EncodeToString(signature)
+		}
+	}
+	return rpcSignScript
+}
+
 func TranslateRpcSignScriptToSignScript(rpcSignScript *RpcSignScript) (*SignScript, error) {
 	if rpcSignScript == nil {
 		return nil, ErrNoSignature
 	}
+	if rpcSignScript.MultiSig != nil {
+		return translateRpcMultiSigScript(rpcSignScript.MultiSig)
+	}
 	if rpcSignScript.Signature == "" || rpcSignScript.PubKey == "" {
 		return nil, ErrWrongSignature
 	}
@@ -551,6 +1061,28 @@ func TranslateRpcSignScriptToSignScript(rpcSignScript *RpcSignScript) (*SignScri
 	}, nil
 }
 
+func translateRpcMultiSigScript(rpcMultiSig *RpcMultiSigScript) (*SignScript, error) {
+	multiSig := &MultiSigScript{
+		Type:       rpcMultiSig.Type,
+		M:          rpcMultiSig.M,
+		PubKeys:    make([][]byte, len(rpcMultiSig.PubKeys)),
+		Signers:    rpcMultiSig.Signers,
+		Signatures: make([][]byte, len(rpcMultiSig.Signatures)),
+	}
+	var err error
+	for i, pubKey := range rpcMultiSig.PubKeys {
+		if multiSig.PubKeys[i], err = hex.DecodeString(pubKey); err != nil {
+			return nil, err
+		}
+	}
+	for i, signature := range rpcMultiSig.Signatures {
+		if multiSig.Signatures[i], err = hex.DecodeString(signature); err != nil {
+			return nil, err
+		}
+	}
+	return &SignScript{MultiSig: []*MultiSigScript{multiSig}}, nil
+}
+
 func translateRpcNormalBodyToBody(rpcBody *RpcTransferBody) (*TransferBody, error) {
 	if rpcBody == nil {
 		return nil, errors.New("wrong transaction body")
@@ -597,6 +1129,9 @@ func translateRpcLoginBodyToBody(rpcBody *RpcLoginTransactionBody) (*LoginTransa
 	if rpcBody == nil {
 		return nil, errors.New("wrong transaction body")
 	}
+	if len(rpcBody.PeerIdBytes()) != PeerIdLength {
+		return nil, errors.New("wrong peer id")
+	}
 	loginTx := &LoginTransactionBody{}
 	copy(loginTx.PeerId[:], rpcBody.PeerIdBytes())
 	return loginTx, nil
@@ -610,6 +1145,30 @@ func translateRpcVoteBodyToBody(rpcBody *RpcVoteTransactionBody) (*VoteTransacti
 	return &VoteTransactionBody{To: hasharry.StringToAddress(rpcBody.To)}, nil
 }
 
+func translateRpcDoubleSignBodyToBody(rpcBody *RpcDoubleSignBody) (*DoubleSignBody, error) {
+	header1, err := decodeRpcHeader(rpcBody.Header1)
+	if err != nil {
+		return nil, err
+	}
+	header2, err := decodeRpcHeader(rpcBody.Header2)
+	if err != nil {
+		return nil, err
+	}
+	return &DoubleSignBody{Header1: header1, Header2: header2}, nil
+}
+
+func decodeRpcHeader(s string) (*Header, error) {
+	bytes, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var header *Header
+	if err := rlp.DecodeBytes(bytes, &header); err != nil {
+		return nil, fmt.Errorf("wrong block header, %s", err.Error())
+	}
+	return header, nil
+}
+
 func addressToString(address hasharry.Address) string {
 	if address.IsEqual(hasharry.StringToAddress(CoinBase)) {
 		return CoinBase
@@ -617,6 +1176,14 @@ func addressToString(address hasharry.Address) string {
 	return address.String()
 }
 
+// An empty string for the zero hash
+func hashToString(hash hasharry.Hash) string {
+	if hash.IsEqual(hasharry.Hash{}) {
+		return ""
+	}
+	return hash.String()
+}
+
 func addrListToHashAddr(addrList []string) []hasharry.Address {
 	hashList := make([]hasharry.Address, len(addrList))
 	for i, addr := range addrList {
@@ -632,3 +1199,63 @@ func hashAddrToAddr(hashList []hasharry.Address) []string {
 	}
 	return addrList
 }
+
+func TranslateContractV2State(contractState *ContractV2State) *RpcContractState {
+	var state *RpcContractState = &RpcContractState{
+		StateCode: Contract_Wait,
+		Events:    make([]*RpcEvent, 0),
+		Error:     "",
+	}
+	if contractState != nil {
+		state.StateCode = contractState.State
+		state.Error = contractState.Error
+		if contractState.Event != nil {
+			for _, e := range contractState.Event {
+				state.Events = append(state.Events, TranslateEventToRpcEvent(e))
+			}
+		}
+	}
+	return state
+}
+
+func TranslateEventToRpcEvent(e *Event) *RpcEvent {
+	rpcEvent := &RpcEvent{
+		EventType: int(e.EventType),
+		From:      e.From.String(),
+		To:        e.To.String(),
+		Token:     e.Token.String(),
+		Height:    e.Height,
+	}
+	if e.IsNFT() {
+		rpcEvent.TokenId = e.Amount
+	} else {
+		rpcEvent.Amount = Amount(e.Amount).ToCoin()
+	}
+	return rpcEvent
+}
+
+func TranslateNFTToRpcNFT(token *nft.Token) *RpcNFT {
+	return &RpcNFT{
+		Collection:   token.Collection.String(),
+		Id:           token.Id,
+		Owner:        token.Owner.String(),
+		Approved:     token.Approved.String(),
+		URI:          token.URI,
+		MetadataHash: hashToString(token.MetadataHash),
+	}
+}
+
+func TranslateSwapToRpcHTLC(address hasharry.Address, createHash hasharry.Hash, swap *htlc.Swap) *RpcHTLC {
+	return &RpcHTLC{
+		Address:    address.String(),
+		Sender:     swap.Sender.String(),
+		Recipient:  swap.Recipient.String(),
+		Token:      swap.Token.String(),
+		Amount:     Amount(swap.Amount).ToCoin(),
+		HashLock:   swap.HashLock.String(),
+		TimeLock:   swap.TimeLock,
+		State:      swap.State.String(),
+		Preimage:   hex.EncodeToString(swap.Preimage),
+		CreateHash: createHash.String(),
+	}
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"sync"
+)
+
+// Maximum number of signatures kept in the cache
+const maxSigCacheSize = 100000
+
+// The transaction pool and block verification share this cache, so
+// a transaction verified when it entered the pool is not verified
+// again when it is imported in a block.
+var sigCache = NewSigCache(maxSigCacheSize)
+
+// SigCache records signatures that have been verified successfully.
+// The key contains the hash, the signer and the whole sign script, so
+// an entry can only be hit by exactly the same signed transaction.
+type SigCache struct {
+	mutex   sync.RWMutex
+	valid   map[string]struct{}
+	maxSize int
+}
+
+func NewSigCache(maxSize int) *SigCache {
+	return &SigCache{
+		valid:   make(map[string]struct{}, maxSize),
+		maxSize: maxSize,
+	}
+}
+
+func (s *SigCache) Exist(hash hasharry.Hash, signer hasharry.Address, signScript *SignScript) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, ok := s.valid[sigCacheKey(hash, signer, signScript)]
+	return ok
+}
+
+// Add a verified signature, if the cache is full a random entry is evicted
+func (s *SigCache) Add(hash hasharry.Hash, signer hasharry.Address, signScript *SignScript) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.maxSize <= 0 {
+		return
+	}
+	if len(s.valid) >= s.maxSize {
+		for key := range s.valid {
+			delete(s.valid, key)
+			break
+		}
+	}
+	s.valid[sigCacheKey(hash, signer, signScript)] = struct{}{}
+}
+
+func (s *SigCache) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.valid)
+}
+
+// The public key length is part of the key, so that the boundary
+// between the public key and the signature can not be shifted.
+// A multi-signature script is appended in its rlp encoding.
+func sigCacheKey(hash hasharry.Hash, signer hasharry.Address, signScript *SignScript) string {
+	keyLen := make([]byte, 4)
+	binary.BigEndian.PutUint32(keyLen, uint32(len(signScript.PubKey)))
+	key := bytes.Join([][]byte{hash.Bytes(), signer.Bytes(), keyLen, signScript.PubKey, signScript.Signature}, []byte{})
+	if signScript.IsMultiSig() {
+		multiSig, _ := rlp.EncodeToBytes(signScript.MultiSig)
+		key = append(key, multiSig...)
+	}
+	return string(key)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"testing"
+)
+
+func TestSigCache(t *testing.T) {
+	cache := NewSigCache(2)
+	hash := hasharry.BytesToHash([]byte("hash"))
+	signer := hasharry.StringToAddress("UWDSigner")
+	script := &SignScript{Signature: []byte{1, 2, 3}, PubKey: []byte{4, 5}}
+	cache.Add(hash, signer, script)
+	if !cache.Exist(hash, signer, script) {
+		t.Fatal("signature should exist")
+	}
+
+	// Moving bytes between the public key and the signature is another script
+	shifted := &SignScript{Signature: []byte{5, 1, 2, 3}, PubKey: []byte{4}}
+	if cache.Exist(hash, signer, shifted) {
+		t.Fatal("shifted signature should not exist")
+	}
+
+	cache.Add(hasharry.BytesToHash([]byte("hash1")), signer, script)
+	cache.Add(hasharry.BytesToHash([]byte("hash2")), signer, script)
+	if cache.Len() != 2 {
+		t.Fatalf("cache size %d, expect 2", cache.Len())
+	}
+}
@@ -7,10 +7,14 @@ import (
 )
 
 // Signature information, including the result of the
-// signature and the public key.
+// signature and the public key. A multi-signature account
+// leaves them empty and signs with MultiSig, it has one
+// script at most and is encoded as the tail so that the
+// encoding of single signatures is unchanged.
 type SignScript struct {
-	Signature []byte `json:"signature"`
-	PubKey    []byte `json:"pubkey"`
+	Signature []byte            `json:"signature"`
+	PubKey    []byte            `json:"pubkey"`
+	MultiSig  []*MultiSigScript `json:"multisig,omitempty" rlp:"tail"`
 }
 
 // Sign the hash with the private key
@@ -19,7 +23,12 @@ func Sign(key *secp256k1.PrivateKey, hash hasharry.Hash) (*SignScript, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &SignScript{signature.Serialize(), key.PubKey().SerializeCompressed()}, nil
+	return &SignScript{Signature: signature.Serialize(), PubKey: key.PubKey().SerializeCompressed()}, nil
+}
+
+// Whether it is the signature of a multi-signature account
+func (s *SignScript) IsMultiSig() bool {
+	return len(s.MultiSig) != 0
 }
 
 // Verify signature by hash and signature result
@@ -32,6 +41,9 @@ func Verify(hash hasharry.Hash, signScript *SignScript) bool {
 		return false
 	}
 	signature, err := secp256k1.ParseSignature(signScript.Signature, secp256k1.S256())
+	if err != nil {
+		return false
+	}
 	return signature.Verify(hash.Bytes(), pubkey)
 }
 
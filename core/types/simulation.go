@@ -0,0 +1,24 @@
+package types
+
+import "github.com/uworldao/UWORLD/common/hasharry"
+
+// Result of running a transaction against a copy of the state
+type Simulation struct {
+	TxHash  hasharry.Hash
+	Height  uint64
+	Fees    uint64
+	State   *ContractV2State
+	Changes []*BalanceChange
+	Error   string
+}
+
+// Change of a coin account of an address
+type BalanceChange struct {
+	Address   hasharry.Address
+	Contract  string
+	Balance   int64
+	LockedIn  int64
+	LockedOut int64
+	// Change of the coins of the locked transfers
+	Locked int64
+}
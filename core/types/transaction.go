@@ -2,11 +2,15 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/uworldao/UWORLD/common/encode/rlp"
 	hash2 "github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
 	"github.com/uworldao/UWORLD/core/types/functionbody/exchange_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/htlc_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/nft_func"
+	"github.com/uworldao/UWORLD/core/types/functionbody/token_func"
 	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
 	"github.com/uworldao/UWORLD/crypto/hash"
 	"github.com/uworldao/UWORLD/param"
@@ -20,8 +24,12 @@ const (
 	LoginCandidate_
 	TransferV2_
 	ContractV2_
-	/*LogoutCandidate
-	VoteToCandidate*/
+	LockedTransfer_
+	VoteToCandidate_
+	LogoutCandidate_
+	DoubleSign_
+	SetCommission_
+	ClaimReward_
 )
 const MaxNote = 256
 
@@ -38,6 +46,10 @@ type TransactionHead struct {
 	Time       uint64
 	Note       string
 	SignScript *SignScript
+	// Height of the last block that can contain the transaction, it has
+	// at most one element. The transactions without an expiry are
+	// encoded as before it was added.
+	ValidUntil []uint64 `rlp:"tail"`
 }
 
 type Transaction struct {
@@ -54,8 +66,9 @@ func (t *Transaction) Size() uint64 {
 	return uint64(len(bytes))
 }
 
-func (t *Transaction) VerifyTx() error {
-	if err := t.verifyHead(); err != nil {
+// Verify the transaction by the rules of the block of the height
+func (t *Transaction) VerifyTx(height uint64) error {
+	if err := t.verifyHead(height); err != nil {
 		return err
 	}
 
@@ -65,12 +78,12 @@ func (t *Transaction) VerifyTx() error {
 	return nil
 }
 
-func (t *Transaction) verifyHead() error {
+func (t *Transaction) verifyHead(height uint64) error {
 	if t.TxHead == nil {
 		return ErrTxHead
 	}
 
-	if err := t.verifyTxType(); err != nil {
+	if err := t.verifyTxType(height); err != nil {
 		return err
 	}
 
@@ -86,11 +99,15 @@ func (t *Transaction) verifyHead() error {
 		return err
 	}
 
-	if err := t.verifyTxFees(); err != nil {
+	if err := t.verifyTxFees(height); err != nil {
 		return err
 	}
 
-	if err := t.verifyTxSinger(); err != nil {
+	if err := t.verifyTxExpiry(height); err != nil {
+		return err
+	}
+
+	if err := t.verifyTxSinger(height); err != nil {
 		return err
 	}
 	return nil
@@ -111,18 +128,20 @@ func (t *Transaction) verifyBody() error {
 	return nil
 }
 
-func (t *Transaction) VerifyCoinBaseTx(height, sumFees uint64) error {
+// The coinbase pays the commission of the winner with the rate in
+// percent, the rest of the reward is shared by its voters
+func (t *Transaction) VerifyCoinBaseTx(height, sumFees, rate uint64) error {
 	if err := t.verifyTxSize(); err != nil {
 		return err
 	}
 
-	if err := t.verifyCoinBaseAmount(height, sumFees); err != nil {
+	if err := t.verifyCoinBaseAmount(height, sumFees, rate); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (t *Transaction) verifyTxFees() error {
+func (t *Transaction) verifyTxFees(height uint64) error {
 	var fees uint64
 	switch t.TxHead.TxType {
 	case Transfer_:
@@ -133,6 +152,14 @@ func (t *Transaction) verifyTxFees() error {
 		fees = param.TokenConsumption
 	case ContractV2_:
 		fees = param.Fees
+	case LockedTransfer_:
+		fees = param.Fees
+	case LoginCandidate_, VoteToCandidate_, LogoutCandidate_:
+		fees = param.Fees
+	case DoubleSign_:
+		fees = param.Fees
+	case SetCommission_, ClaimReward_:
+		fees = param.Fees
 	}
 	if t.TxHead.Fees != fees {
 		return fmt.Errorf("transaction costs %d fees", fees)
@@ -140,7 +167,14 @@ func (t *Transaction) verifyTxFees() error {
 	return nil
 }
 
-func (t *Transaction) verifyTxSinger() error {
+func (t *Transaction) verifyTxSinger(height uint64) error {
+	if t.TxHead.SignScript != nil && t.TxHead.SignScript.IsMultiSig() {
+		return t.verifyMultiSigner(height)
+	}
+	if t.TxHead.SignScript != nil && sigCache.Exist(t.TxHead.TxHash, t.TxHead.From, t.TxHead.SignScript) {
+		return nil
+	}
+
 	if !Verify(t.TxHead.TxHash, t.TxHead.SignScript) {
 		return ErrSignature
 	}
@@ -148,6 +182,25 @@ func (t *Transaction) verifyTxSinger() error {
 	if !VerifySigner(param.Net, t.TxHead.From, t.TxHead.SignScript.PubKey) {
 		return ErrSigner
 	}
+	sigCache.Add(t.TxHead.TxHash, t.TxHead.From, t.TxHead.SignScript)
+	return nil
+}
+
+func (t *Transaction) verifyMultiSigner(height uint64) error {
+	if !param.IsActive(param.MultiSig, height) {
+		return ErrMultiSigInactive
+	}
+	signScript := t.TxHead.SignScript
+	if len(signScript.MultiSig) != 1 || len(signScript.Signature) != 0 || len(signScript.PubKey) != 0 {
+		return ErrWrongSignature
+	}
+	if sigCache.Exist(t.TxHead.TxHash, t.TxHead.From, signScript) {
+		return nil
+	}
+	if err := VerifyMultiSig(param.Net, t.TxHead.TxHash, t.TxHead.From, signScript.MultiSig[0]); err != nil {
+		return err
+	}
+	sigCache.Add(t.TxHead.TxHash, t.TxHead.From, signScript)
 	return nil
 }
 
@@ -160,21 +213,13 @@ func (t *Transaction) verifyTxSize() error {
 		fallthrough
 	case Contract_:
 		return nil
-		/*case LogoutCandidate:
-			fallthrough
-		case LoginCandidate_:
-			fallthrough
-		case VoteToCandidate:
-			if t.Size() > MaxNoDataTxSize {
-				return ErrTxSize
-			}*/
 	}
 	return nil
 }
 
-func (t *Transaction) verifyCoinBaseAmount(height, amount uint64) error {
+func (t *Transaction) verifyCoinBaseAmount(height, amount, rate uint64) error {
 	nTx := t.TxBody.(*TransferBody)
-	sumAmount := CalCoinBase(height, param.CoinHeight) + amount
+	sumAmount := CalCommission(CalCoinBase(height, param.CoinHeight), rate) + amount
 	if sumAmount != nTx.Amount {
 		return ErrCoinBase
 	}
@@ -196,7 +241,7 @@ func (t *Transaction) verifyTxFrom() error {
 	return nil
 }
 
-func (t *Transaction) verifyTxType() error {
+func (t *Transaction) verifyTxType(height uint64) error {
 	switch t.TxHead.TxType {
 	case Transfer_:
 		return nil
@@ -205,17 +250,88 @@ func (t *Transaction) verifyTxType() error {
 	case Contract_:
 		return nil
 	case ContractV2_:
+		body, ok := t.TxBody.(*TxContractV2Body)
+		if !ok {
+			return ErrTxType
+		}
+		if fork, ok := contractV2Forks[body.Type]; ok && !param.IsActive(fork, height) {
+			return ErrTxType
+		}
+		return nil
+	case LockedTransfer_:
+		if _, ok := t.TxBody.(*LockedTransferBody); !ok {
+			return ErrTxType
+		}
+		if !param.IsActive(param.TimeLock, height) {
+			return ErrTxType
+		}
+		return nil
+	case LoginCandidate_:
+		if _, ok := t.TxBody.(*LoginTransactionBody); !ok {
+			return ErrTxType
+		}
+		return t.verifyElection(height)
+	case VoteToCandidate_:
+		if _, ok := t.TxBody.(*VoteTransactionBody); !ok {
+			return ErrTxType
+		}
+		return t.verifyElection(height)
+	case LogoutCandidate_:
+		if _, ok := t.TxBody.(*LogoutTransactionBody); !ok {
+			return ErrTxType
+		}
+		return t.verifyElection(height)
+	case DoubleSign_:
+		if _, ok := t.TxBody.(*DoubleSignBody); !ok {
+			return ErrTxType
+		}
+		if !param.IsActive(param.Slashing, height) {
+			return ErrTxType
+		}
 		return nil
-		/*case VoteToCandidate:
-			return nil
-		case LoginCandidate_:
-			return nil
-		case LogoutCandidate:
-			return nil*/
+	case SetCommission_:
+		if _, ok := t.TxBody.(*CommissionBody); !ok {
+			return ErrTxType
+		}
+		return t.verifyRewardSharing(height)
+	case ClaimReward_:
+		if _, ok := t.TxBody.(*ClaimRewardBody); !ok {
+			return ErrTxType
+		}
+		return t.verifyRewardSharing(height)
 	}
 	return ErrTxType
 }
 
+func (t *Transaction) verifyRewardSharing(height uint64) error {
+	if !param.IsActive(param.RewardSharing, height) {
+		return ErrTxType
+	}
+	return nil
+}
+
+// Whether a block has at most one transaction of the type. These
+// transactions are verified against the consensus state before the
+// block, so a second one could break the rules the first one checked.
+func OncePerBlock(txType TransactionType) bool {
+	return txType == LogoutCandidate_ || txType == DoubleSign_
+}
+
+// Whether a sender has at most one transaction of the type in a block.
+// The rewards to claim and the candidates are read from the consensus
+// state before the block, which the first transaction changes.
+func OncePerSender(txType TransactionType) bool {
+	return txType == ClaimReward_ || txType == LoginCandidate_
+}
+
+// The candidates of the genesis are the only ones before the election fork
+func (t *Transaction) verifyElection(height uint64) error {
+	if !param.IsActive(param.Election, height) {
+		return ErrTxType
+	}
+	return nil
+}
+
 func (t *Transaction) verifyTxHash() error {
 	newTx := t.copy()
 	newTx.SetHash()
@@ -232,6 +348,22 @@ func (t *Transaction) verifyTxNote() error {
 	return nil
 }
 
+func (t *Transaction) verifyTxExpiry(height uint64) error {
+	if len(t.TxHead.ValidUntil) == 0 {
+		return nil
+	}
+	if !param.IsActive(param.TxExpiry, height) {
+		return ErrTxExpiryInactive
+	}
+	if len(t.TxHead.ValidUntil) > 1 {
+		return errors.New("wrong transaction expiry")
+	}
+	if t.Expired(height) {
+		return ErrTxExpired
+	}
+	return nil
+}
+
 func (t *Transaction) EncodeToBytes() ([]byte, error) {
 	return rlp.EncodeToBytes(t)
 }
@@ -269,6 +401,7 @@ func (t *Transaction) copy() *Transaction {
 		Time:       t.TxHead.Time,
 		Note:       t.TxHead.Note,
 		SignScript: t.TxHead.SignScript,
+		ValidUntil: t.TxHead.ValidUntil,
 	}
 	return &Transaction{
 		TxHead: header,
@@ -304,6 +437,32 @@ func (t *Transaction) GetNote() string {
 	return t.TxHead.Note
 }
 
+// GetValidUntilHeight returns the height of the last block that can
+// contain the transaction, 0 if it does not expire
+func (t *Transaction) GetValidUntilHeight() uint64 {
+	if len(t.TxHead.ValidUntil) == 0 {
+		return 0
+	}
+	return t.TxHead.ValidUntil[0]
+}
+
+// SetValidUntilHeight sets the expiry of the transaction, 0 removes it.
+// The hash must be set again.
+func (t *Transaction) SetValidUntilHeight(height uint64) {
+	if height == 0 {
+		t.TxHead.ValidUntil = nil
+		return
+	}
+	t.TxHead.ValidUntil = []uint64{height}
+}
+
+// Expired returns whether the transaction can not be in the block of
+// the height
+func (t *Transaction) Expired(height uint64) bool {
+	validUntil := t.GetValidUntilHeight()
+	return validUntil != 0 && height > validUntil
+}
+
 func (t *Transaction) GetTxType() TransactionType {
 	return t.TxHead.TxType
 }
@@ -364,6 +523,58 @@ func (t *Transaction) TranslateToRlpTransaction() *RlpTransaction {
 			function, _ := body.Function.(*exchange_func.ExchangeRemoveLiquidity)
 			bytes, _ := rlp.EncodeToBytes(function)
 			rlpC.TxBody.Function = bytes
+		case contractv2.Token_Init:
+			function, _ := body.Function.(*token_func.TokenInitBody)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.Token_Approve:
+			function, _ := body.Function.(*token_func.TokenApprove)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.Token_TransferFrom:
+			function, _ := body.Function.(*token_func.TokenTransferFrom)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.Token_Burn:
+			function, _ := body.Function.(*token_func.TokenBurn)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.Token_Mint:
+			function, _ := body.Function.(*token_func.TokenMint)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.NFT_Create:
+			function, _ := body.Function.(*nft_func.NFTCreateBody)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.NFT_Mint:
+			function, _ := body.Function.(*nft_func.NFTMint)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.NFT_Transfer:
+			function, _ := body.Function.(*nft_func.NFTTransfer)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.NFT_Approve:
+			function, _ := body.Function.(*nft_func.NFTApprove)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.NFT_Burn:
+			function, _ := body.Function.(*nft_func.NFTBurn)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.HTLC_Create:
+			function, _ := body.Function.(*htlc_func.HTLCCreateBody)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.HTLC_Claim:
+			function, _ := body.Function.(*htlc_func.HTLCClaim)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
+		case contractv2.HTLC_Refund:
+			function, _ := body.Function.(*htlc_func.HTLCRefund)
+			bytes, _ := rlp.EncodeToBytes(function)
+			rlpC.TxBody.Function = bytes
 		}
 		rlpTx.TxBody, _ = rlp.EncodeToBytes(rlpC.TxBody)
 	default:
@@ -382,6 +593,15 @@ func (t *TxLocation) GetHeight() uint64 {
 	return t.Height
 }
 
+// The part of the reward paid to the winner by the coinbase with the
+// commission rate in percent, the rest is shared by its voters
+func CalCommission(reward, rate uint64) uint64 {
+	if rate >= MaxCommission {
+		return reward
+	}
+	return reward * rate / MaxCommission
+}
+
 func CalCoinBase(height, startHeight uint64) uint64 {
 	if height < startHeight {
 		return 0
@@ -1,8 +1,16 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"testing"
+
+	"github.com/uworldao/UWORLD/common/encode/rlp"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/ut"
 )
 
 func TestCalCoinBase(t *testing.T) {
@@ -17,3 +25,242 @@ func TestCalCoinBase(t *testing.T) {
 	}
 	fmt.Println(sum)
 }
+
+func TestTransactionExpiry(t *testing.T) {
+	tx := &Transaction{
+		TxHead: &TransactionHead{
+			TxType:     Transfer_,
+			From:       hasharry.StringToAddress("UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw"),
+			Nonce:      1,
+			Fees:       param.Fees,
+			Time:       1600000000,
+			SignScript: &SignScript{},
+		},
+		TxBody: &TransferBody{
+			Contract: param.Token,
+			To:       hasharry.StringToAddress("UWDMy4HuzG6E8eJQNzuMcHkyHkNv6HX1vZgh"),
+			Amount:   100,
+		},
+	}
+	tx.SetHash()
+	head := tx.TxHead
+	old := &struct {
+		TxHash     hasharry.Hash
+		TxType     TransactionType
+		From       hasharry.Address
+		Nonce      uint64
+		Fees       uint64
+		Time       uint64
+		Note       string
+		SignScript *SignScript
+	}{head.TxHash, head.TxType, head.From, head.Nonce, head.Fees, head.Time, head.Note, head.SignScript}
+	headBytes, _ := rlp.EncodeToBytes(head)
+	oldBytes, _ := rlp.EncodeToBytes(old)
+	if !bytes.Equal(headBytes, oldBytes) {
+		t.Fatal("encoding of a transaction without expiry changed")
+	}
+	if tx.Expired(math.MaxUint64) || tx.verifyTxExpiry(1) != nil {
+		t.Fatal("a transaction without expiry expired")
+	}
+
+	hash := tx.Hash()
+	tx.SetValidUntilHeight(100)
+	tx.SetHash()
+	if tx.Hash().IsEqual(hash) {
+		t.Fatal("the expiry is not in the hash")
+	}
+	if tx.Expired(100) || !tx.Expired(101) {
+		t.Fatal("wrong expiry")
+	}
+	decoded := tx.TranslateToRlpTransaction().TranslateToTransaction()
+	if decoded.GetValidUntilHeight() != 100 || !decoded.Hash().IsEqual(tx.Hash()) || decoded.verifyTxHash() != nil {
+		t.Fatalf("wrong decoded transaction %+v", decoded.TxHead)
+	}
+	if tx.verifyTxExpiry(1) != ErrTxExpiryInactive {
+		t.Fatal("expiry verified before the fork")
+	}
+}
+
+func TestCandidateTransactions(t *testing.T) {
+	var peerId PeerId
+	copy(peerId[:], "16Uiu2HAm8dGc2gAuQG9WAdPNeXRDG1GU8wQtv4f2jdGEGkrX9Ln1")
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidate := ut.GenerateUWDAddress(param.Net, priv.PubKey())
+	bodies := map[TransactionType]ITransactionBody{
+		LoginCandidate_:  &LoginTransactionBody{PeerId: peerId},
+		VoteToCandidate_: &VoteTransactionBody{To: hasharry.StringToAddress(candidate)},
+		LogoutCandidate_: &LogoutTransactionBody{},
+	}
+	for txType, body := range bodies {
+		tx := &Transaction{
+			TxHead: &TransactionHead{
+				TxType:     txType,
+				From:       hasharry.StringToAddress("UWDGLmQMfEeF6Fh8CGztrSktnHVpCxLiheYw"),
+				Nonce:      1,
+				Fees:       param.Fees,
+				Time:       1600000000,
+				SignScript: &SignScript{},
+			},
+			TxBody: body,
+		}
+		tx.SetHash()
+		if err := tx.SignTx(priv); err != nil {
+			t.Fatal(err)
+		}
+		decoded := tx.TranslateToRlpTransaction().TranslateToTransaction()
+		if decoded == nil || !decoded.Hash().IsEqual(tx.Hash()) || decoded.verifyTxHash() != nil {
+			t.Fatalf("wrong decoded transaction of type %d", txType)
+		}
+		rpcTx, err := TranslateTxToRpcTx(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		translated, err := TranslateRpcTxToTx(rpcTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if translated.verifyTxHash() != nil || translated.GetTxBody().VerifyBody(tx.From()) != nil {
+			t.Fatalf("wrong translated transaction of type %d", txType)
+		}
+		if tx.verifyTxType(1) != ErrTxType || tx.verifyTxFees(1) != nil {
+			t.Fatalf("wrong verification of type %d before the election fork", txType)
+		}
+	}
+
+	short := &LoginTransactionBody{}
+	copy(short.PeerId[:], "16Uiu2HAm8dGc2gAuQG9WAdPNeXRDG1GU8wQ")
+	if short.VerifyBody(hasharry.Address{}) == nil {
+		t.Fatal("a short peer id is accepted")
+	}
+}
+
+func TestDoubleSignEvidence(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := hasharry.StringToAddress(ut.GenerateUWDAddress(param.Net, priv.PubKey()))
+	signHeader := func(height, time uint64, root string) *Header {
+		header := &Header{Version: BlockVersion, Height: height, Time: time, Term: 1, Signer: signer,
+			StateRoot: hasharry.BytesToHash([]byte(root))}
+		header.SetHash()
+		if header.SignScript, err = Sign(priv, header.Hash); err != nil {
+			t.Fatal(err)
+		}
+		return header
+	}
+	body := &DoubleSignBody{Header1: signHeader(10, 1600000020, "a"), Header2: signHeader(10, 1600000020, "b")}
+	if err := body.VerifyBody(hasharry.Address{}); err != nil {
+		t.Fatal(err)
+	}
+	tx := &Transaction{
+		TxHead: &TransactionHead{
+			TxType:     DoubleSign_,
+			From:       signer,
+			Nonce:      1,
+			Fees:       param.Fees,
+			Time:       1600000000,
+			SignScript: &SignScript{},
+		},
+		TxBody: body,
+	}
+	tx.SetHash()
+	if err := tx.SignTx(priv); err != nil {
+		t.Fatal(err)
+	}
+	decoded := tx.TranslateToRlpTransaction().TranslateToTransaction()
+	if decoded == nil || decoded.verifyTxHash() != nil || decoded.GetTxBody().VerifyBody(signer) != nil {
+		t.Fatal("wrong decoded evidence")
+	}
+	rpcTx, err := TranslateTxToRpcTx(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	translated, err := TranslateRpcTxToTx(rpcTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if translated.verifyTxHash() != nil || translated.GetTxBody().VerifyBody(signer) != nil {
+		t.Fatal("wrong translated evidence")
+	}
+	header, err := TranslateRpcHeaderToHeader(TranslateHeaderToRpcHeader(body.Header1))
+	if err != nil || header.VerifySignature() != nil {
+		t.Fatal("wrong translated header")
+	}
+
+	same := &DoubleSignBody{Header1: body.Header1, Header2: body.Header1}
+	if same.VerifyBody(signer) == nil {
+		t.Fatal("the same header is accepted")
+	}
+	// A signature of another hash can not be used as a header
+	forged := *body.Header2
+	forged.Time++
+	if (&DoubleSignBody{Header1: body.Header1, Header2: &forged}).VerifyBody(signer) == nil {
+		t.Fatal("a header with a wrong hash is accepted")
+	}
+	other := signHeader(11, 1600000020, "b")
+	if (&DoubleSignBody{Header1: body.Header1, Header2: other}).VerifyBody(signer) == nil {
+		t.Fatal("headers at different heights are accepted")
+	}
+	// A block that was lost can be signed again at its height in a later slot
+	later := signHeader(10, 1600000020+param.BlockInterval, "b")
+	if (&DoubleSignBody{Header1: body.Header1, Header2: later}).VerifyBody(signer) == nil {
+		t.Fatal("headers of different slots are accepted")
+	}
+}
+
+func TestRewardTransactions(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := hasharry.StringToAddress(ut.GenerateUWDAddress(param.Net, priv.PubKey()))
+	bodies := map[TransactionType]ITransactionBody{
+		SetCommission_: &CommissionBody{Rate: 20},
+		ClaimReward_:   &ClaimRewardBody{Amount: 12345},
+	}
+	for txType, body := range bodies {
+		tx := &Transaction{
+			TxHead: &TransactionHead{
+				TxType:     txType,
+				From:       from,
+				Nonce:      1,
+				Fees:       param.Fees,
+				Time:       1600000000,
+				SignScript: &SignScript{},
+			},
+			TxBody: body,
+		}
+		tx.SetHash()
+		if err := tx.SignTx(priv); err != nil {
+			t.Fatal(err)
+		}
+		decoded := tx.TranslateToRlpTransaction().TranslateToTransaction()
+		if decoded == nil || decoded.verifyTxHash() != nil {
+			t.Fatalf("wrong decoded transaction of type %d", txType)
+		}
+		rpcTx, err := TranslateTxToRpcTx(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		translated, err := TranslateRpcTxToTx(rpcTx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if translated.verifyTxHash() != nil || translated.GetTxBody().VerifyBody(from) != nil {
+			t.Fatalf("wrong translated transaction of type %d", txType)
+		}
+	}
+	if (&CommissionBody{Rate: MaxCommission + 1}).VerifyBody(from) == nil {
+		t.Fatal("a rate over 100 is accepted")
+	}
+	if (&ClaimRewardBody{}).VerifyBody(from) == nil {
+		t.Fatal("an empty claim is accepted")
+	}
+	if CalCommission(300000000, 20) != 60000000 || CalCommission(300000000, MaxCommission) != 300000000 {
+		t.Fatal("wrong commission")
+	}
+}
@@ -0,0 +1,53 @@
+package schnorr
+
+import (
+	"math/big"
+
+	chainhash "github.com/Qitmeer/qitmeer-lib/common/hash"
+	"github.com/uworldao/UWORLD/crypto/ecc/secp256k1"
+)
+
+// KeyCoefficient returns the MuSig coefficient of the public key in the
+// key set, Hash(Hash(keys) || key) mod N. Weighting every key with its
+// coefficient before the keys are added stops a signer from choosing a
+// key that cancels the keys of the others.
+func KeyCoefficient(keySet [][]byte, pubKey []byte) *big.Int {
+	setInput := make([]byte, 0, len(keySet)*33)
+	for _, key := range keySet {
+		setInput = append(setInput, key...)
+	}
+	hashInput := append(chainhash.HashB(setInput), pubKey...)
+	coefficient := new(big.Int).SetBytes(chainhash.HashB(hashInput))
+	return coefficient.Mod(coefficient, secp256k1.S256().N)
+}
+
+// CombinePubkeysMuSig combines the public keys of the signers, which
+// must be members of the key set, into the key a MuSig signature of the
+// signers is verified with.
+func CombinePubkeysMuSig(keySet [][]byte, signers [][]byte) (*secp256k1.PublicKey, error) {
+	curve := secp256k1.S256()
+	pks := make([]*secp256k1.PublicKey, 0, len(signers))
+	for _, signer := range signers {
+		pk, err := secp256k1.ParsePubKey(signer)
+		if err != nil {
+			return nil, err
+		}
+		coefficient := KeyCoefficient(keySet, signer)
+		x, y := curve.ScalarMult(pk.GetX(), pk.GetY(), coefficient.Bytes())
+		pks = append(pks, secp256k1.NewPublicKey(x, y))
+	}
+	pkSum := CombinePubkeys(pks)
+	if pkSum == nil {
+		str := "combined public key is invalid"
+		return nil, schnorrError(ErrInputValue, str)
+	}
+	return pkSum, nil
+}
+
+// MuSigPrivkey returns the private key weighted with the coefficient of
+// its public key in the key set, a partial signature made with it adds up
+// to a signature of the key of CombinePubkeysMuSig.
+func MuSigPrivkey(keySet [][]byte, priv *secp256k1.PrivateKey, pubKey []byte) *secp256k1.PrivateKey {
+	d := new(big.Int).Mul(priv.GetD(), KeyCoefficient(keySet, pubKey))
+	return secp256k1.NewPrivateKey(d.Mod(d, secp256k1.S256().N))
+}
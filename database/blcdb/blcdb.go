@@ -1,6 +1,7 @@
 package blcdb
 
 import (
+	"encoding/binary"
 	"fmt"
 	"github.com/uworldao/UWORLD/common/encode/rlp"
 	"github.com/uworldao/UWORLD/common/hasharry"
@@ -20,8 +21,24 @@ const (
 	consensusRoot     = "consensusRoot"
 	historyConfirmed  = "historyConfirmed"
 	termLastHash      = "termLastHash"
+	commitJournal     = "commitJournal"
+	sideBlock         = "sideBlock"
+	sideHeight        = "sideHeight"
+	addrTxBucket      = "addrTx"
+	addrSeqBucket     = "addrSeq"
+	addrCountBucket   = "addrCount"
+	addrIndexHeight   = "addrIndexHeight"
+	receiptBucket     = "receiptBucket"
 )
 
+// The block being committed, it is written before the state tries
+// are committed and removed together with the block data. If it is
+// found at startup, the last commit was interrupted.
+type journal struct {
+	Height uint64
+	Hash   hasharry.Hash
+}
+
 type BlockChainStorage struct {
 	db *leveldb.Base
 }
@@ -43,8 +60,59 @@ func (b *BlockChainStorage) Close() error {
 }
 
 func (b *BlockChainStorage) initBucket() error {
+	if err := b.migrateSideBlocks(); err != nil {
+		return err
+	}
+	return b.dropAddrTxIndex()
+}
 
-	return nil
+// The address index was keyed by height without a counter, it is
+// dropped and built again when the index is enabled
+func (b *BlockChainStorage) dropAddrTxIndex() error {
+	iter := b.db.Iterator([]byte(addrTxBucket + "-"))
+	defer iter.Release()
+
+	batch := b.db.NewBatch()
+	for iter.Next() {
+		key := make([]byte, len(iter.Key()))
+		copy(key, iter.Key())
+		batch.Delete(key)
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	batch.Delete([]byte(addrIndexHeight))
+	return batch.Write()
+}
+
+// Side blocks were keyed by hash with the height as the value, they are
+// moved to the keys ordered by height
+func (b *BlockChainStorage) migrateSideBlocks() error {
+	batch := b.db.NewBatch()
+	for key, value := range b.db.Foreach(sideBlock) {
+		height, err := strconv.ParseUint(string(value), 10, 64)
+		if err == nil {
+			hash := hasharry.BytesToHash([]byte(key)[len(sideBlock)+1:])
+			batch.Put(sideBlockKey(height, hash), []byte{})
+		}
+		batch.Delete([]byte(key))
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	return batch.Write()
+}
+
+// The key of a side block is the big endian height followed by
+// the hash, so the side blocks below a height are a range
+func sideBlockKey(height uint64, hash hasharry.Hash) []byte {
+	key := make([]byte, 8+hasharry.HashLength)
+	binary.BigEndian.PutUint64(key, height)
+	copy(key[8:], hash.Bytes())
+	return leveldb.GetKey(sideHeight, key)
 }
 
 func (b *BlockChainStorage) GetHeaderByHeight(height uint64) (*types.Header, error) {
@@ -219,3 +287,331 @@ func (b *BlockChainStorage) UpdateTermLastHash(term uint64, hash hasharry.Hash)
 	key := leveldb.GetKey(termLastHash, bytes)
 	b.db.UpdateValue(key, hash.Bytes())
 }
+
+func (b *BlockChainStorage) GetCommitJournal() (uint64, hasharry.Hash, error) {
+	bytes, err := b.db.GetValue([]byte(commitJournal))
+	if err != nil {
+		return 0, hasharry.Hash{}, err
+	}
+	j := new(journal)
+	if err := rlp.DecodeBytes(bytes, j); err != nil {
+		return 0, hasharry.Hash{}, err
+	}
+	return j.Height, j.Hash, nil
+}
+
+func (b *BlockChainStorage) UpdateCommitJournal(height uint64, hash hasharry.Hash) error {
+	bytes, err := rlp.EncodeToBytes(&journal{Height: height, Hash: hash})
+	if err != nil {
+		return err
+	}
+	batch := b.db.NewBatch()
+	batch.Put([]byte(commitJournal), bytes)
+	return batch.Write()
+}
+
+func (b *BlockChainStorage) DeleteCommitJournal() error {
+	batch := b.db.NewBatch()
+	batch.Delete([]byte(commitJournal))
+	return batch.Write()
+}
+
+// GetReceipts gets the receipts of the transactions of the block
+func (b *BlockChainStorage) GetReceipts(blockHash hasharry.Hash) (types.Receipts, error) {
+	bytes, err := b.db.GetValue(leveldb.GetKey(receiptBucket, blockHash.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	return types.DecodeReceipts(bytes)
+}
+
+// CommitBlock stores the block, its receipts, its indexes, the new trie roots
+// and the last height in one atomic write, and clears the commit journal.
+// The address index entries are added unless addrTxs is nil, the side
+// blocks that are not above the confirmed height are pruned.
+func (b *BlockChainStorage) CommitBlock(block *types.Block, receipts types.Receipts, confirmedHeight uint64, addrTxs []*types.AddressTx, stateRoot, contractRoot, consensusRoot hasharry.Hash) error {
+	batch := b.db.NewBatch()
+
+	headerBytes, err := rlp.EncodeToBytes(block.Header)
+	if err != nil {
+		return err
+	}
+	batch.Put(leveldb.GetKey(headerBucket, block.Hash.Bytes()), headerBytes)
+
+	txsBytes, err := rlp.EncodeToBytes(block.Body.TranslateToRlpBody().Transactions)
+	if err != nil {
+		return err
+	}
+	batch.Put(leveldb.GetKey(transactionBucket, block.TxRoot.Bytes()), txsBytes)
+
+	for hash, loc := range block.GetTxsLocations() {
+		locBytes, err := rlp.EncodeToBytes(loc)
+		if err != nil {
+			return err
+		}
+		batch.Put(leveldb.GetKey(locationBucket, hash.Bytes()), locBytes)
+	}
+
+	if receipts != nil {
+		batch.Put(leveldb.GetKey(receiptBucket, block.Hash.Bytes()), receipts.Bytes())
+	}
+
+	heightBytes := []byte(strconv.FormatUint(block.Height, 10))
+	batch.Put(leveldb.GetKey(heightHash, heightBytes), block.Hash.Bytes())
+	batch.Put(leveldb.GetKey(historyConfirmed, heightBytes), []byte(strconv.FormatUint(confirmedHeight, 10)))
+	batch.Put(leveldb.GetKey(termLastHash, []byte(strconv.FormatUint(block.Term, 10))), block.Hash.Bytes())
+
+	if addrTxs != nil {
+		b.putAddressTxs(batch, block.Height, addrTxs)
+	}
+	putTip(batch, block.Height, stateRoot, contractRoot, consensusRoot)
+	batch.Delete(sideBlockKey(block.Height, block.Hash))
+	if err := b.pruneSideBlocks(batch, confirmedHeight); err != nil {
+		return err
+	}
+	batch.Delete([]byte(commitJournal))
+	return batch.Write()
+}
+
+// UpdateSideBlock stores a block of a competing branch by hash,
+// it is not reachable by height until the branch becomes canonical
+func (b *BlockChainStorage) UpdateSideBlock(block *types.Block) error {
+	batch := b.db.NewBatch()
+	if err := putSideBlock(batch, block); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// RevertTip moves the tip back to the fork block. The reverted blocks are
+// kept as side blocks and their height and transaction indexes are removed,
+// the address index entries are removed unless addrTxs is nil.
+func (b *BlockChainStorage) RevertTip(fork *types.Header, reverted []*types.Block, addrTxs []*types.AddressTx, stateRoot, contractRoot, consensusRoot hasharry.Hash) error {
+	batch := b.db.NewBatch()
+	if addrTxs != nil {
+		b.deleteAddressTxs(batch, fork.Height, addrTxs)
+	}
+	for _, block := range reverted {
+		heightBytes := []byte(strconv.FormatUint(block.Height, 10))
+		batch.Delete(leveldb.GetKey(heightHash, heightBytes))
+		batch.Delete(leveldb.GetKey(historyConfirmed, heightBytes))
+		for hash, _ := range block.GetTxsLocations() {
+			batch.Delete(leveldb.GetKey(locationBucket, hash.Bytes()))
+		}
+
+		termKey := leveldb.GetKey(termLastHash, []byte(strconv.FormatUint(block.Term, 10)))
+		if block.Term == fork.Term {
+			batch.Put(termKey, fork.Hash.Bytes())
+		} else if block.Term > fork.Term {
+			batch.Delete(termKey)
+		}
+		if err := putSideBlock(batch, block); err != nil {
+			return err
+		}
+	}
+	putTip(batch, fork.Height, stateRoot, contractRoot, consensusRoot)
+	return batch.Write()
+}
+
+// Delete the side blocks that can no longer become canonical
+// because they are not above the confirmed height
+func (b *BlockChainStorage) pruneSideBlocks(batch *leveldb.Batch, confirmedHeight uint64) error {
+	prefix := []byte(sideHeight + "-")
+	iter := b.db.Iterator(prefix)
+	defer iter.Release()
+
+	for iter.Next() {
+		key := iter.Key()[len(prefix):]
+		height := binary.BigEndian.Uint64(key[:8])
+		if height > confirmedHeight {
+			break
+		}
+		hash := hasharry.BytesToHash(key[8:])
+		if header, err := b.GetHeader(hash); err == nil {
+			// Transactions are stored by tx root, keep them if the canonical block has the same
+			if canonical, err := b.GetHeaderByHeight(height); err != nil || !canonical.TxRoot.IsEqual(header.TxRoot) {
+				batch.Delete(leveldb.GetKey(transactionBucket, header.TxRoot.Bytes()))
+			}
+			batch.Delete(leveldb.GetKey(headerBucket, hash.Bytes()))
+		}
+		batch.Delete(leveldb.GetKey(receiptBucket, hash.Bytes()))
+		batch.Delete(sideBlockKey(height, hash))
+	}
+	return iter.Error()
+}
+
+func putSideBlock(batch *leveldb.Batch, block *types.Block) error {
+	headerBytes, err := rlp.EncodeToBytes(block.Header)
+	if err != nil {
+		return err
+	}
+	batch.Put(leveldb.GetKey(headerBucket, block.Hash.Bytes()), headerBytes)
+
+	txsBytes, err := rlp.EncodeToBytes(block.Body.TranslateToRlpBody().Transactions)
+	if err != nil {
+		return err
+	}
+	batch.Put(leveldb.GetKey(transactionBucket, block.TxRoot.Bytes()), txsBytes)
+	batch.Put(sideBlockKey(block.Height, block.Hash), []byte{})
+	return nil
+}
+
+// ImportHeaders stores headers without their bodies together with the
+// height and term indexes, the tip is not changed
+func (b *BlockChainStorage) ImportHeaders(headers []*types.Header) error {
+	batch := b.db.NewBatch()
+	for _, header := range headers {
+		headerBytes, err := rlp.EncodeToBytes(header)
+		if err != nil {
+			return err
+		}
+		batch.Put(leveldb.GetKey(headerBucket, header.Hash.Bytes()), headerBytes)
+		batch.Put(leveldb.GetKey(heightHash, []byte(strconv.FormatUint(header.Height, 10))), header.Hash.Bytes())
+		batch.Put(leveldb.GetKey(termLastHash, []byte(strconv.FormatUint(header.Term, 10))), header.Hash.Bytes())
+	}
+	return batch.Write()
+}
+
+// UpdateTip atomically sets the last height and the trie roots of that height
+func (b *BlockChainStorage) UpdateTip(height uint64, stateRoot, contractRoot, consensusRoot hasharry.Hash) error {
+	batch := b.db.NewBatch()
+	putTip(batch, height, stateRoot, contractRoot, consensusRoot)
+	return batch.Write()
+}
+
+func putTip(batch *leveldb.Batch, height uint64, stateHash, contractHash, consensusHash hasharry.Hash) {
+	batch.Put([]byte(stateRoot), stateHash.Bytes())
+	batch.Put([]byte(contractRoot), contractHash.Bytes())
+	batch.Put([]byte(consensusRoot), consensusHash.Bytes())
+	batch.Put([]byte(lastHeight), []byte(strconv.FormatUint(height, 10)))
+}
+
+// The entries of an address are numbered from 0 in the order they are
+// added, the key is the address followed by the big endian number. The
+// number of entries of the address is kept under its own key.
+func addrSeqKey(address hasharry.Address, seq uint64) []byte {
+	key := make([]byte, hasharry.AddressLength+8)
+	copy(key, address.Bytes())
+	binary.BigEndian.PutUint64(key[hasharry.AddressLength:], seq)
+	return leveldb.GetKey(addrSeqBucket, key)
+}
+
+func addrCountKey(address hasharry.Address) []byte {
+	return leveldb.GetKey(addrCountBucket, address.Bytes())
+}
+
+// The value of an entry is the transaction hash, the big endian height and index
+func encodeAddrTx(addrTx *types.AddressTx) []byte {
+	value := make([]byte, hasharry.HashLength+12)
+	copy(value, addrTx.TxHash.Bytes())
+	binary.BigEndian.PutUint64(value[hasharry.HashLength:], addrTx.Height)
+	binary.BigEndian.PutUint32(value[hasharry.HashLength+8:], addrTx.TxIndex)
+	return value
+}
+
+func decodeAddrTx(address hasharry.Address, value []byte) (*types.AddressTx, error) {
+	if len(value) != hasharry.HashLength+12 {
+		return nil, fmt.Errorf("wrong address index entry of %s", address.String())
+	}
+	return &types.AddressTx{
+		Address: address,
+		TxHash:  hasharry.BytesToHash(value[:hasharry.HashLength]),
+		Height:  binary.BigEndian.Uint64(value[hasharry.HashLength:]),
+		TxIndex: binary.BigEndian.Uint32(value[hasharry.HashLength+8:]),
+	}, nil
+}
+
+func (b *BlockChainStorage) getAddrTxCount(address hasharry.Address) uint64 {
+	bytes, err := b.db.GetValue(addrCountKey(address))
+	if err != nil || len(bytes) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bytes)
+}
+
+func putAddrTxCount(batch *leveldb.Batch, address hasharry.Address, count uint64) {
+	if count == 0 {
+		batch.Delete(addrCountKey(address))
+		return
+	}
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, count)
+	batch.Put(addrCountKey(address), bytes)
+}
+
+// Height of the last block in the address index
+func (b *BlockChainStorage) GetAddrIndexHeight() (uint64, error) {
+	bytes, err := b.db.GetValue([]byte(addrIndexHeight))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(string(bytes), 10, 64)
+}
+
+// UpdateAddressTxs adds the index entries of the block at the height
+func (b *BlockChainStorage) UpdateAddressTxs(height uint64, addrTxs []*types.AddressTx) error {
+	batch := b.db.NewBatch()
+	b.putAddressTxs(batch, height, addrTxs)
+	return batch.Write()
+}
+
+func (b *BlockChainStorage) putAddressTxs(batch *leveldb.Batch, height uint64, addrTxs []*types.AddressTx) {
+	counts := make(map[hasharry.Address]uint64)
+	for _, addrTx := range addrTxs {
+		count, ok := counts[addrTx.Address]
+		if !ok {
+			count = b.getAddrTxCount(addrTx.Address)
+		}
+		batch.Put(addrSeqKey(addrTx.Address, count), encodeAddrTx(addrTx))
+		counts[addrTx.Address] = count + 1
+	}
+	for address, count := range counts {
+		putAddrTxCount(batch, address, count)
+	}
+	batch.Put([]byte(addrIndexHeight), []byte(strconv.FormatUint(height, 10)))
+}
+
+// Remove the index entries of reverted blocks, height is the last block
+// that stays in the index. The reverted entries are the last ones of
+// their addresses.
+func (b *BlockChainStorage) deleteAddressTxs(batch *leveldb.Batch, height uint64, addrTxs []*types.AddressTx) {
+	counts := make(map[hasharry.Address]uint64)
+	for _, addrTx := range addrTxs {
+		count, ok := counts[addrTx.Address]
+		if !ok {
+			count = b.getAddrTxCount(addrTx.Address)
+		}
+		if count == 0 {
+			continue
+		}
+		batch.Delete(addrSeqKey(addrTx.Address, count-1))
+		counts[addrTx.Address] = count - 1
+	}
+	for address, count := range counts {
+		putAddrTxCount(batch, address, count)
+	}
+	batch.Put([]byte(addrIndexHeight), []byte(strconv.FormatUint(height, 10)))
+}
+
+// GetAddressTxs returns count entries of the address starting from the
+// start newest one, and the total number of entries of the address
+func (b *BlockChainStorage) GetAddressTxs(address hasharry.Address, start, count uint64) ([]*types.AddressTx, uint64, error) {
+	addrTxs := make([]*types.AddressTx, 0)
+	total := b.getAddrTxCount(address)
+	if start >= total || count == 0 {
+		return addrTxs, total, nil
+	}
+	prefix := leveldb.GetKey(addrSeqBucket, address.Bytes())
+	iter := b.db.Iterator(prefix)
+	defer iter.Release()
+
+	// The start newest entry has the number total-1-start
+	for ok := iter.Seek(addrSeqKey(address, total-1-start)); ok && uint64(len(addrTxs)) < count; ok = iter.Prev() {
+		addrTx, err := decodeAddrTx(address, iter.Value())
+		if err != nil {
+			return nil, 0, err
+		}
+		addrTxs = append(addrTxs, addrTx)
+	}
+	return addrTxs, total, iter.Error()
+}
@@ -1,22 +1,41 @@
 package contractdb
 
 import (
+	"bytes"
+	"errors"
 	"github.com/uworldao/UWORLD/common/codec"
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
+	"github.com/uworldao/UWORLD/core/types/contractv2/token"
 	"github.com/uworldao/UWORLD/database/triedb"
 	"github.com/uworldao/UWORLD/trie"
 )
 
+var ErrReadOnly = errors.New("read only contract state can not be committed")
+
 type ContractStorage struct {
 	trieDB       *triedb.TrieDB
 	contractTrie *trie.Trie
+
+	// Opened by CopyAt, shares the database with its origin
+	readOnly bool
 }
 
 func NewContractStorage(path string) *ContractStorage {
 	trieDB := triedb.NewTrieDB(path)
-	return &ContractStorage{trieDB, nil}
+	return &ContractStorage{trieDB, nil, false}
+}
+
+// Open a read-only storage at the contract root
+func (c *ContractStorage) CopyAt(contractRoot hasharry.Hash) (*ContractStorage, error) {
+	contractTrie, err := trie.New(contractRoot, c.trieDB)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractStorage{c.trieDB, contractTrie, true}, nil
 }
 
 func (c *ContractStorage) InitTrie(contractRoot hasharry.Hash) error {
@@ -29,21 +48,56 @@ func (c *ContractStorage) InitTrie(contractRoot hasharry.Hash) error {
 }
 
 func (c *ContractStorage) Commit() (hasharry.Hash, error) {
-	return c.contractTrie.Commit()
+	if c.readOnly {
+		return hasharry.Hash{}, ErrReadOnly
+	}
+	batch := c.trieDB.NewBatch()
+	root, err := c.contractTrie.CommitTo(batch)
+	if err != nil {
+		return hasharry.Hash{}, err
+	}
+	return root, batch.Write()
 }
 
 func (c *ContractStorage) RootHash() hasharry.Hash {
 	return c.contractTrie.Hash()
 }
 
+// Walk the stored nodes of the contract trie of the root
+func (c *ContractStorage) DumpTrie(contractRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error {
+	return trie.DumpTrie(contractRoot, c.trieDB, fn)
+}
+
+// Read the stored trie node of the hash
+func (c *ContractStorage) TrieNode(hash hasharry.Hash) ([]byte, error) {
+	return c.trieDB.Get(hash.Bytes())
+}
+
+// Store the contract trie of the root with the nodes from fn
+func (c *ContractStorage) SyncTrie(contractRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error {
+	return trie.SyncTrie(contractRoot, c.trieDB, fn)
+}
+
 func (c *ContractStorage) Open() error {
 	return c.trieDB.Open()
 }
 
 func (c *ContractStorage) Close() error {
+	if c.readOnly {
+		return nil
+	}
 	return c.trieDB.Close()
 }
 
+// Get the value of the key and the trie nodes proving it against the root
+func (c *ContractStorage) Prove(key []byte) ([]byte, [][]byte, error) {
+	var proof trie.ProofList
+	if err := c.contractTrie.Prove(key, 0, &proof); err != nil {
+		return nil, nil, err
+	}
+	return c.contractTrie.Get(key), proof, nil
+}
+
 func (c *ContractStorage) GetContract(contractAddr string) *types.Contract {
 	contract := types.NewContract()
 	bytes := c.contractTrie.Get([]byte(contractAddr))
@@ -81,3 +135,114 @@ func (c *ContractStorage) GetContractV2State(txHash string) *types.ContractV2Sta
 	cs, _ := types.DecodeContractV2State(bytes)
 	return cs
 }
+
+func (c *ContractStorage) GetAllowance(address, owner, spender hasharry.Address) uint64 {
+	bytes := c.contractTrie.Get(token.AllowanceTrieKey(address, owner, spender))
+	if len(bytes) != 8 {
+		return 0
+	}
+	return codec.BytesToUint64(bytes)
+}
+
+func (c *ContractStorage) SetAllowance(address, owner, spender hasharry.Address, amount uint64) {
+	if amount == 0 {
+		c.contractTrie.Delete(token.AllowanceTrieKey(address, owner, spender))
+		return
+	}
+	c.contractTrie.Update(token.AllowanceTrieKey(address, owner, spender), codec.Uint64toBytes(amount))
+}
+
+func (c *ContractStorage) GetNFT(collection hasharry.Address, id uint64) *nft.Token {
+	bytes := c.contractTrie.Get(nft.TokenTrieKey(collection, id))
+	if len(bytes) == 0 {
+		return nil
+	}
+	token, _ := nft.DecodeToToken(bytes)
+	return token
+}
+
+func (c *ContractStorage) SetNFT(token *nft.Token) {
+	c.contractTrie.Update(nft.TokenTrieKey(token.Collection, token.Id), token.Bytes())
+}
+
+func (c *ContractStorage) DeleteNFT(collection hasharry.Address, id uint64) {
+	c.contractTrie.Delete(nft.TokenTrieKey(collection, id))
+}
+
+// Tokens of the collection with ids greater than start
+func (c *ContractStorage) GetCollectionNFTs(collection hasharry.Address, start, count uint64) []*nft.Token {
+	prefix := nft.TokenTriePrefix(collection)
+	tokens := make([]*nft.Token, 0)
+	it := trie.NewIterator(c.contractTrie.NodeIterator(nft.TokenTrieKey(collection, start+1)))
+	for uint64(len(tokens)) < count && it.Next() {
+		if !bytes.HasPrefix(it.Key, prefix) {
+			break
+		}
+		if token, err := nft.DecodeToToken(it.Value); err == nil {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+func (c *ContractStorage) AddOwnerNFT(owner hasharry.Address, key nft.TokenKey) {
+	ownerKey := nft.OwnerTokenTrieKey(owner, key)
+	if len(c.contractTrie.Get(ownerKey)) != 0 {
+		return
+	}
+	c.contractTrie.Update(ownerKey, key.Bytes())
+	c.setOwnerNFTCount(owner, c.GetOwnerNFTCount(owner)+1)
+}
+
+func (c *ContractStorage) RemoveOwnerNFT(owner hasharry.Address, key nft.TokenKey) {
+	ownerKey := nft.OwnerTokenTrieKey(owner, key)
+	if len(c.contractTrie.Get(ownerKey)) == 0 {
+		return
+	}
+	c.contractTrie.Delete(ownerKey)
+	c.setOwnerNFTCount(owner, c.GetOwnerNFTCount(owner)-1)
+}
+
+func (c *ContractStorage) GetOwnerNFTCount(owner hasharry.Address) uint64 {
+	bytes := c.contractTrie.Get(nft.OwnerCountTrieKey(owner))
+	if len(bytes) != 8 {
+		return 0
+	}
+	return codec.BytesToUint64(bytes)
+}
+
+func (c *ContractStorage) setOwnerNFTCount(owner hasharry.Address, count uint64) {
+	if count == 0 {
+		c.contractTrie.Delete(nft.OwnerCountTrieKey(owner))
+		return
+	}
+	c.contractTrie.Update(nft.OwnerCountTrieKey(owner), codec.Uint64toBytes(count))
+}
+
+// Keys of the tokens of the owner from the index start
+func (c *ContractStorage) GetOwnerNFTs(owner hasharry.Address, start, count uint64) []nft.TokenKey {
+	keys := make([]nft.TokenKey, 0)
+	it := trie.NewIterator(c.contractTrie.PrefixIterator(nft.OwnerTriePrefix(owner)))
+	for i := uint64(0); uint64(len(keys)) < count && it.Next(); i++ {
+		if i < start {
+			continue
+		}
+		if key, err := nft.DecodeToTokenKey(it.Value); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (c *ContractStorage) GetHashLockSwaps(hashLock hasharry.Hash) htlc.HashLockSwaps {
+	bytes := c.contractTrie.Get(htlc.HashLockTrieKey(hashLock))
+	if len(bytes) == 0 {
+		return htlc.HashLockSwaps{}
+	}
+	swaps, _ := htlc.DecodeToHashLockSwaps(bytes)
+	return swaps
+}
+
+func (c *ContractStorage) SetHashLockSwaps(hashLock hasharry.Hash, swaps htlc.HashLockSwaps) {
+	c.contractTrie.Update(htlc.HashLockTrieKey(hashLock), swaps.Bytes())
+}
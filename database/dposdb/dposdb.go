@@ -2,13 +2,13 @@ package dposdb
 
 import (
 	"bytes"
+	"errors"
 	"github.com/uworldao/UWORLD/common/encode/rlp"
 	hash2 "github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/crypto/hash"
 	"github.com/uworldao/UWORLD/database/triedb"
 	"github.com/uworldao/UWORLD/trie"
-	"strconv"
 )
 
 const (
@@ -35,13 +35,33 @@ func (c *DPosStorage) InitTrie(contractRoot hash2.Hash) error {
 }
 
 func (c *DPosStorage) Commit() (hash2.Hash, error) {
-	return c.dposTrie.Commit()
+	batch := c.trieDB.NewBatch()
+	root, err := c.dposTrie.CommitTo(batch)
+	if err != nil {
+		return hash2.Hash{}, err
+	}
+	return root, batch.Write()
 }
 
 func (c *DPosStorage) RootHash() hash2.Hash {
 	return c.dposTrie.Hash()
 }
 
+// Walk the stored nodes of the dpos trie of the root
+func (c *DPosStorage) DumpTrie(consensusRoot hash2.Hash, fn func(hash hash2.Hash, node []byte) error) error {
+	return trie.DumpTrie(consensusRoot, c.trieDB, fn)
+}
+
+// Read the stored trie node of the hash
+func (c *DPosStorage) TrieNode(hash hash2.Hash) ([]byte, error) {
+	return c.trieDB.Get(hash.Bytes())
+}
+
+// Store the dpos trie of the root with the nodes from fn
+func (c *DPosStorage) SyncTrie(consensusRoot hash2.Hash, fn func(hash hash2.Hash) ([]byte, error)) error {
+	return trie.SyncTrie(consensusRoot, c.trieDB, fn)
+}
+
 func (c *DPosStorage) Open() error {
 	if err := c.trieDB.Open(); err != nil {
 		return err
@@ -208,7 +228,11 @@ func (dps *DPosStorage) SetConfirmedBlockHash(hash hash2.Hash) {
 func (dps *DPosStorage) GetTermWinnerMintCnt(term uint64, address hash2.Address) (uint64, error) {
 	hash := termWinnerMintCntHash(term, address).Bytes()
 	bytes := dps.dposTrie.Get(hash)
-	return strconv.ParseUint(string(bytes), 10, 64)
+	var cnt uint64
+	if err := rlp.DecodeBytes(bytes, &cnt); err != nil {
+		return 0, err
+	}
+	return cnt, nil
 }
 func (dps *DPosStorage) SetTermWinnerMintCnt(term uint64, address hash2.Address) {
 	hash := termWinnerMintCntHash(term, address).Bytes()
@@ -217,10 +241,269 @@ func (dps *DPosStorage) SetTermWinnerMintCnt(term uint64, address hash2.Address)
 		cnt = 0
 	}
 	cnt++
-	dps.dposTrie.Update(hash, []byte(strconv.FormatUint(cnt, 10)))
+	dps.dposTrie.Update(hash, uint64Bytes(cnt))
+}
+
+// The RLP encoding of the numbers stored in the trie and of the
+// numbers in their keys
+func uint64Bytes(value uint64) []byte {
+	bytes, _ := rlp.EncodeToBytes(value)
+	return bytes
 }
 
 func termWinnerMintCntHash(term uint64, address hash2.Address) hash2.Hash {
-	bytes := bytes.Join([][]byte{[]byte(strconv.FormatUint(term, 10)), address.Bytes()}, []byte{})
+	bytes := bytes.Join([][]byte{uint64Bytes(term), address.Bytes()}, []byte{})
+	return hash.Hash(bytes)
+}
+
+// The term whose blocks are counted and its winners, the winners are
+// empty for the term the counting started in
+type MintTerm struct {
+	Term    uint64
+	Winners []hash2.Address
+}
+
+func mintTermHash() hash2.Hash {
+	return hash.Hash([]byte("mint term"))
+}
+
+func (dps *DPosStorage) GetMintTerm() (*MintTerm, error) {
+	var mintTerm *MintTerm
+	bytes := dps.dposTrie.Get(mintTermHash().Bytes())
+	if err := rlp.DecodeBytes(bytes, &mintTerm); err != nil {
+		return nil, err
+	}
+	return mintTerm, nil
+}
+
+func (dps *DPosStorage) SetMintTerm(mintTerm *MintTerm) error {
+	bytes, err := rlp.EncodeToBytes(mintTerm)
+	if err != nil {
+		return err
+	}
+	dps.dposTrie.Update(mintTermHash().Bytes(), bytes)
+	return nil
+}
+
+func jailHash(address hash2.Address) hash2.Hash {
+	return hash.Hash(append([]byte("jail"), address.Bytes()...))
+}
+
+// The first term the address can be elected in again, 0 if it
+// has never been jailed
+func (dps *DPosStorage) GetJailedUntil(address hash2.Address) uint64 {
+	bytes := dps.dposTrie.Get(jailHash(address).Bytes())
+	var value uint64
+	if err := rlp.DecodeBytes(bytes, &value); err != nil {
+		return 0
+	}
+	return value
+}
+
+// A later term does not shorten the jail of the address
+func (dps *DPosStorage) SetJailedUntil(address hash2.Address, term uint64) {
+	if term <= dps.GetJailedUntil(address) {
+		return
+	}
+	dps.dposTrie.Update(jailHash(address).Bytes(), uint64Bytes(term))
+}
+
+func doubleSignHash(address hash2.Address, height uint64) hash2.Hash {
+	bytes := bytes.Join([][]byte{[]byte("double sign"), address.Bytes(), uint64Bytes(height)}, []byte{})
 	return hash.Hash(bytes)
 }
+
+// Whether the evidence of the signer at the height has been submitted
+func (dps *DPosStorage) HasDoubleSign(address hash2.Address, height uint64) bool {
+	return len(dps.dposTrie.Get(doubleSignHash(address, height).Bytes())) != 0
+}
+
+func (dps *DPosStorage) SetDoubleSign(address hash2.Address, height uint64) {
+	dps.dposTrie.Update(doubleSignHash(address, height).Bytes(), []byte{1})
+}
+
+// Commission rate of a candidate, Next applies from the term From
+type Commission struct {
+	Rate uint64
+	Next uint64
+	From uint64
+}
+
+func commissionHash(address hash2.Address) hash2.Hash {
+	return hash.Hash(append([]byte("commission"), address.Bytes()...))
+}
+
+func (dps *DPosStorage) getCommission(address hash2.Address) (*Commission, error) {
+	var commission *Commission
+	bytes := dps.dposTrie.Get(commissionHash(address).Bytes())
+	if err := rlp.DecodeBytes(bytes, &commission); err != nil {
+		return nil, err
+	}
+	return commission, nil
+}
+
+// The commission rate of the address in the term, the whole
+// reward if it has not set one
+func (dps *DPosStorage) GetCommission(address hash2.Address, term uint64) uint64 {
+	commission, err := dps.getCommission(address)
+	if err != nil {
+		return types.MaxCommission
+	}
+	if term >= commission.From {
+		return commission.Next
+	}
+	return commission.Rate
+}
+
+// Change the commission rate of the address from the term after the
+// current one, the rate of the current term is kept
+func (dps *DPosStorage) SetCommission(address hash2.Address, rate, term uint64) error {
+	commission := &Commission{
+		Rate: dps.GetCommission(address, term),
+		Next: rate,
+		From: term + 1,
+	}
+	bytes, err := rlp.EncodeToBytes(commission)
+	if err != nil {
+		return err
+	}
+	dps.dposTrie.Update(commissionHash(address).Bytes(), bytes)
+	return nil
+}
+
+// The shares of the block rewards of the winners in the term, they
+// are paid to the voters after the term ends
+type RewardPool struct {
+	Term   uint64
+	Shares []RewardShare
+}
+
+type RewardShare struct {
+	Winner hash2.Address
+	Amount uint64
+}
+
+func (rp *RewardPool) Add(winner hash2.Address, amount uint64) {
+	for i, share := range rp.Shares {
+		if share.Winner.IsEqual(winner) {
+			rp.Shares[i].Amount += amount
+			return
+		}
+	}
+	rp.Shares = append(rp.Shares, RewardShare{Winner: winner, Amount: amount})
+}
+
+func rewardPoolHash() hash2.Hash {
+	return hash.Hash([]byte("reward pool"))
+}
+
+func (dps *DPosStorage) GetRewardPool() (*RewardPool, error) {
+	var pool *RewardPool
+	bytes := dps.dposTrie.Get(rewardPoolHash().Bytes())
+	if err := rlp.DecodeBytes(bytes, &pool); err != nil {
+		return nil, err
+	}
+	return pool, nil
+}
+
+func (dps *DPosStorage) SetRewardPool(pool *RewardPool) error {
+	bytes, err := rlp.EncodeToBytes(pool)
+	if err != nil {
+		return err
+	}
+	dps.dposTrie.Update(rewardPoolHash().Bytes(), bytes)
+	return nil
+}
+
+func rewardHash(address hash2.Address) hash2.Hash {
+	return hash.Hash(append([]byte("reward"), address.Bytes()...))
+}
+
+// The rewards accrued to the address and not claimed
+func (dps *DPosStorage) GetReward(address hash2.Address) uint64 {
+	bytes := dps.dposTrie.Get(rewardHash(address).Bytes())
+	var value uint64
+	if err := rlp.DecodeBytes(bytes, &value); err != nil {
+		return 0
+	}
+	return value
+}
+
+func (dps *DPosStorage) SetReward(address hash2.Address, reward uint64) {
+	if reward == 0 {
+		dps.dposTrie.Delete(rewardHash(address).Bytes())
+		return
+	}
+	dps.dposTrie.Update(rewardHash(address).Bytes(), uint64Bytes(reward))
+}
+
+// A share of a finished term being paid to the voters of the winner.
+// The coins of the voters are summed first, then the voters are paid,
+// both a few voters in a block.
+type RewardPayout struct {
+	Winner hash2.Address
+	Term   uint64
+	Amount uint64
+	// Number of voters the winner had when the term ended, the voters
+	// are stored by SetTermVoters
+	Voters uint64
+	// Number of voters whose coins are in Sum
+	Summed uint64
+	Sum    uint64
+	// Number of voters that have been paid and the amount paid
+	Rewarded uint64
+	Paid     uint64
+}
+
+func rewardPayoutsHash() hash2.Hash {
+	return hash.Hash([]byte("reward payouts"))
+}
+
+// The shares waiting to be paid, in the order of their terms
+func (dps *DPosStorage) GetRewardPayouts() []*RewardPayout {
+	var payouts []*RewardPayout
+	bytes := dps.dposTrie.Get(rewardPayoutsHash().Bytes())
+	if err := rlp.DecodeBytes(bytes, &payouts); err != nil {
+		return []*RewardPayout{}
+	}
+	return payouts
+}
+
+func (dps *DPosStorage) SetRewardPayouts(payouts []*RewardPayout) error {
+	if len(payouts) == 0 {
+		dps.dposTrie.Delete(rewardPayoutsHash().Bytes())
+		return nil
+	}
+	bytes, err := rlp.EncodeToBytes(payouts)
+	if err != nil {
+		return err
+	}
+	dps.dposTrie.Update(rewardPayoutsHash().Bytes(), bytes)
+	return nil
+}
+
+func termVoterHash(term uint64, winner hash2.Address, index uint64) hash2.Hash {
+	bytes := bytes.Join([][]byte{[]byte("term voter"), uint64Bytes(term), winner.Bytes(), uint64Bytes(index)}, []byte{})
+	return hash.Hash(bytes)
+}
+
+// Store the voters of the winner at the end of the term, each under
+// its own key, and return their number
+func (dps *DPosStorage) SetTermVoters(term uint64, winner hash2.Address, voters []hash2.Address) uint64 {
+	for index, voter := range voters {
+		dps.dposTrie.Update(termVoterHash(term, winner, uint64(index)).Bytes(), voter.Bytes())
+	}
+	return uint64(len(voters))
+}
+
+func (dps *DPosStorage) GetTermVoter(term uint64, winner hash2.Address, index uint64) (hash2.Address, error) {
+	bytes := dps.dposTrie.Get(termVoterHash(term, winner, index).Bytes())
+	if len(bytes) != hash2.AddressLength {
+		return hash2.Address{}, errors.New("term voter does not exist")
+	}
+	return hash2.BytesToAddress(bytes), nil
+}
+
+func (dps *DPosStorage) DeleteTermVoter(term uint64, winner hash2.Address, index uint64) {
+	dps.dposTrie.Delete(termVoterHash(term, winner, index).Bytes())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/btcsuite/goleveldb/leveldb"
+	"github.com/btcsuite/goleveldb/leveldb/iterator"
 	"github.com/btcsuite/goleveldb/leveldb/opt"
 	"github.com/btcsuite/goleveldb/leveldb/util"
 )
@@ -73,6 +74,11 @@ func (b *Base) Foreach(bucket string) map[string][]byte {
 	return rs
 }
 
+// Iterator over the keys with the prefix in key order, it must be released
+func (b *Base) Iterator(prefix []byte) iterator.Iterator {
+	return b.Db.NewIterator(util.BytesPrefix(prefix), nil)
+}
+
 func GetKey(bucket string, key []byte) []byte {
 	return bytes.Join([][]byte{
 		[]byte(bucket + "-"), key}, []byte{})
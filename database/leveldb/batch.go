@@ -0,0 +1,39 @@
+package leveldb
+
+import (
+	"github.com/btcsuite/goleveldb/leveldb"
+	"github.com/btcsuite/goleveldb/leveldb/opt"
+)
+
+// Batch collects writes and deletes and applies them to
+// the database in a single atomic, synced write
+type Batch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+func (b *Base) NewBatch() *Batch {
+	return &Batch{db: b.Db, batch: new(leveldb.Batch)}
+}
+
+// Put implements trie.DatabaseWriter, so trie nodes can be committed in a batch
+func (b *Batch) Put(key, value []byte) error {
+	b.batch.Put(key, value)
+	return nil
+}
+
+func (b *Batch) Delete(key []byte) {
+	b.batch.Delete(key)
+}
+
+func (b *Batch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *Batch) Write() error {
+	return b.db.Write(b.batch, &opt.WriteOptions{Sync: true})
+}
+
+func (b *Batch) Reset() {
+	b.batch.Reset()
+}
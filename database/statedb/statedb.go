@@ -3,6 +3,7 @@ package statedb
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/uworldao/UWORLD/common/encode/rlp"
 	"github.com/uworldao/UWORLD/common/hasharry"
@@ -11,15 +12,30 @@ import (
 	"github.com/uworldao/UWORLD/trie"
 )
 
+var ErrReadOnly = errors.New("read only state can not be committed")
+
 type StateStorage struct {
 	trieDB    *triedb.TrieDB
 	stateTrie *trie.Trie
+
+	// Opened by CopyAt, shares the database with its origin
+	readOnly bool
 }
 
 func NewStateStorage(path string) *StateStorage {
 	trieDB := triedb.NewTrieDB(path)
 
-	return &StateStorage{trieDB, nil}
+	return &StateStorage{trieDB, nil, false}
+}
+
+// Open a read-only storage at the state root, changes made to
+// it stay in memory and never disturb the origin trie
+func (s *StateStorage) CopyAt(stateRoot hasharry.Hash) (*StateStorage, error) {
+	stateTrie, err := trie.New(stateRoot, s.trieDB)
+	if err != nil {
+		return nil, err
+	}
+	return &StateStorage{s.trieDB, stateTrie, true}, nil
 }
 
 func (s *StateStorage) InitTrie(stateRoot hasharry.Hash) error {
@@ -31,11 +47,29 @@ func (s *StateStorage) InitTrie(stateRoot hasharry.Hash) error {
 	return nil
 }
 
+// Walk the stored nodes of the state trie of the root
+func (s *StateStorage) DumpTrie(stateRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error {
+	return trie.DumpTrie(stateRoot, s.trieDB, fn)
+}
+
+// Read the stored trie node of the hash
+func (s *StateStorage) TrieNode(hash hasharry.Hash) ([]byte, error) {
+	return s.trieDB.Get(hash.Bytes())
+}
+
+// Store the state trie of the root with the nodes from fn
+func (s *StateStorage) SyncTrie(stateRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error {
+	return trie.SyncTrie(stateRoot, s.trieDB, fn)
+}
+
 func (s *StateStorage) Open() error {
 	return s.trieDB.Open()
 }
 
 func (s *StateStorage) Close() error {
+	if s.readOnly {
+		return nil
+	}
 	return s.trieDB.Close()
 }
 
@@ -77,12 +111,30 @@ func (s *StateStorage) GetAccountNonce(stateKey hasharry.Address) uint64 {
 	return account.GetNonce()
 }
 
+// Get the encoded account and the trie nodes proving it against the root
+func (s *StateStorage) Prove(stateKey hasharry.Address) ([]byte, [][]byte, error) {
+	var proof trie.ProofList
+	if err := s.stateTrie.Prove(stateKey.Bytes(), 0, &proof); err != nil {
+		return nil, nil, err
+	}
+	return s.stateTrie.Get(stateKey.Bytes()), proof, nil
+}
+
 func (s *StateStorage) DeleteAccount(stateKey hasharry.Address) {
 	s.stateTrie.Delete(stateKey.Bytes())
 }
 
 func (s *StateStorage) Commit() (hasharry.Hash, error) {
-	return s.stateTrie.Commit()
+	if s.readOnly {
+		return hasharry.Hash{}, ErrReadOnly
+	}
+	// Write all nodes in one batch, a crash can not leave part of them
+	batch := s.trieDB.NewBatch()
+	root, err := s.stateTrie.CommitTo(batch)
+	if err != nil {
+		return hasharry.Hash{}, err
+	}
+	return root, batch.Write()
 }
 
 func (s *StateStorage) RootHash() hasharry.Hash {
@@ -46,6 +46,11 @@ func (s *TrieDB) Has(key []byte) (bool, error) {
 	return true, nil
 }
 
+// NewBatch returns a batch whose writes are applied atomically
+func (s *TrieDB) NewBatch() *leveldb.Batch {
+	return s.db.NewBatch()
+}
+
 func (s *TrieDB) CreateBucket(bucket string) error {
 	if err := s.db.CreateBucket(bucket); err != nil {
 		return err
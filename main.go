@@ -41,6 +41,12 @@ func UWDMain() error {
 	if err != nil {
 		return err
 	}
+
+	// Subcommands work on the local chain and exit
+	if config.Command != "" {
+		return node.RunCommand(config)
+	}
+
 	//Initialize the UWD node
 	node, err := node.NewNode(config)
 	if err != nil {
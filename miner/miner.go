@@ -133,7 +133,7 @@ func (miner *Miner) mintingBlock(now uint64) {
 }
 
 func (miner *Miner) generateBlock(header *types.Header) (*types.Block, error) {
-	txs := miner.getTransactions(header.Height)
+	txs := miner.getTransactions(header)
 	header.TxRoot = txs.Hash()
 	header.SetHash()
 	block := types.NewBlock(header, types.NewBody(txs))
@@ -143,18 +143,23 @@ func (miner *Miner) generateBlock(header *types.Header) (*types.Block, error) {
 }
 
 // Get transactions from the transaction pool and generate coinbase transactions
-func (miner *Miner) getTransactions(height uint64) types.Transactions {
+func (miner *Miner) getTransactions(header *types.Header) types.Transactions {
 	txs := miner.txPool.Gets(maxBlockTransactions, maxTransactionsSize)
-	coinBase := miner.getCoinBase(txs, height)
+	coinBase := miner.getCoinBase(txs, header)
 	coinBaseTx := miner.generateCoinBaseTx(coinBase)
 	coinBaseTx.SetHash()
 	txs = append(txs, coinBaseTx)
 	return txs
 }
 
-func (miner *Miner) getCoinBase(txs types.Transactions, height uint64) uint64 {
+func (miner *Miner) getCoinBase(txs types.Transactions, header *types.Header) uint64 {
 	//return types.CalCoinBase(height) + txs.SumFees()
-	return types.CalCoinBase(height, param.CoinHeight)
+	reward := types.CalCoinBase(header.Height, param.CoinHeight)
+	if param.IsActive(param.RewardSharing, header.Height) {
+		// The rest accrues to the voters in the consensus
+		reward = types.CalCommission(reward, miner.consensus.GetCommission(miner.signer, header.Term))
+	}
+	return reward
 }
 
 func (miner *Miner) generateCoinBaseTx(coinBase uint64) types.ITransaction {
@@ -0,0 +1,77 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/config"
+	"github.com/uworldao/UWORLD/consensus"
+	"github.com/uworldao/UWORLD/consensus/devseal"
+	"github.com/uworldao/UWORLD/consensus/dpos"
+	"github.com/uworldao/UWORLD/core"
+	runner2 "github.com/uworldao/UWORLD/core/runner"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+	"github.com/uworldao/UWORLD/services/accountstate"
+	"github.com/uworldao/UWORLD/services/contractstate"
+	"github.com/uworldao/UWORLD/ut"
+)
+
+// States and block chain opened from the data directory
+type chain struct {
+	accountState  *accountstate.AccountState
+	contractState *contractstate.ContractState
+	consensus     consensus.IConsensus
+	runner        *runner2.ContractRunner
+	blockChain    *core.BlockChain
+}
+
+func openChain(cfg *config.Config, sign consensus.ISign, stateUpdateCh chan struct{}, removeTxsCh, revertedTxsCh chan types.Transactions) (*chain, error) {
+	var err error
+	c := &chain{}
+	if c.accountState, err = accountstate.NewAccountState(cfg.DataDir); err != nil {
+		return nil, fmt.Errorf("create account state failed! err:%s", err)
+	}
+
+	if c.contractState, err = contractstate.NewContractState(cfg.DataDir); err != nil {
+		return nil, fmt.Errorf("create contract state failed! err:%s", err)
+	}
+
+	if c.consensus, err = newConsensus(cfg, sign); err != nil {
+		return nil, fmt.Errorf("create %s failed! err:%s", cfg.Consensus, err)
+	}
+
+	c.runner = runner2.NewContractRunner(c.accountState, c.contractState)
+	if c.blockChain, err = core.NewBlockChain(cfg.DataDir, c.consensus, stateUpdateCh, removeTxsCh, revertedTxsCh, c.accountState, c.contractState, c.runner); err != nil {
+		return nil, fmt.Errorf("create block chain failed! err:%s", err)
+	}
+	if cfg.AddrIndex {
+		if err := c.blockChain.EnableAddressIndex(); err != nil {
+			return nil, fmt.Errorf("enable address index failed! err:%s", err)
+		}
+	}
+	return c, nil
+}
+
+// Create the consensus selected by the configuration. Devseal is
+// for development networks, it is refused on the main and the test
+// network.
+func newConsensus(cfg *config.Config, sign consensus.ISign) (consensus.IConsensus, error) {
+	switch cfg.Consensus {
+	case config.DefaultConsensus:
+		return dpos.NewDPos(cfg.DataDir, cfg.NodePrivate.Address, sign)
+	case devseal.Name:
+		if !param.IsCustomGenesis() {
+			return nil, errors.New("devseal can only be used on a custom network, use --genesis")
+		}
+		authority := cfg.NodePrivate.Address
+		if cfg.Authority != "" {
+			if !ut.CheckUWDAddress(param.Net, cfg.Authority) {
+				return nil, fmt.Errorf("wrong authority address %s", cfg.Authority)
+			}
+			authority = hasharry.StringToAddress(cfg.Authority)
+		}
+		return devseal.NewDevSeal(cfg.DataDir, cfg.NodePrivate.Address, authority, sign)
+	}
+	return nil, fmt.Errorf("unknown consensus %s", cfg.Consensus)
+}
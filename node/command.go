@@ -0,0 +1,127 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/config"
+	"github.com/uworldao/UWORLD/core/types"
+	log "github.com/uworldao/UWORLD/log/log15"
+	"github.com/uworldao/UWORLD/param"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Data directory of the chain that executes the blocks again
+const reindexDir = "reindex"
+
+// Directory of the trie nodes of a snapshot that is being restored
+const restoreDir = "restore"
+
+// Signer of the chain opened by a subcommand
+type commandSigner struct {
+	private *config.NodePrivate
+}
+
+func (c *commandSigner) SignHash(hash hasharry.Hash) (*types.SignScript, error) {
+	return types.Sign(c.private.PrivateKey, hash)
+}
+
+// Run the subcommand on the local chain, the network services are
+// not started
+func RunCommand(cfg *config.Config) error {
+	stateUpdateCh := make(chan struct{}, 50)
+	removeTxsCh := make(chan types.Transactions, 100)
+	revertedTxsCh := make(chan types.Transactions, 100)
+
+	// Nobody else receives the notifications of the chain
+	go func() {
+		for range stateUpdateCh {
+		}
+	}()
+	go func() {
+		for range removeTxsCh {
+		}
+	}()
+	go func() {
+		for range revertedTxsCh {
+		}
+	}()
+
+	chain, err := openChain(cfg, &commandSigner{cfg.NodePrivate}, stateUpdateCh, removeTxsCh, revertedTxsCh)
+	if err != nil {
+		return err
+	}
+	defer chain.blockChain.CloseStorage()
+
+	if err := chain.consensus.Init(chain.blockChain); err != nil {
+		return fmt.Errorf("init consensus failed! err:%s", err)
+	}
+
+	switch cfg.Command {
+	case "init":
+		return initGenesis(cfg, chain)
+	case "export":
+		return chain.blockChain.ExportChain(cfg.Export.Out, cfg.Export.From, cfg.Export.To)
+	case "import":
+		return chain.blockChain.ImportChain(cfg.Import.In)
+	case "snapshot":
+		return chain.blockChain.ExportSnapshot(cfg.Snapshot.Out)
+	case "restore":
+		checkpoint, err := hasharry.StringToHash(cfg.Restore.Checkpoint)
+		if err != nil {
+			return fmt.Errorf("wrong checkpoint %s! %s", cfg.Restore.Checkpoint, err.Error())
+		}
+		return chain.blockChain.RestoreSnapshot(cfg.Restore.In, cfg.DataDir+"/"+restoreDir, checkpoint)
+	case "reindex":
+		return reindex(cfg, chain, stateUpdateCh, removeTxsCh, revertedTxsCh)
+	}
+	return fmt.Errorf("unknown command %s", cfg.Command)
+}
+
+// The genesis block is written when the chain is opened, keep a copy
+// of the genesis file so the data directory is started with it
+func initGenesis(cfg *config.Config, chain *chain) error {
+	genesis, err := chain.blockChain.GetHeaderByHeight(0)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(cfg.DataDir, config.GenesisFile)
+	if filepath.Clean(cfg.Genesis) != path {
+		bytes, err := json.MarshalIndent(param.GetGenesis(), "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, bytes, 0644); err != nil {
+			return err
+		}
+	}
+	log.Info("Init genesis completed", "chain id", param.GetGenesis().ChainId, "hash", genesis.Hash.String(), "data", cfg.DataDir)
+	return nil
+}
+
+// Execute the blocks again on a chain in a temporary data directory,
+// which is removed when it is done
+func reindex(cfg *config.Config, chain *chain, stateUpdateCh chan struct{}, removeTxsCh, revertedTxsCh chan types.Transactions) error {
+	replayCfg := *cfg
+	replayCfg.DataDir = cfg.DataDir + "/" + reindexDir
+	replayCfg.AddrIndex = false
+	if err := os.RemoveAll(replayCfg.DataDir); err != nil {
+		return err
+	}
+	replay, err := openChain(&replayCfg, &commandSigner{cfg.NodePrivate}, stateUpdateCh, removeTxsCh, revertedTxsCh)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(replayCfg.DataDir)
+	defer replay.blockChain.CloseStorage()
+
+	if err := replay.consensus.Init(replay.blockChain); err != nil {
+		return fmt.Errorf("init consensus failed! err:%s", err)
+	}
+	if cfg.Reindex.Verify {
+		return chain.blockChain.Replay(replay.blockChain)
+	}
+	return chain.blockChain.Reindex(replay.blockChain)
+}
@@ -7,19 +7,15 @@ import (
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/config"
 	"github.com/uworldao/UWORLD/consensus"
-	"github.com/uworldao/UWORLD/consensus/dpos"
-	"github.com/uworldao/UWORLD/core"
+	"github.com/uworldao/UWORLD/consensus/devseal"
 	"github.com/uworldao/UWORLD/core/interface"
-	runner2 "github.com/uworldao/UWORLD/core/runner"
 	"github.com/uworldao/UWORLD/core/types"
 	log "github.com/uworldao/UWORLD/log/log15"
 	"github.com/uworldao/UWORLD/miner"
 	"github.com/uworldao/UWORLD/p2p"
 	"github.com/uworldao/UWORLD/param"
 	"github.com/uworldao/UWORLD/rpc"
-	"github.com/uworldao/UWORLD/services/accountstate"
 	"github.com/uworldao/UWORLD/services/blkmgr"
-	"github.com/uworldao/UWORLD/services/contractstate"
 	"github.com/uworldao/UWORLD/services/peermgr"
 	"github.com/uworldao/UWORLD/services/reqmgr"
 	"github.com/uworldao/UWORLD/services/txmgr"
@@ -50,34 +46,27 @@ func NewNode(cfg *config.Config) (*Node, error) {
 	minerWorkCh := make(chan bool)
 	stateUpdateChan := make(chan struct{}, 50)
 	removeTxsCh := make(chan types.Transactions, 100)
+	revertedTxsCh := make(chan types.Transactions, 100)
 	secpKey, err := p2pcrypto.UnmarshalSecp256k1PrivateKey(cfg.NodePrivate.PrivateKey.Serialize())
 	node.localNode = p2p.NewPeerInfo(secpKey, &peer.AddrInfo{}, nil)
 	node.peerManager = peermgr.NewPeerManager(node.localNode)
-	accountState, err := accountstate.NewAccountState(cfg.DataDir)
+	chain, err := openChain(cfg, node, stateUpdateChan, removeTxsCh, revertedTxsCh)
 	if err != nil {
-		return nil, fmt.Errorf("create account state failed! err:%s", err)
-	}
-
-	contractState, err := contractstate.NewContractState(cfg.DataDir)
-	if err != nil {
-		return nil, fmt.Errorf("create contract state failed! err:%s", err)
-	}
-
-	if node.consensus, err = dpos.NewDPos(cfg.DataDir, cfg.NodePrivate.Address, node); err != nil {
-		return nil, fmt.Errorf("create dpos failed! err:%s", err)
-	}
-
-	runner := runner2.NewContractRunner(accountState, contractState)
-	if node.blockChain, err = core.NewBlockChain(cfg.DataDir, node.consensus, stateUpdateChan, removeTxsCh, accountState, contractState, runner); err != nil {
-		return nil, fmt.Errorf("create block chain failed! err:%s", err)
+		return nil, err
 	}
+	node.consensus = chain.consensus
+	node.blockChain = chain.blockChain
 	node.network = reqmgr.NewRequestManger(node.blockChain, revBlkCh, revTxCh, node)
 
 	if node.p2pServer, err = p2p.NewP2pServer(cfg, node.localNode, node.peerManager, node.network); err != nil {
 		return nil, fmt.Errorf("create p2p server failed! err:%s", err)
 	}
 
-	node.txPool = txmgr.NewTxPool(cfg, accountState, contractState, node.consensus, node.peerManager, node.network, runner, revTxCh, stateUpdateChan, removeTxsCh, node.p2pServer, node.blockChain.GetLastHeight)
+	node.txPool = txmgr.NewTxPool(cfg, chain.accountState, chain.contractState, node.consensus, node.peerManager, node.network, chain.runner, revTxCh, stateUpdateChan, removeTxsCh, revertedTxsCh, node.p2pServer, node.blockChain.GetLastHeight)
+	if seal, ok := node.consensus.(*devseal.DevSeal); ok {
+		// Transactions in the pool are sealed at once
+		seal.SetTxPool(node.txPool)
+	}
 
 	if err := node.consensus.Init(node.blockChain); err != nil {
 		return nil, fmt.Errorf("init consensus failed! err:%s", err)
@@ -94,7 +83,7 @@ func NewNode(cfg *config.Config) (*Node, error) {
 		RpcCert:  cfg.RpcCert,
 		RpcPass:  cfg.RpcPass,
 	}
-	node.rpcServer = rpc.NewServer(rpcConfig, node.txPool, accountState, contractState, runner, node.consensus, node.blockChain, node.peerManager, node)
+	node.rpcServer = rpc.NewServer(rpcConfig, node.txPool, chain.accountState, chain.contractState, chain.runner, node.consensus, node.blockChain, node.peerManager, node)
 
 	if cfg.FallBackTo != config.DefaultFallBack && cfg.FallBackTo > 0 {
 		if err := node.blockChain.FallBackTo(uint64(cfg.FallBackTo)); err != nil {
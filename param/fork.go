@@ -0,0 +1,77 @@
+package param
+
+import "fmt"
+
+// Fork is a change of the rules of the chain. The new rules apply to
+// the blocks from the activation height of the fork, the blocks before
+// it are still verified by the old rules.
+type Fork string
+
+const (
+	// Transactions signed by m of n keys, as separate signatures or as
+	// one aggregated schnorr signature
+	MultiSig Fork = "multisig"
+	// Fungible token contracts with allowances, burn and owner mint
+	FungibleToken Fork = "fungibletoken"
+	// Collections of non-fungible tokens
+	NonFungibleToken Fork = "nft"
+	// Transfers locked until a height or released over a schedule
+	TimeLock Fork = "timelock"
+	// Hashed time locked swaps with other chains
+	HTLC Fork = "htlc"
+	// Transactions that can not be packed after a height
+	TxExpiry Fork = "txexpiry"
+	// Candidates joining and leaving the election and votes for them
+	Election Fork = "election"
+	// Jailing of the winners that miss blocks and of the signers of
+	// two blocks at the same height, whose bonds are slashed
+	Slashing Fork = "slashing"
+	// Commission rates of the winners, the rest of the block rewards
+	// is shared by the voters
+	RewardSharing Fork = "rewardsharing"
+)
+
+// All forks, a new fork is added here and to the schedules of the
+// networks it is released on
+var forks = []Fork{MultiSig, FungibleToken, NonFungibleToken, TimeLock, HTLC, TxExpiry, Election, Slashing, RewardSharing}
+
+// Activation heights of the forks on the public networks. A fork that
+// is not in the schedule of the network is not active.
+var forkSchedule = map[string]map[Fork]uint64{
+	MainNet: {},
+	TestNet: {},
+}
+
+// IsActive returns whether the rules of the fork apply to the block
+// of the height
+func IsActive(fork Fork, height uint64) bool {
+	activation, ok := ForkHeight(fork)
+	return ok && height >= activation
+}
+
+// ForkHeight returns the activation height of the fork on the current
+// network. A custom network only runs the forks listed in its genesis.
+func ForkHeight(fork Fork) (uint64, bool) {
+	if genesis != nil {
+		height, ok := genesis.Forks[fork]
+		return height, ok
+	}
+	height, ok := forkSchedule[Net][fork]
+	return height, ok
+}
+
+func verifyForks(heights map[Fork]uint64) error {
+	for fork := range heights {
+		known := false
+		for _, f := range forks {
+			if f == fork {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown fork %s", fork)
+		}
+	}
+	return nil
+}
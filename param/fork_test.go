@@ -0,0 +1,38 @@
+package param
+
+import "testing"
+
+func TestIsActive(t *testing.T) {
+	const testFork = Fork("test")
+	oldForks, oldSchedule, oldGenesis := forks, forkSchedule[MainNet], genesis
+	defer func() {
+		forks, forkSchedule[MainNet], genesis = oldForks, oldSchedule, oldGenesis
+	}()
+	forks = append(forks, testFork)
+	genesis = nil
+
+	forkSchedule[MainNet] = map[Fork]uint64{}
+	if IsActive(testFork, 1000) {
+		t.Fatal("fork not in the schedule must not be active")
+	}
+	forkSchedule[MainNet] = map[Fork]uint64{testFork: 100}
+	if IsActive(testFork, 99) || !IsActive(testFork, 100) {
+		t.Fatal("fork must be active from the activation height")
+	}
+
+	genesis = DefaultGenesis()
+	if IsActive(testFork, 1000) {
+		t.Fatal("fork not in a custom genesis must not be active")
+	}
+	genesis.Forks = map[Fork]uint64{testFork: 10}
+	if err := genesis.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if IsActive(testFork, 9) || !IsActive(testFork, 10) {
+		t.Fatal("fork must be active from the height of the genesis")
+	}
+	genesis.Forks = map[Fork]uint64{"unknown": 10}
+	if err := genesis.Verify(); err == nil {
+		t.Fatal("unknown fork must be refused")
+	}
+}
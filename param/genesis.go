@@ -0,0 +1,237 @@
+package param
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// Genesis describes a network, it decides the genesis block and the
+// parameters of the consensus. Nodes with different genesis can not
+// join the same network.
+type Genesis struct {
+	// Network id, nodes only connect to the nodes of the same id
+	ChainId string `json:"chainid" toml:"chainid"`
+	// Time of the genesis block
+	Time uint64 `json:"time" toml:"time"`
+	// Name of the main coin
+	Token string `json:"token" toml:"token"`
+	// Address that receives the fees
+	FeeAddress string `json:"feeaddress" toml:"feeaddress"`
+	// Version bytes of the addresses and the contract addresses in hex
+	AddressPrefix string `json:"addressprefix" toml:"addressprefix"`
+	TokenPrefix   string `json:"tokenprefix" toml:"tokenprefix"`
+	BlockInterval uint64 `json:"blockinterval" toml:"blockinterval"`
+	// Seconds between two elections
+	TermInterval  uint64 `json:"terminterval" toml:"terminterval"`
+	MaxWinnerSize int    `json:"maxwinnersize" toml:"maxwinnersize"`
+	// Fees of a transaction and consumption of a new coin
+	Fees             uint64 `json:"fees" toml:"fees"`
+	TokenConsumption uint64 `json:"tokenconsumption" toml:"tokenconsumption"`
+	// Bond of a candidate and the blocks until it is released after
+	// the logout
+	CandidateBond   uint64 `json:"candidatebond" toml:"candidatebond"`
+	UnbondingPeriod uint64 `json:"unbondingperiod" toml:"unbondingperiod"`
+	// Premine allocations of the main coin
+	Alloc      []MappingInfo    `json:"alloc" toml:"alloc"`
+	Candidates []CandidatesInfo `json:"candidates" toml:"candidates"`
+	// Activation heights of the forks, the forks not in it are not
+	// active
+	Forks map[Fork]uint64 `json:"forks,omitempty" toml:"forks"`
+}
+
+// Genesis of the network the node runs, nil for the main network
+var genesis *Genesis
+
+// Chain id of the main and the test network, a genesis file must use
+// another id
+const builtinChainId = "UWorld"
+
+// DefaultGenesis returns the genesis of the main network
+func DefaultGenesis() *Genesis {
+	return &Genesis{
+		ChainId:          builtinChainId,
+		Time:             1569398062,
+		Token:            Token.String(),
+		FeeAddress:       FeeAddress.String(),
+		AddressPrefix:    hex.EncodeToString(MainPubKeyHashAddrID[:]),
+		TokenPrefix:      hex.EncodeToString(MainPubKeyHashTokenID[:]),
+		BlockInterval:    BlockInterval,
+		TermInterval:     TermInterval,
+		MaxWinnerSize:    MaxWinnerSize,
+		Fees:             Fees,
+		TokenConsumption: TokenConsumption,
+		CandidateBond:    CandidateBond,
+		UnbondingPeriod:  UnbondingPeriod,
+		Alloc:            MappingCoin,
+		Candidates:       InitialCandidates,
+	}
+}
+
+// GetGenesis returns the genesis of the network the node runs
+func GetGenesis() *Genesis {
+	if genesis == nil {
+		return DefaultGenesis()
+	}
+	return genesis
+}
+
+// IsCustomGenesis returns whether the node runs a network described
+// by a genesis file instead of the main or the test network
+func IsCustomGenesis() bool {
+	return genesis != nil
+}
+
+// LoadGenesis reads a genesis file, the format is toml if the file
+// extension is .toml, otherwise json. The parameters not in the file
+// are the parameters of the main network, the allocations and the
+// candidates must be given.
+func LoadGenesis(file string) (*Genesis, error) {
+	bytes, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	g := DefaultGenesis()
+	g.Alloc = nil
+	g.Candidates = nil
+	if strings.ToLower(filepath.Ext(file)) == ".toml" {
+		err = toml.Unmarshal(bytes, g)
+	} else {
+		err = json.Unmarshal(bytes, g)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse genesis file %s failed! %s", file, err.Error())
+	}
+	if err := g.verifyCustom(); err != nil {
+		return nil, fmt.Errorf("wrong genesis file %s! %s", file, err.Error())
+	}
+	return g, nil
+}
+
+// Verify checks the parameters of the genesis
+func (g *Genesis) Verify() error {
+	if g.ChainId == "" {
+		return errors.New("no chain id")
+	}
+	if g.Token == "" {
+		return errors.New("no token")
+	}
+	if g.FeeAddress == "" {
+		return errors.New("no fee address")
+	}
+	if _, err := decodePrefix(g.AddressPrefix); err != nil {
+		return fmt.Errorf("wrong address prefix, %s", err.Error())
+	}
+	if _, err := decodePrefix(g.TokenPrefix); err != nil {
+		return fmt.Errorf("wrong token prefix, %s", err.Error())
+	}
+	if g.BlockInterval == 0 || g.TermInterval%g.BlockInterval != 0 {
+		return fmt.Errorf("block interval must divide the term interval %d", g.TermInterval)
+	}
+	if g.MaxWinnerSize <= 0 {
+		return errors.New("max winner size must be greater than 0")
+	}
+	if g.TermInterval < g.BlockInterval*uint64(g.MaxWinnerSize) {
+		return errors.New("every winner must have a block in a term")
+	}
+	if g.Fees == 0 || g.Fees > MaxFeesCoefficient {
+		return fmt.Errorf("fees must be in the range of 1 and %d", MaxFeesCoefficient)
+	}
+	if len(g.Candidates) < g.MaxWinnerSize {
+		return fmt.Errorf("at least %d candidates are required", g.MaxWinnerSize)
+	}
+	for _, candidate := range g.Candidates {
+		if candidate.Address == "" || candidate.PeerId == "" {
+			return errors.New("candidate must have an address and a peer id")
+		}
+	}
+	if err := verifyForks(g.Forks); err != nil {
+		return err
+	}
+	if len(g.Alloc) == 0 {
+		return errors.New("no allocations")
+	}
+	var sum uint64
+	for _, alloc := range g.Alloc {
+		if alloc.Address == "" || alloc.Amount == 0 {
+			return errors.New("allocation must have an address and an amount")
+		}
+		if alloc.Start > alloc.End && alloc.End != 0 || alloc.Start != 0 && alloc.End == 0 {
+			return fmt.Errorf("the end height of the allocation of %s must not be less than the start height", alloc.Address)
+		}
+		if alloc.Amount > Circulation-sum {
+			return fmt.Errorf("allocations exceed the circulation %d", uint64(Circulation))
+		}
+		sum += alloc.Amount
+	}
+	return nil
+}
+
+// A custom network must not be taken for the main or the test network
+func (g *Genesis) verifyCustom() error {
+	if err := g.Verify(); err != nil {
+		return err
+	}
+	if g.ChainId == builtinChainId {
+		return fmt.Errorf("chain id %s is the id of the main and the test network", g.ChainId)
+	}
+	return nil
+}
+
+// SumAlloc returns the sum of the premine allocations
+func (g *Genesis) SumAlloc() uint64 {
+	var sum uint64
+	for _, alloc := range g.Alloc {
+		sum += alloc.Amount
+	}
+	return sum
+}
+
+// SetGenesis changes the parameters to the custom network of the
+// genesis, it must be called before anything else uses them
+func SetGenesis(g *Genesis) error {
+	if err := g.verifyCustom(); err != nil {
+		return err
+	}
+	addrPrefix, _ := decodePrefix(g.AddressPrefix)
+	tokenPrefix, _ := decodePrefix(g.TokenPrefix)
+	MainPubKeyHashAddrID = addrPrefix
+	TestPubKeyHashAddrID = addrPrefix
+	MainPubKeyHashTokenID = tokenPrefix
+	TestPubKeyHashTokenID = tokenPrefix
+
+	UniqueNetWork = "_" + g.ChainId
+	Token = hasharry.StringToAddress(g.Token)
+	FeeAddress = hasharry.StringToAddress(g.FeeAddress)
+	BlockInterval = g.BlockInterval
+	TermInterval = g.TermInterval
+	MaxWinnerSize = g.MaxWinnerSize
+	SafeSize = MaxWinnerSize*2/3 + 1
+	ConsensusSize = MaxWinnerSize*2/3 + 1
+	SkipCurrentWinnerWaitTimeBase = BlockInterval * uint64(MaxWinnerSize) * 1
+	Fees = g.Fees
+	TokenConsumption = g.TokenConsumption
+	CandidateBond = g.CandidateBond
+	UnbondingPeriod = g.UnbondingPeriod
+	genesis = g
+	return nil
+}
+
+func decodePrefix(prefix string) ([3]byte, error) {
+	var ver [3]byte
+	bytes, err := hex.DecodeString(prefix)
+	if err != nil {
+		return ver, err
+	}
+	if len(bytes) != len(ver) {
+		return ver, fmt.Errorf("must be %d bytes", len(ver))
+	}
+	copy(ver[:], bytes)
+	return ver, nil
+}
@@ -0,0 +1,103 @@
+package param
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testGenesis = `
+chainid = "devnet"
+blockinterval = 5
+maxwinnersize = 1
+
+[[alloc]]
+address = "UWDM1qcsk7UUNANMPKSpALJW7AqpDCy7tdoN"
+amount = 100
+
+[[candidates]]
+address = "UWDR5oWfEjnGcEnQJrknuNW3LxZeWSpZUPJ5"
+peerid = "16Uiu2HAm8dGc2gAuQG9WAdPNeXRDG1GU8wQtv4f2jdGEGkrX9Ln1"
+
+[forks]
+election = 0
+slashing = 100
+`
+
+func TestLoadGenesis(t *testing.T) {
+	dir, err := ioutil.TempDir("", "genesis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "genesis.toml")
+	if err := ioutil.WriteFile(file, []byte(testGenesis), 0644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := LoadGenesis(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.ChainId != "devnet" || g.BlockInterval != 5 || g.MaxWinnerSize != 1 {
+		t.Fatalf("wrong parameters %+v", g)
+	}
+	if g.Token != Token.String() || g.Fees != Fees || g.CandidateBond != CandidateBond {
+		t.Fatal("missing parameters must be the parameters of the main network")
+	}
+	if len(g.Alloc) != 1 || g.SumAlloc() != 100 || len(g.Candidates) != 1 {
+		t.Fatal("wrong allocations or candidates")
+	}
+	if len(g.Forks) != 2 || g.Forks[Election] != 0 || g.Forks[Slashing] != 100 {
+		t.Fatalf("wrong forks %v", g.Forks)
+	}
+
+	// The id of the built-in networks is refused
+	builtin := strings.Replace(testGenesis, `chainid = "devnet"`, `chainid = "UWorld"`, 1)
+	if err := ioutil.WriteFile(file, []byte(builtin), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadGenesis(file); err == nil {
+		t.Fatal("a genesis with the chain id of the main network is loaded")
+	}
+	if err := ioutil.WriteFile(file, []byte(strings.Replace(testGenesis, `chainid = "devnet"`, "", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadGenesis(file); err == nil {
+		t.Fatal("a genesis without a chain id is loaded")
+	}
+	if err := SetGenesis(DefaultGenesis()); err == nil {
+		t.Fatal("the genesis of the main network is set as a custom network")
+	}
+}
+
+func TestGenesisVerify(t *testing.T) {
+	if err := DefaultGenesis().Verify(); err != nil {
+		t.Fatalf("main network genesis: %v", err)
+	}
+	if DefaultGenesis().SumAlloc() != GenesisCoins {
+		t.Fatal("wrong main network allocations")
+	}
+	for name, change := range map[string]func(g *Genesis){
+		"interval":   func(g *Genesis) { g.BlockInterval = 7 },
+		"winners":    func(g *Genesis) { g.MaxWinnerSize = len(g.Candidates) + 1 },
+		"term":       func(g *Genesis) { g.TermInterval = g.BlockInterval * uint64(g.MaxWinnerSize-1) },
+		"prefix":     func(g *Genesis) { g.AddressPrefix = "0382" },
+		"no alloc":   func(g *Genesis) { g.Alloc = nil },
+		"zero alloc": func(g *Genesis) { g.Alloc = []MappingInfo{{Address: "UWDM1qcsk7UUNANMPKSpALJW7AqpDCy7tdoN"}} },
+		"no fees":    func(g *Genesis) { g.Fees = 0 },
+		"lock end": func(g *Genesis) {
+			g.Alloc = []MappingInfo{{Address: "UWDM1qcsk7UUNANMPKSpALJW7AqpDCy7tdoN", Amount: 100, Start: 20, End: 10}}
+		},
+		"lock start": func(g *Genesis) {
+			g.Alloc = []MappingInfo{{Address: "UWDM1qcsk7UUNANMPKSpALJW7AqpDCy7tdoN", Amount: 100, Start: 20}}
+		},
+	} {
+		g := DefaultGenesis()
+		change(g)
+		if err := g.Verify(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
@@ -23,18 +23,35 @@ var (
 	EaterAddress = hasharry.StringToAddress("UWDCoinEaterAddressDontSend000000000")
 )
 
-const (
+// The consensus parameters can be changed by the genesis of the network
+var (
+	// Re-election interval
+	TermInterval = uint64(60 * 60 * 24 * 365 * 100)
 	// Block interval period
 	BlockInterval = uint64(30)
-	// Re-election interval
-	TermInterval = 60 * 60 * 24 * 365 * 100
 	// Maximum number of super nodes
 	MaxWinnerSize = 11
 	// The minimum number of nodes required to confirm the transaction
 	SafeSize = MaxWinnerSize*2/3 + 1
 	// The minimum threshold at which a block is valid
 	ConsensusSize                 = MaxWinnerSize*2/3 + 1
-	SkipCurrentWinnerWaitTimeBase = BlockInterval * MaxWinnerSize * 1
+	SkipCurrentWinnerWaitTimeBase = BlockInterval * uint64(MaxWinnerSize) * 1
+	// Coins locked by a candidate at login
+	CandidateBond uint64 = 10000 * AtomsPerCoin
+	// Number of blocks after the logout of a candidate until its
+	// bond is released, 7 days at the block interval
+	UnbondingPeriod = 7 * 24 * 3600 / BlockInterval
+)
+
+const (
+	// Number of elections a jailed candidate can not be elected in
+	JailTerms = 1
+	// Percentage of the bond burned for signing two blocks at the
+	// same height
+	DoubleSignSlash = 10
+	// Number of voters whose coins are summed or who are paid their
+	// share of the rewards of a finished term in a block
+	RewardPayoutsPerBlock = 100
 )
 
 const (
@@ -68,15 +85,17 @@ const (
 	// MaxContractCoin is the maximum allowable contract COINS
 	MaxContractCoin uint64 = 1e10 * AtomsPerCoin
 
-	Fees uint64 = 0.002 * AtomsPerCoin
-
-	TokenConsumption uint64 = 10.24 * AtomsPerCoin
-
 	CoinHeight = 1
 
 	MaximumReceiver = 1000
 )
 
+var (
+	Fees uint64 = 0.002 * AtomsPerCoin
+
+	TokenConsumption uint64 = 10.24 * AtomsPerCoin
+)
+
 var (
 	MainPubKeyHashAddrID  = [3]byte{0x03, 0x82, 0x32} //UWD 3, 82, 32
 	TestPubKeyHashAddrID  = [3]byte{0x03, 0x82, 0x32} //uwd
@@ -85,9 +104,18 @@ var (
 )
 
 type MappingInfo struct {
-	Address string
-	Note    string
-	Amount  uint64
+	Address string `json:"address" toml:"address"`
+	Note    string `json:"note" toml:"note"`
+	Amount  uint64 `json:"amount" toml:"amount"`
+	// The allocation is locked if End is not 0, it is released
+	// linearly from the Start height to the End height
+	Start uint64 `json:"start,omitempty" toml:"start"`
+	End   uint64 `json:"end,omitempty" toml:"end"`
+}
+
+// Whether the allocation is paid as a locked transfer
+func (m MappingInfo) IsLocked() bool {
+	return m.End != 0
 }
 
 var MappingCoin = []MappingInfo{
@@ -98,6 +126,60 @@ var MappingCoin = []MappingInfo{
 	},
 }
 
+type CandidatesInfo struct {
+	Address string `json:"address" toml:"address"`
+	PeerId  string `json:"peerid" toml:"peerid"`
+}
+
+// InitialCandidates the first super node of the block generation cycle.
+// The first half is the address of the block, the second half is the id of the block node
+var InitialCandidates = []CandidatesInfo{
+	{
+		Address: "UWDR5oWfEjnGcEnQJrknuNW3LxZeWSpZUPJ5",
+		PeerId:  "16Uiu2HAm8dGc2gAuQG9WAdPNeXRDG1GU8wQtv4f2jdGEGkrX9Ln1",
+	},
+	{
+		Address: "UWDP1EbJ1mpT4sDD1p6TWhvNXBjxRiqLjW7F",
+		PeerId:  "16Uiu2HAm5gMSBTPc1PjcsJJpSNvXWmA3KaMkfLsjxs78BDA4FLDM",
+	},
+	{
+		Address: "UWDGvCpqgfGdjTRYBV2F8WCek6oBWTLiUTQH",
+		PeerId:  "16Uiu2HAmTfA1REe8wkYGNn7NzENwfyR9wVbLFLCDe6x3J1aXhTG2",
+	},
+	{
+		Address: "UWDcSaX5hUhutRDC4EQT65DLeRJxBJWBWppX",
+		PeerId:  "16Uiu2HAmK2PNwXpTM91ftN9ZF92CNLKf5m7XDApBaBa69cwPDpMv",
+	},
+	{
+		Address: "UWDHR3U2FQDNYdQpgUrHw3LkrMiC23wetsb6",
+		PeerId:  "16Uiu2HAmKCs2So8WRqZj5aWQKHAMj6DhLd1eQQBvF8MdjgbajUHL",
+	},
+	{
+		Address: "UWDamE7HaJNvRrRDx3Rnmj9WKqHnDMFzQ6P3",
+		PeerId:  "16Uiu2HAkvRSRt5VEeN8vXKg1sxDSeXwJfQzPqYPPneoaTNwYxQuM",
+	},
+	{
+		Address: "UWDRKs3eg7deVcRo5pJVCsRAVQ1umXNM1DiD",
+		PeerId:  "16Uiu2HAmABaSg6ZmBpKXoSCV5V89fNS4N3s91wRAbneq7DJPN81y",
+	},
+	{
+		Address: "UWDZqKCCQLV2yTZ7crW6kZn7UFLPCpHtFdGi",
+		PeerId:  "16Uiu2HAm9w9gZsnS7ZKGqdLfNgXuyMrfhBgSKHWePVfhHGVzDDxH",
+	},
+	{
+		Address: "UWDKvSoMxcD7MbT1KZ3kX5xjnL4tWK7XXGCd",
+		PeerId:  "16Uiu2HAmULDuqSBP9mueYjHCknUuex3yGVQM4QXgBNTHf8qiNFD6",
+	},
+	{
+		Address: "UWDN2rVzEgJRVGyZFUQstL2y2JpSkGVz1kbY",
+		PeerId:  "16Uiu2HAkxKA74FNnwY5hAJGW64GyDgfWFeCtkkuFJwXbSLeVKK1t",
+	},
+	{
+		Address: "UWDToaCgPNCZ168ZnvUg38bRMm5U7DjwRVuA",
+		PeerId:  "16Uiu2HAmRsZi1iWAx1efSv7o5dvzeYoRXhQusNtjzbhmmuqUo99q",
+	},
+}
+
 var DayCoin = map[uint64]float64{
 	1:   7000,
 	2:   7700,
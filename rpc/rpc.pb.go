@@ -213,6 +213,163 @@ func (m *Null) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Null proto.InternalMessageInfo
 
+type AddressPage struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Start                uint64   `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	Count                uint64   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressPage) Reset()         { *m = AddressPage{} }
+func (m *AddressPage) String() string { return proto.CompactTextString(m) }
+func (*AddressPage) ProtoMessage()    {}
+func (*AddressPage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{5}
+}
+
+func (m *AddressPage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressPage.Unmarshal(m, b)
+}
+func (m *AddressPage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressPage.Marshal(b, m, deterministic)
+}
+func (m *AddressPage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressPage.Merge(m, src)
+}
+func (m *AddressPage) XXX_Size() int {
+	return xxx_messageInfo_AddressPage.Size(m)
+}
+func (m *AddressPage) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressPage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressPage proto.InternalMessageInfo
+
+func (m *AddressPage) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AddressPage) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *AddressPage) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type AddressHeight struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddressHeight) Reset()         { *m = AddressHeight{} }
+func (m *AddressHeight) String() string { return proto.CompactTextString(m) }
+func (*AddressHeight) ProtoMessage()    {}
+func (*AddressHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{6}
+}
+
+func (m *AddressHeight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddressHeight.Unmarshal(m, b)
+}
+func (m *AddressHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddressHeight.Marshal(b, m, deterministic)
+}
+func (m *AddressHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddressHeight.Merge(m, src)
+}
+func (m *AddressHeight) XXX_Size() int {
+	return xxx_messageInfo_AddressHeight.Size(m)
+}
+func (m *AddressHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddressHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddressHeight proto.InternalMessageInfo
+
+func (m *AddressHeight) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *AddressHeight) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type TokenAllowance struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Owner                string   `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Spender              string   `protobuf:"bytes,3,opt,name=spender,proto3" json:"spender,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TokenAllowance) Reset()         { *m = TokenAllowance{} }
+func (m *TokenAllowance) String() string { return proto.CompactTextString(m) }
+func (*TokenAllowance) ProtoMessage()    {}
+func (*TokenAllowance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{7}
+}
+
+func (m *TokenAllowance) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TokenAllowance.Unmarshal(m, b)
+}
+func (m *TokenAllowance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TokenAllowance.Marshal(b, m, deterministic)
+}
+func (m *TokenAllowance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenAllowance.Merge(m, src)
+}
+func (m *TokenAllowance) XXX_Size() int {
+	return xxx_messageInfo_TokenAllowance.Size(m)
+}
+func (m *TokenAllowance) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenAllowance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenAllowance proto.InternalMessageInfo
+
+func (m *TokenAllowance) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *TokenAllowance) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *TokenAllowance) GetSpender() string {
+	if m != nil {
+		return m.Spender
+	}
+	return ""
+}
+
 // The response message containing the greetings
 type Response struct {
 	Code                 int32    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -227,7 +384,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_77a6da22d6a3feb1, []int{5}
+	return fileDescriptor_77a6da22d6a3feb1, []int{8}
 }
 
 func (m *Response) XXX_Unmarshal(b []byte) error {
@@ -275,46 +432,72 @@ func init() {
 	proto.RegisterType((*Hash)(nil), "rpc.Hash")
 	proto.RegisterType((*Height)(nil), "rpc.Height")
 	proto.RegisterType((*Null)(nil), "rpc.Null")
+	proto.RegisterType((*AddressPage)(nil), "rpc.AddressPage")
+	proto.RegisterType((*AddressHeight)(nil), "rpc.AddressHeight")
+	proto.RegisterType((*TokenAllowance)(nil), "rpc.TokenAllowance")
 	proto.RegisterType((*Response)(nil), "rpc.Response")
 }
 
 func init() { proto.RegisterFile("rpc.proto", fileDescriptor_77a6da22d6a3feb1) }
 
 var fileDescriptor_77a6da22d6a3feb1 = []byte{
-	// 517 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x80, 0x95, 0x36, 0x3f, 0xcd, 0x34, 0x4d, 0xdb, 0x6d, 0x94, 0xa6, 0xa1, 0x85, 0xb2, 0x5c,
-	0x50, 0x0f, 0x8d, 0x80, 0x03, 0x52, 0x25, 0x0e, 0x09, 0x3f, 0x4e, 0xa5, 0xaa, 0x84, 0xd0, 0x17,
-	0xd8, 0xda, 0xd3, 0x38, 0xc2, 0x78, 0xad, 0xdd, 0x0d, 0x6a, 0xaf, 0xbc, 0x02, 0x8f, 0xc6, 0x2b,
-	0xf0, 0x0a, 0xdc, 0xd1, 0x8c, 0x37, 0x4a, 0xb0, 0x44, 0xc2, 0x6d, 0xc6, 0x3b, 0xdf, 0xb7, 0xb3,
-	0x33, 0x92, 0xa1, 0x6e, 0xb2, 0xf0, 0x3c, 0x33, 0xda, 0x69, 0xb1, 0x69, 0xb2, 0xb0, 0x7b, 0x3c,
-	0xd1, 0x7a, 0x92, 0x60, 0x4f, 0x65, 0xd3, 0x9e, 0x4a, 0x53, 0xed, 0x94, 0x9b, 0xea, 0xd4, 0xe6,
-	0x25, 0xf2, 0x04, 0x2a, 0x83, 0x07, 0x87, 0x56, 0xb4, 0xa0, 0x72, 0x4b, 0x41, 0xa7, 0x74, 0x5a,
-	0x7a, 0xde, 0x18, 0xe7, 0x89, 0x7c, 0x06, 0xb5, 0x7e, 0x14, 0x19, 0xb4, 0x56, 0x74, 0xa0, 0xa6,
-	0xf2, 0x90, 0x4b, 0xea, 0xe3, 0x79, 0x2a, 0xbb, 0x50, 0x1e, 0x2a, 0x1b, 0x0b, 0x01, 0xe5, 0x58,
-	0xd9, 0xd8, 0x1f, 0x73, 0x2c, 0x4f, 0xa1, 0x3a, 0xc4, 0xe9, 0x24, 0x76, 0xa2, 0x0d, 0xd5, 0x98,
-	0x23, 0x3e, 0x2f, 0x8f, 0x7d, 0x26, 0xab, 0x50, 0xbe, 0x9e, 0x25, 0x89, 0x1c, 0xc2, 0xd6, 0x18,
-	0x6d, 0xa6, 0x53, 0x8b, 0x64, 0x0a, 0x75, 0x84, 0x5c, 0x59, 0x19, 0x73, 0x4c, 0xbc, 0x41, 0x3b,
-	0x4b, 0x5c, 0x67, 0x83, 0x3b, 0xf4, 0x99, 0xd8, 0x83, 0x4d, 0x34, 0xa6, 0xb3, 0xc9, 0x97, 0x52,
-	0xf8, 0xf2, 0x77, 0x15, 0x6a, 0x81, 0x41, 0x74, 0x68, 0xc4, 0x15, 0xec, 0x7e, 0xc6, 0x34, 0xba,
-	0x31, 0x2a, 0xb5, 0x2a, 0xa4, 0x97, 0x0b, 0x38, 0xa7, 0x09, 0xf1, 0xab, 0xbb, 0x3b, 0x1c, 0xcf,
-	0xef, 0x95, 0x8f, 0xbf, 0xff, 0xfc, 0xf5, 0x63, 0xa3, 0x23, 0x0f, 0x7a, 0xdf, 0x5e, 0xf4, 0x0a,
-	0xdc, 0x45, 0xe9, 0x4c, 0xbc, 0x03, 0x08, 0xd0, 0xf5, 0xc3, 0x50, 0xcf, 0x52, 0x27, 0x1a, 0x0c,
-	0xfb, 0xf9, 0x14, 0x55, 0x47, 0xac, 0x3a, 0x90, 0x4d, 0x52, 0x2d, 0x20, 0xb2, 0x5c, 0x42, 0x33,
-	0x40, 0xb7, 0xdc, 0x52, 0x9d, 0x59, 0x1a, 0x62, 0x51, 0x73, 0xc2, 0x9a, 0x43, 0x29, 0xbc, 0xa6,
-	0xd0, 0x50, 0xae, 0x1a, 0x24, 0x3a, 0xfc, 0x32, 0x78, 0xe0, 0x25, 0xfc, 0xbf, 0x6a, 0x89, 0x22,
-	0xd5, 0x47, 0xd8, 0x5b, 0xfa, 0x98, 0xef, 0x6c, 0x3b, 0x97, 0x71, 0x52, 0xd4, 0x3d, 0x61, 0xdd,
-	0x91, 0x6c, 0x15, 0x74, 0x5c, 0x4c, 0xc2, 0x3e, 0x0f, 0x6b, 0xa4, 0x75, 0x72, 0x73, 0x6f, 0x7d,
-	0x5f, 0xb4, 0xe9, 0x75, 0x93, 0xf2, 0x04, 0x29, 0x02, 0xd8, 0x09, 0xd0, 0x5d, 0x29, 0xeb, 0x7c,
-	0x43, 0xff, 0xb6, 0x1c, 0xb3, 0xa5, 0x2d, 0xf7, 0xbd, 0x65, 0x01, 0x91, 0xe8, 0x03, 0x6c, 0x07,
-	0xe8, 0xde, 0xea, 0xd4, 0x19, 0x15, 0xae, 0xd9, 0x5c, 0x97, 0x4d, 0x2d, 0xb9, 0xeb, 0x4d, 0x73,
-	0x8a, 0x3c, 0x9f, 0x40, 0xe4, 0x5f, 0xee, 0xa6, 0xe6, 0x2b, 0x46, 0x6b, 0xbb, 0x7a, 0xca, 0xae,
-	0x47, 0xb2, 0xbd, 0x70, 0x2d, 0x93, 0xa4, 0x7c, 0x0d, 0x95, 0x11, 0xa2, 0x59, 0x35, 0xa1, 0x16,
-	0x5b, 0x9a, 0xb2, 0x4e, 0x16, 0x2e, 0x26, 0xf0, 0x0d, 0x6c, 0x5d, 0xeb, 0x08, 0x2f, 0xd3, 0x3b,
-	0xbd, 0x82, 0x3d, 0x64, 0x76, 0x5f, 0x36, 0x88, 0x9d, 0xd7, 0x13, 0x3e, 0xe2, 0x7d, 0xbf, 0xbf,
-	0x0f, 0x63, 0x95, 0x4e, 0x70, 0xa4, 0xa6, 0xc6, 0xae, 0x9e, 0x4b, 0x71, 0xe1, 0x7f, 0xa1, 0x17,
-	0xa5, 0xb3, 0xdb, 0x2a, 0xff, 0x52, 0x5e, 0xfd, 0x09, 0x00, 0x00, 0xff, 0xff, 0x55, 0xd3, 0x02,
-	0x33, 0x82, 0x04, 0x00, 0x00,
+	// 893 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x96, 0x5d, 0x4e, 0x23, 0x47,
+	0x10, 0xc7, 0xc5, 0x37, 0x2e, 0xc0, 0x40, 0x9b, 0x80, 0xd7, 0x61, 0xb3, 0x6c, 0x47, 0x51, 0xa2,
+	0x7d, 0x58, 0x94, 0xe4, 0x21, 0xd2, 0x4a, 0x51, 0x62, 0x93, 0xac, 0x59, 0x09, 0xb1, 0x8e, 0xb1,
+	0x56, 0x4a, 0x94, 0x87, 0xf4, 0xce, 0x14, 0xb6, 0xc5, 0xec, 0xb4, 0xd5, 0xdd, 0x84, 0xe5, 0x35,
+	0x57, 0xc8, 0x69, 0x72, 0x8e, 0x5c, 0x21, 0x07, 0x89, 0xaa, 0x7a, 0x86, 0x99, 0x69, 0xc0, 0x86,
+	0xb7, 0xae, 0x9e, 0xfe, 0xff, 0xaa, 0x5c, 0x55, 0xdd, 0x65, 0xa8, 0x99, 0x49, 0xf4, 0x72, 0x62,
+	0xb4, 0xd3, 0x62, 0xc1, 0x4c, 0xa2, 0xd6, 0xfe, 0x50, 0xeb, 0x61, 0x82, 0x87, 0x6a, 0x32, 0x3e,
+	0x54, 0x69, 0xaa, 0x9d, 0x72, 0x63, 0x9d, 0x5a, 0x7f, 0x44, 0x3e, 0x85, 0xa5, 0xce, 0xb5, 0x43,
+	0x2b, 0x76, 0x60, 0xe9, 0x3d, 0x2d, 0x9a, 0x73, 0x07, 0x73, 0x5f, 0xad, 0xf7, 0xbd, 0x21, 0x3f,
+	0x87, 0x95, 0x76, 0x1c, 0x1b, 0xb4, 0x56, 0x34, 0x61, 0x45, 0xf9, 0x25, 0x1f, 0xa9, 0xf5, 0x73,
+	0x53, 0xb6, 0x60, 0xf1, 0x58, 0xd9, 0x91, 0x10, 0xb0, 0x38, 0x52, 0x76, 0x94, 0x7d, 0xe6, 0xb5,
+	0x3c, 0x80, 0xe5, 0x63, 0x1c, 0x0f, 0x47, 0x4e, 0xec, 0xc2, 0xf2, 0x88, 0x57, 0xfc, 0x7d, 0xb1,
+	0x9f, 0x59, 0x72, 0x19, 0x16, 0x4f, 0x2f, 0x93, 0x44, 0x9e, 0xc1, 0x5a, 0xe6, 0xaa, 0xa7, 0x86,
+	0x78, 0xbf, 0x3b, 0x8a, 0xd4, 0x3a, 0x65, 0x5c, 0x73, 0x9e, 0x39, 0xde, 0xa0, 0xdd, 0x48, 0x5f,
+	0xa6, 0xae, 0xb9, 0xe0, 0x77, 0xd9, 0x90, 0x6d, 0xd8, 0xc8, 0xa0, 0x59, 0x14, 0xf7, 0x63, 0x8b,
+	0xf8, 0xe6, 0x2b, 0xf1, 0xbd, 0x83, 0xfa, 0x40, 0x5f, 0x60, 0xda, 0x4e, 0x12, 0x7d, 0xa5, 0xd2,
+	0x08, 0xc9, 0x95, 0xa3, 0x9d, 0x8c, 0xe0, 0x0d, 0xda, 0xd5, 0x57, 0x29, 0x1a, 0x96, 0xd7, 0xfa,
+	0xde, 0x20, 0x7f, 0x76, 0x82, 0x69, 0x8c, 0x86, 0x03, 0xab, 0xf5, 0x73, 0x53, 0x1e, 0xc3, 0x6a,
+	0x1f, 0xed, 0x44, 0xa7, 0x16, 0x29, 0x73, 0x91, 0x8e, 0x91, 0x81, 0x4b, 0x7d, 0x5e, 0x53, 0x3c,
+	0x06, 0xed, 0x65, 0xe2, 0xe3, 0x59, 0xef, 0x67, 0x96, 0xd8, 0x82, 0x05, 0x34, 0x39, 0x8d, 0x96,
+	0xdf, 0xfc, 0xb3, 0x0d, 0x2b, 0x5d, 0x83, 0xe8, 0xd0, 0x88, 0x13, 0xd8, 0x3c, 0xc3, 0x34, 0x1e,
+	0x18, 0x95, 0x5a, 0x15, 0x51, 0xa5, 0x05, 0xbc, 0xa4, 0x8e, 0xe0, 0x2a, 0xb7, 0x36, 0x78, 0x9d,
+	0xfb, 0x95, 0x9f, 0xfd, 0xf5, 0xef, 0x7f, 0x7f, 0xcf, 0x37, 0x65, 0xe3, 0xf0, 0xcf, 0xaf, 0x0f,
+	0x03, 0xdd, 0xab, 0xb9, 0x17, 0x62, 0x00, 0x8d, 0xb3, 0xf1, 0x87, 0xcb, 0x44, 0x39, 0x7c, 0x20,
+	0x51, 0x32, 0x71, 0x5f, 0xee, 0x31, 0xf1, 0xb6, 0x96, 0xa8, 0x3f, 0x01, 0x74, 0xd1, 0xb5, 0x23,
+	0x2e, 0x91, 0x58, 0x67, 0x40, 0x56, 0xa5, 0x10, 0xf7, 0x84, 0x71, 0x0d, 0x59, 0x27, 0x5c, 0x21,
+	0x22, 0xca, 0x1b, 0xa8, 0x77, 0xd1, 0x95, 0xc3, 0xaa, 0xb1, 0x96, 0x5a, 0x31, 0xc4, 0x3c, 0x65,
+	0xcc, 0x9e, 0x14, 0x19, 0x26, 0x08, 0xc8, 0xa3, 0x3a, 0x89, 0x8e, 0x2e, 0x3a, 0xd7, 0xdc, 0xca,
+	0x0f, 0x47, 0x95, 0x54, 0x84, 0x7a, 0x0b, 0x5b, 0xa5, 0x4d, 0xdf, 0x73, 0x6b, 0x1e, 0xc6, 0x46,
+	0x88, 0x7b, 0xc6, 0xb8, 0x27, 0x72, 0x27, 0xc0, 0xf1, 0x61, 0x02, 0xb6, 0x39, 0x59, 0x3d, 0xad,
+	0x93, 0xc1, 0x47, 0x9b, 0xc5, 0x45, 0xf7, 0x65, 0x56, 0xa6, 0x32, 0x05, 0x21, 0xba, 0xb0, 0xd1,
+	0x45, 0x77, 0xa2, 0xac, 0xcb, 0x02, 0xba, 0x9f, 0xb2, 0xcf, 0x94, 0x5d, 0xb9, 0x9d, 0x51, 0x0a,
+	0x11, 0x81, 0x5e, 0xc3, 0x5a, 0x17, 0xdd, 0x91, 0x4e, 0x9d, 0x51, 0xd1, 0x8c, 0xca, 0xb5, 0x98,
+	0xb4, 0x23, 0x37, 0x33, 0x52, 0xae, 0x22, 0xce, 0x2f, 0x20, 0xfc, 0xce, 0xf9, 0xd8, 0x7c, 0xc0,
+	0x78, 0x66, 0x54, 0xcf, 0x99, 0xf5, 0xa9, 0xdc, 0x2d, 0x58, 0x65, 0x25, 0x21, 0xbf, 0x83, 0xa5,
+	0x1e, 0xa2, 0x99, 0x96, 0xa1, 0x1d, 0xa6, 0xd4, 0x65, 0x8d, 0x28, 0x7c, 0x98, 0x84, 0xdf, 0xc3,
+	0xea, 0xa9, 0x8e, 0xf1, 0x4d, 0x7a, 0xae, 0xa7, 0x68, 0xf7, 0x58, 0xbb, 0x2d, 0xd7, 0x49, 0x9b,
+	0x9f, 0x27, 0x79, 0x8f, 0xeb, 0xfd, 0xf3, 0xc7, 0x68, 0xa4, 0xd2, 0x21, 0xf6, 0xd4, 0xd8, 0xd8,
+	0xe9, 0x79, 0x09, 0x0b, 0x5e, 0x91, 0x12, 0xf1, 0x0f, 0xd8, 0xa5, 0x46, 0xf7, 0xea, 0x52, 0xa3,
+	0x5a, 0xb1, 0x55, 0xe6, 0xd2, 0x23, 0x19, 0xb2, 0xbf, 0x60, 0xf6, 0x33, 0xd9, 0xca, 0x6f, 0xcb,
+	0x6d, 0x08, 0x79, 0xf8, 0x0d, 0x44, 0x71, 0x95, 0xda, 0x79, 0x53, 0x88, 0x32, 0xfd, 0xee, 0x66,
+	0x0d, 0xeb, 0x10, 0x20, 0x88, 0xfd, 0x3b, 0x34, 0x4a, 0xc5, 0x7e, 0x0c, 0xbc, 0xf2, 0x72, 0xdc,
+	0xc1, 0x20, 0xfa, 0x39, 0x34, 0xc3, 0x94, 0x3d, 0xc6, 0xc5, 0x97, 0xec, 0xe2, 0xb9, 0xdc, 0xbf,
+	0x2b, 0xf7, 0x65, 0x3f, 0x03, 0xd8, 0x2c, 0x7e, 0x5e, 0xcf, 0x68, 0x7d, 0xfe, 0x10, 0x7c, 0xe5,
+	0x35, 0x0d, 0xf4, 0x44, 0x7d, 0x07, 0x5b, 0xa5, 0xdf, 0xf5, 0x60, 0x6c, 0xd8, 0x31, 0x15, 0x00,
+	0x71, 0x7f, 0x84, 0x55, 0x7a, 0xd3, 0x78, 0x0a, 0x4d, 0xed, 0xbd, 0x4a, 0x17, 0xe7, 0x12, 0x22,
+	0xfc, 0x0a, 0xdb, 0xb9, 0x59, 0x8c, 0xb9, 0x06, 0x8b, 0xab, 0x9b, 0x21, 0xf1, 0x80, 0x89, 0x2d,
+	0xf9, 0x49, 0x99, 0x78, 0x73, 0xba, 0xb8, 0x20, 0xa7, 0xaf, 0x07, 0x47, 0x3a, 0x49, 0xd0, 0x3f,
+	0xd4, 0x8f, 0xba, 0x20, 0x15, 0xa9, 0x6f, 0xdf, 0x86, 0xdf, 0xb6, 0x9d, 0xeb, 0x12, 0x74, 0xe6,
+	0xed, 0x08, 0x1b, 0x2c, 0x24, 0xf8, 0x68, 0xeb, 0x37, 0x5f, 0xde, 0xf2, 0x00, 0x9f, 0x89, 0x0d,
+	0x07, 0x42, 0x49, 0xec, 0x07, 0x02, 0xa5, 0xf6, 0x78, 0x70, 0x72, 0xe4, 0x87, 0xc4, 0x89, 0x8e,
+	0x2e, 0xa6, 0x8c, 0x97, 0x30, 0xa1, 0x55, 0x61, 0xf1, 0x9a, 0x1f, 0xa9, 0x34, 0x1e, 0xc7, 0xca,
+	0xa1, 0x7d, 0xc4, 0x6b, 0x5e, 0x88, 0x08, 0xd4, 0x81, 0x5a, 0x17, 0x5d, 0x1f, 0xaf, 0x94, 0x89,
+	0xa7, 0x97, 0xa4, 0xc9, 0x1c, 0x21, 0x37, 0x32, 0x8e, 0xd7, 0x10, 0xe3, 0x07, 0xa8, 0x9d, 0xa1,
+	0x4a, 0x78, 0x6a, 0x4d, 0x09, 0xa4, 0x02, 0xb8, 0x11, 0xbc, 0x9a, 0x7b, 0xf1, 0x7e, 0x99, 0xff,
+	0x86, 0x7e, 0xfb, 0xff, 0x00, 0xd3, 0x88, 0x33, 0xd3, 0xb6, 0x0a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -331,6 +514,7 @@ const _ = grpc.SupportPackageIsVersion4
 type GreeterClient interface {
 	// Sends a greeting
 	SendTransaction(ctx context.Context, in *Bytes, opts ...grpc.CallOption) (*Response, error)
+	SimulateTransaction(ctx context.Context, in *Bytes, opts ...grpc.CallOption) (*Response, error)
 	GetAccount(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error)
 	GetTransaction(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*Response, error)
 	GetBlockByHash(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*Response, error)
@@ -342,6 +526,21 @@ type GreeterClient interface {
 	Peers(ctx context.Context, in *Null, opts ...grpc.CallOption) (*Response, error)
 	NodeInfo(ctx context.Context, in *Null, opts ...grpc.CallOption) (*Response, error)
 	GetExchangePairs(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error)
+	GetAddressTransactions(ctx context.Context, in *AddressPage, opts ...grpc.CallOption) (*Response, error)
+	GetAccountAtHeight(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error)
+	GetContractAtHeight(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error)
+	GetExchangePairsAtHeight(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error)
+	GetAccountProof(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error)
+	GetContractProof(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error)
+	GetToken(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error)
+	GetTokenAllowance(ctx context.Context, in *TokenAllowance, opts ...grpc.CallOption) (*Response, error)
+	GetNFTCollection(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error)
+	GetNFTsByCollection(ctx context.Context, in *AddressPage, opts ...grpc.CallOption) (*Response, error)
+	GetNFTsByOwner(ctx context.Context, in *AddressPage, opts ...grpc.CallOption) (*Response, error)
+	GetHTLCByHashLock(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*Response, error)
+	GetCandidates(ctx context.Context, in *Null, opts ...grpc.CallOption) (*Response, error)
+	GetReward(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error)
+	SealBlock(ctx context.Context, in *Null, opts ...grpc.CallOption) (*Response, error)
 }
 
 type greeterClient struct {
@@ -361,6 +560,15 @@ func (c *greeterClient) SendTransaction(ctx context.Context, in *Bytes, opts ...
 	return out, nil
 }
 
+func (c *greeterClient) SimulateTransaction(ctx context.Context, in *Bytes, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/SimulateTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *greeterClient) GetAccount(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetAccount", in, out, opts...)
@@ -460,10 +668,146 @@ func (c *greeterClient) GetExchangePairs(ctx context.Context, in *Address, opts
 	return out, nil
 }
 
+func (c *greeterClient) GetAddressTransactions(ctx context.Context, in *AddressPage, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetAddressTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetAccountAtHeight(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetAccountAtHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetContractAtHeight(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetContractAtHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetExchangePairsAtHeight(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetExchangePairsAtHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetAccountProof(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetAccountProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetContractProof(ctx context.Context, in *AddressHeight, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetContractProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetToken(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetTokenAllowance(ctx context.Context, in *TokenAllowance, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetTokenAllowance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetNFTCollection(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetNFTCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetNFTsByCollection(ctx context.Context, in *AddressPage, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetNFTsByCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetNFTsByOwner(ctx context.Context, in *AddressPage, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetNFTsByOwner", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetHTLCByHashLock(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetHTLCByHashLock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetCandidates(ctx context.Context, in *Null, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetCandidates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetReward(ctx context.Context, in *Address, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/GetReward", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) SealBlock(ctx context.Context, in *Null, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/rpc.Greeter/SealBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GreeterServer is the server API for Greeter service.
 type GreeterServer interface {
 	// Sends a greeting
 	SendTransaction(context.Context, *Bytes) (*Response, error)
+	SimulateTransaction(context.Context, *Bytes) (*Response, error)
 	GetAccount(context.Context, *Address) (*Response, error)
 	GetTransaction(context.Context, *Hash) (*Response, error)
 	GetBlockByHash(context.Context, *Hash) (*Response, error)
@@ -475,6 +819,21 @@ type GreeterServer interface {
 	Peers(context.Context, *Null) (*Response, error)
 	NodeInfo(context.Context, *Null) (*Response, error)
 	GetExchangePairs(context.Context, *Address) (*Response, error)
+	GetAddressTransactions(context.Context, *AddressPage) (*Response, error)
+	GetAccountAtHeight(context.Context, *AddressHeight) (*Response, error)
+	GetContractAtHeight(context.Context, *AddressHeight) (*Response, error)
+	GetExchangePairsAtHeight(context.Context, *AddressHeight) (*Response, error)
+	GetAccountProof(context.Context, *AddressHeight) (*Response, error)
+	GetContractProof(context.Context, *AddressHeight) (*Response, error)
+	GetToken(context.Context, *Address) (*Response, error)
+	GetTokenAllowance(context.Context, *TokenAllowance) (*Response, error)
+	GetNFTCollection(context.Context, *Address) (*Response, error)
+	GetNFTsByCollection(context.Context, *AddressPage) (*Response, error)
+	GetNFTsByOwner(context.Context, *AddressPage) (*Response, error)
+	GetHTLCByHashLock(context.Context, *Hash) (*Response, error)
+	GetCandidates(context.Context, *Null) (*Response, error)
+	GetReward(context.Context, *Address) (*Response, error)
+	SealBlock(context.Context, *Null) (*Response, error)
 }
 
 // UnimplementedGreeterServer can be embedded to have forward compatible implementations.
@@ -484,6 +843,9 @@ type UnimplementedGreeterServer struct {
 func (*UnimplementedGreeterServer) SendTransaction(ctx context.Context, req *Bytes) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
 }
+func (*UnimplementedGreeterServer) SimulateTransaction(ctx context.Context, req *Bytes) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateTransaction not implemented")
+}
 func (*UnimplementedGreeterServer) GetAccount(ctx context.Context, req *Address) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
 }
@@ -517,6 +879,51 @@ func (*UnimplementedGreeterServer) NodeInfo(ctx context.Context, req *Null) (*Re
 func (*UnimplementedGreeterServer) GetExchangePairs(ctx context.Context, req *Address) (*Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExchangePairs not implemented")
 }
+func (*UnimplementedGreeterServer) GetAddressTransactions(ctx context.Context, req *AddressPage) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressTransactions not implemented")
+}
+func (*UnimplementedGreeterServer) GetAccountAtHeight(ctx context.Context, req *AddressHeight) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountAtHeight not implemented")
+}
+func (*UnimplementedGreeterServer) GetContractAtHeight(ctx context.Context, req *AddressHeight) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContractAtHeight not implemented")
+}
+func (*UnimplementedGreeterServer) GetExchangePairsAtHeight(ctx context.Context, req *AddressHeight) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangePairsAtHeight not implemented")
+}
+func (*UnimplementedGreeterServer) GetAccountProof(ctx context.Context, req *AddressHeight) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountProof not implemented")
+}
+func (*UnimplementedGreeterServer) GetContractProof(ctx context.Context, req *AddressHeight) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetContractProof not implemented")
+}
+func (*UnimplementedGreeterServer) GetToken(ctx context.Context, req *Address) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetToken not implemented")
+}
+func (*UnimplementedGreeterServer) GetTokenAllowance(ctx context.Context, req *TokenAllowance) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTokenAllowance not implemented")
+}
+func (*UnimplementedGreeterServer) GetNFTCollection(ctx context.Context, req *Address) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNFTCollection not implemented")
+}
+func (*UnimplementedGreeterServer) GetNFTsByCollection(ctx context.Context, req *AddressPage) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNFTsByCollection not implemented")
+}
+func (*UnimplementedGreeterServer) GetNFTsByOwner(ctx context.Context, req *AddressPage) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNFTsByOwner not implemented")
+}
+func (*UnimplementedGreeterServer) GetHTLCByHashLock(ctx context.Context, req *Hash) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHTLCByHashLock not implemented")
+}
+func (*UnimplementedGreeterServer) GetCandidates(ctx context.Context, req *Null) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandidates not implemented")
+}
+func (*UnimplementedGreeterServer) GetReward(ctx context.Context, req *Address) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReward not implemented")
+}
+func (*UnimplementedGreeterServer) SealBlock(ctx context.Context, req *Null) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SealBlock not implemented")
+}
 
 func RegisterGreeterServer(s *grpc.Server, srv GreeterServer) {
 	s.RegisterService(&_Greeter_serviceDesc, srv)
@@ -540,6 +947,24 @@ func _Greeter_SendTransaction_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Greeter_SimulateTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Bytes)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).SimulateTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/SimulateTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).SimulateTransaction(ctx, req.(*Bytes))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Greeter_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Address)
 	if err := dec(in); err != nil {
@@ -738,6 +1163,276 @@ func _Greeter_GetExchangePairs_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Greeter_GetAddressTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressPage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetAddressTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetAddressTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetAddressTransactions(ctx, req.(*AddressPage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetAccountAtHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetAccountAtHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetAccountAtHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetAccountAtHeight(ctx, req.(*AddressHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetContractAtHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetContractAtHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetContractAtHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetContractAtHeight(ctx, req.(*AddressHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetExchangePairsAtHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetExchangePairsAtHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetExchangePairsAtHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetExchangePairsAtHeight(ctx, req.(*AddressHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetAccountProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetAccountProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetAccountProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetAccountProof(ctx, req.(*AddressHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetContractProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetContractProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetContractProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetContractProof(ctx, req.(*AddressHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Address)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetToken(ctx, req.(*Address))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetTokenAllowance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenAllowance)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetTokenAllowance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetTokenAllowance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetTokenAllowance(ctx, req.(*TokenAllowance))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetNFTCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Address)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetNFTCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetNFTCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetNFTCollection(ctx, req.(*Address))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetNFTsByCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressPage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetNFTsByCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetNFTsByCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetNFTsByCollection(ctx, req.(*AddressPage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetNFTsByOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressPage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetNFTsByOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetNFTsByOwner",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetNFTsByOwner(ctx, req.(*AddressPage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetHTLCByHashLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Hash)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetHTLCByHashLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetHTLCByHashLock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetHTLCByHashLock(ctx, req.(*Hash))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Null)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetCandidates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetCandidates(ctx, req.(*Null))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetReward_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Address)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetReward(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/GetReward",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetReward(ctx, req.(*Address))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_SealBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Null)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).SealBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Greeter/SealBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).SealBlock(ctx, req.(*Null))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Greeter_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpc.Greeter",
 	HandlerType: (*GreeterServer)(nil),
@@ -746,6 +1441,10 @@ var _Greeter_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendTransaction",
 			Handler:    _Greeter_SendTransaction_Handler,
 		},
+		{
+			MethodName: "SimulateTransaction",
+			Handler:    _Greeter_SimulateTransaction_Handler,
+		},
 		{
 			MethodName: "GetAccount",
 			Handler:    _Greeter_GetAccount_Handler,
@@ -790,6 +1489,66 @@ var _Greeter_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetExchangePairs",
 			Handler:    _Greeter_GetExchangePairs_Handler,
 		},
+		{
+			MethodName: "GetAddressTransactions",
+			Handler:    _Greeter_GetAddressTransactions_Handler,
+		},
+		{
+			MethodName: "GetAccountAtHeight",
+			Handler:    _Greeter_GetAccountAtHeight_Handler,
+		},
+		{
+			MethodName: "GetContractAtHeight",
+			Handler:    _Greeter_GetContractAtHeight_Handler,
+		},
+		{
+			MethodName: "GetExchangePairsAtHeight",
+			Handler:    _Greeter_GetExchangePairsAtHeight_Handler,
+		},
+		{
+			MethodName: "GetAccountProof",
+			Handler:    _Greeter_GetAccountProof_Handler,
+		},
+		{
+			MethodName: "GetContractProof",
+			Handler:    _Greeter_GetContractProof_Handler,
+		},
+		{
+			MethodName: "GetToken",
+			Handler:    _Greeter_GetToken_Handler,
+		},
+		{
+			MethodName: "GetTokenAllowance",
+			Handler:    _Greeter_GetTokenAllowance_Handler,
+		},
+		{
+			MethodName: "GetNFTCollection",
+			Handler:    _Greeter_GetNFTCollection_Handler,
+		},
+		{
+			MethodName: "GetNFTsByCollection",
+			Handler:    _Greeter_GetNFTsByCollection_Handler,
+		},
+		{
+			MethodName: "GetNFTsByOwner",
+			Handler:    _Greeter_GetNFTsByOwner_Handler,
+		},
+		{
+			MethodName: "GetHTLCByHashLock",
+			Handler:    _Greeter_GetHTLCByHashLock_Handler,
+		},
+		{
+			MethodName: "GetCandidates",
+			Handler:    _Greeter_GetCandidates_Handler,
+		},
+		{
+			MethodName: "GetReward",
+			Handler:    _Greeter_GetReward_Handler,
+		},
+		{
+			MethodName: "SealBlock",
+			Handler:    _Greeter_SealBlock_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc.proto",
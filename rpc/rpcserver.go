@@ -16,8 +16,10 @@ import (
 	"github.com/uworldao/UWORLD/crypto/certgen"
 	log "github.com/uworldao/UWORLD/log/log15"
 	"github.com/uworldao/UWORLD/p2p"
+	"github.com/uworldao/UWORLD/param"
 	"github.com/uworldao/UWORLD/rpc/rpctypes"
 	"github.com/uworldao/UWORLD/services/reqmgr"
+	"github.com/uworldao/UWORLD/ut"
 	"golang.org/x/net/context"
 	"golang.org/x/net/http2"
 	"google.golang.org/grpc"
@@ -226,6 +228,28 @@ func (rs *Server) SendTransaction(_ context.Context, req *Bytes) (*Response, err
 	return NewResponse(rpctypes.RpcSuccess, []byte(fmt.Sprintf("send transaction %s success", tx.Hash().String())), ""), nil
 }
 
+// Run the transaction against a copy of the head state, it is
+// neither committed nor added to the tx pool
+func (rs *Server) SimulateTransaction(_ context.Context, req *Bytes) (*Response, error) {
+	var rpcTx *coreTypes.RpcTransaction
+	if err := json.Unmarshal(req.Bytes, &rpcTx); err != nil {
+		return NewResponse(rpctypes.RpcErrParam, nil, err.Error()), nil
+	}
+	tx, err := coreTypes.TranslateRpcTxToTx(rpcTx)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrParam, nil, err.Error()), nil
+	}
+	simulation, err := rs.chain.SimulateTransaction(tx)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	bytes, err := json.Marshal(rpctypes.TranslateSimulation(simulation))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
 func (rs *Server) GetAccount(_ context.Context, req *Address) (*Response, error) {
 	addr := hasharry.StringToAddress(req.Address)
 	account := rs.accountState.GetAccountState(addr)
@@ -259,11 +283,14 @@ func (rs *Server) GetTransaction(ctx context.Context, req *Hash) (*Response, err
 	} else {
 		rpcTx, _ = coreTypes.TranslateTxToRpcTx(tx.(*coreTypes.Transaction))
 	}
+	// Blocks saved before the receipts were stored have none
+	receipt, _ := rs.chain.GetReceipt(hash)
 	rsMsg := &coreTypes.RpcTransactionConfirmed{
 		TxHead:    rpcTx.TxHead,
 		TxBody:    rpcTx.TxBody,
 		Height:    height,
 		Confirmed: confirmed >= height,
+		Receipt:   coreTypes.TranslateReceiptToRpcReceipt(receipt),
 	}
 	bytes, _ := json.Marshal(rsMsg)
 	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
@@ -322,6 +349,34 @@ func (rs *Server) GetCandidates(context.Context, *Null) (*Response, error) {
 	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
 }
 
+func (rs *Server) GetReward(_ context.Context, req *Address) (*Response, error) {
+	if !ut.CheckUWDAddress(param.Net, req.Address) {
+		return NewResponse(rpctypes.RpcErrParam, nil, "wrong address"), nil
+	}
+	reward := &coreTypes.RpcReward{
+		Address: req.Address,
+		Reward:  rs.consensus.GetReward(hasharry.StringToAddress(req.Address)),
+	}
+	bytes, err := json.Marshal(reward)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Seal the next block of devseal, the result is the height of the block
+func (rs *Server) SealBlock(context.Context, *Null) (*Response, error) {
+	sealer, ok := rs.consensus.(consensus.ISealer)
+	if !ok {
+		return NewResponse(rpctypes.RpcErrDPos, nil, "the consensus does not seal blocks on request"), nil
+	}
+	if err := sealer.Seal(); err != nil {
+		return NewResponse(rpctypes.RpcErrDPos, nil, err.Error()), nil
+	}
+	sHeight := strconv.FormatUint(rs.chain.GetLastHeight()+1, 10)
+	return NewResponse(rpctypes.RpcSuccess, []byte(sHeight), ""), nil
+}
+
 func (rs *Server) GetLastHeight(context.Context, *Null) (*Response, error) {
 	height := rs.chain.GetLastHeight()
 	sHeight := strconv.FormatUint(height, 10)
@@ -367,6 +422,198 @@ func (rs *Server) GetExchangePairs(ctx context.Context, req *Address) (*Response
 	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
 }
 
+// Get the transactions of an address from the newest, the
+// node must be started with the address index
+func (rs *Server) GetAddressTransactions(ctx context.Context, req *AddressPage) (*Response, error) {
+	addr := hasharry.StringToAddress(req.Address)
+	addrTxs, total, err := rs.chain.GetAddressTransactions(addr, req.Start, req.Count)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	result := rpctypes.TranslateAddressTxs(req.Address, req.Start, total, rs.chain.GetConfirmedHeight(), addrTxs)
+	bytes, err := json.Marshal(result)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the account as it was after the block of the height
+func (rs *Server) GetAccountAtHeight(ctx context.Context, req *AddressHeight) (*Response, error) {
+	accountState, _, err := rs.chain.StateAt(req.Height)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	account := accountState.GetAccountState(hasharry.StringToAddress(req.Address))
+	rpcAccount := rpctypes.TranslateAccountToRpcAccount(account.(*coreTypes.Account))
+	bytes, err := json.Marshal(rpcAccount)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the contract as it was after the block of the height
+func (rs *Server) GetContractAtHeight(ctx context.Context, req *AddressHeight) (*Response, error) {
+	_, contractState, err := rs.chain.StateAt(req.Height)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	contract := contractState.GetContract(req.Address)
+	if contract == nil {
+		return NewResponse(rpctypes.RpcErrContract, nil, fmt.Sprintf("contract address %s is not exist at height %d", req.Address, req.Height)), nil
+	}
+	bytes, err := json.Marshal(coreTypes.TranslateContractToRpcContract(contract))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the pairs and reserves of an exchange as they were after
+// the block of the height
+func (rs *Server) GetExchangePairsAtHeight(ctx context.Context, req *AddressHeight) (*Response, error) {
+	accountState, contractState, err := rs.chain.StateAt(req.Height)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	pairs, err := runner.NewContractRunner(accountState, contractState).ExchangePair(hasharry.StringToAddress(req.Address))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrContract, nil, err.Error()), nil
+	}
+	bytes, _ := json.Marshal(pairs)
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the encoded account and its proof against the state root after the
+// block of the height, the same state as GetAccountAtHeight. The root is
+// the StateRoot of the header at height+1, the last height has no proof.
+func (rs *Server) GetAccountProof(ctx context.Context, req *AddressHeight) (*Response, error) {
+	stateRoot, _, _, err := rs.chain.HeaderRootsAt(req.Height)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	accountState, err := rs.accountState.StateAt(stateRoot, 0)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	account, proof, err := accountState.GetAccountProof(hasharry.StringToAddress(req.Address))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	bytes, err := json.Marshal(rpctypes.TranslateAccountProof(req.Address, req.Height, stateRoot, account, proof))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the encoded contract and its proof against the contract root after
+// the block of the height, the same state as GetContractAtHeight. The root
+// is the ContractRoot of the header at height+1, the last height has no proof.
+func (rs *Server) GetContractProof(ctx context.Context, req *AddressHeight) (*Response, error) {
+	_, contractRoot, _, err := rs.chain.HeaderRootsAt(req.Height)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	contractState, err := rs.contractState.StateAt(contractRoot, 0)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrBlockChain, nil, err.Error()), nil
+	}
+	var keyVersion uint8 = ut.ContractKeyV2
+	if contractState.GetContract(req.Address) != nil {
+		keyVersion = ut.ContractKeyV1
+	}
+	key, _ := ut.ContractProofKey(req.Address, keyVersion)
+	contract, proof, err := contractState.GetContractProof(key)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrContract, nil, err.Error()), nil
+	}
+	bytes, err := json.Marshal(rpctypes.TranslateContractProof(req.Address, req.Height, contractRoot, keyVersion, contract, proof))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the name, symbol, decimals and supply of a token contract
+func (rs *Server) GetToken(ctx context.Context, req *Address) (*Response, error) {
+	token, err := rs.runner.Token(hasharry.StringToAddress(req.Address))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrContract, nil, err.Error()), nil
+	}
+	bytes, err := json.Marshal(token)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the amount of a token the spender may still transfer from the owner
+func (rs *Server) GetTokenAllowance(ctx context.Context, req *TokenAllowance) (*Response, error) {
+	allowance, err := rs.runner.TokenAllowance(hasharry.StringToAddress(req.Token),
+		hasharry.StringToAddress(req.Owner), hasharry.StringToAddress(req.Spender))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrContract, nil, err.Error()), nil
+	}
+	bytes, err := json.Marshal(allowance)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+func (rs *Server) GetNFTCollection(ctx context.Context, req *Address) (*Response, error) {
+	collection, err := rs.runner.NFTCollection(hasharry.StringToAddress(req.Address))
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrContract, nil, err.Error()), nil
+	}
+	bytes, err := json.Marshal(collection)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the tokens of a collection whose ids are greater than start
+func (rs *Server) GetNFTsByCollection(ctx context.Context, req *AddressPage) (*Response, error) {
+	page, err := rs.runner.NFTsByCollection(hasharry.StringToAddress(req.Address), req.Start, req.Count)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrContract, nil, err.Error()), nil
+	}
+	bytes, err := json.Marshal(page)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+func (rs *Server) GetNFTsByOwner(ctx context.Context, req *AddressPage) (*Response, error) {
+	page := rs.runner.NFTsByOwner(hasharry.StringToAddress(req.Address), req.Start, req.Count)
+	bytes, err := json.Marshal(page)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
+// Get the swaps locked by the hash
+func (rs *Server) GetHTLCByHashLock(ctx context.Context, req *Hash) (*Response, error) {
+	hashLock, err := hasharry.StringToHash(req.Hash)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrParam, nil, "hash lock error"), nil
+	}
+	swaps, err := rs.runner.HTLCsByHashLock(hashLock)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrContract, nil, err.Error()), nil
+	}
+	bytes, err := json.Marshal(swaps)
+	if err != nil {
+		return NewResponse(rpctypes.RpcErrMarshal, nil, err.Error()), nil
+	}
+	return NewResponse(rpctypes.RpcSuccess, bytes, ""), nil
+}
+
 func NewResponse(code int32, result []byte, err string) *Response {
 	return &Response{Code: code, Result: result, Err: err}
 }
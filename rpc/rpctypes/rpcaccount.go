@@ -10,6 +10,7 @@ type Account struct {
 	Nonce           uint64         `json:"nonce"`
 	Time            uint64         `json:"time"`
 	Coins           []*CoinAccount `json:"coins"`
+	Locks           []*Lock        `json:"locks"`
 	ConfirmedHeight uint64         `json:"confirmedheight"`
 	ConfirmedNonce  uint64         `json:"confirmednonce"`
 	ConfirmedTime   uint64         `json:"confirmedtime"`
@@ -20,16 +21,51 @@ type CoinAccount struct {
 	Balance   float64 `json:"balance"`
 	LockedOut float64 `json:"lockedout"`
 	LockedIn  float64 `json:"lockedin"`
+	Locked    float64 `json:"locked"`
+}
+
+type Lock struct {
+	Contract string  `json:"contract"`
+	Amount   float64 `json:"amount"`
+	Total    float64 `json:"total"`
+	Start    uint64  `json:"start"`
+	End      uint64  `json:"end"`
+	Height   uint64  `json:"height"`
+	// The lock is the bond of a candidate
+	Bond bool `json:"bond,omitempty"`
 }
 
 func TranslateAccountToRpcAccount(account *types.Account) *Account {
 	coins := []*CoinAccount{}
+	listed := map[string]bool{}
 	for _, coinAccount := range *account.Coins {
+		listed[coinAccount.Contract] = true
 		coins = append(coins, &CoinAccount{
 			Contract:  coinAccount.Contract,
 			LockedOut: types.Amount(coinAccount.LockOut).ToCoin(),
 			LockedIn:  types.Amount(coinAccount.LockIn).ToCoin(),
 			Balance:   types.Amount(coinAccount.Balance).ToCoin(),
+			Locked:    types.Amount(account.GetLocked(coinAccount.Contract)).ToCoin(),
+		})
+	}
+	locks := []*Lock{}
+	for _, lock := range account.Locks {
+		// Coins that are only locked have no coin account yet
+		if !listed[lock.Contract] {
+			listed[lock.Contract] = true
+			coins = append(coins, &CoinAccount{
+				Contract: lock.Contract,
+				Locked:   types.Amount(account.GetLocked(lock.Contract)).ToCoin(),
+			})
+		}
+		locks = append(locks, &Lock{
+			Contract: lock.Contract,
+			Amount:   types.Amount(lock.Amount).ToCoin(),
+			Total:    types.Amount(lock.Total).ToCoin(),
+			Start:    lock.Start,
+			End:      lock.End,
+			Height:   lock.Height,
+			Bond:     lock.IsBond(),
 		})
 	}
 	rpcAccount := &Account{
@@ -37,6 +73,7 @@ func TranslateAccountToRpcAccount(account *types.Account) *Account {
 		Nonce:           account.Nonce,
 		Time:            account.Time,
 		Coins:           coins,
+		Locks:           locks,
 		ConfirmedHeight: account.ConfirmedHeight,
 		ConfirmedNonce:  account.ConfirmedNonce,
 		ConfirmedTime:   account.ConfirmedTime,
@@ -0,0 +1,37 @@
+package rpctypes
+
+import (
+	"github.com/uworldao/UWORLD/core/types"
+)
+
+type AddressTransactions struct {
+	Address string       `json:"address"`
+	Total   uint64       `json:"total"`
+	Start   uint64       `json:"start"`
+	Txs     []*AddressTx `json:"txs"`
+}
+
+type AddressTx struct {
+	TxHash    string `json:"txhash"`
+	Height    uint64 `json:"height"`
+	TxIndex   uint32 `json:"txindex"`
+	Confirmed bool   `json:"confirmed"`
+}
+
+func TranslateAddressTxs(address string, start, total, confirmed uint64, addrTxs []*types.AddressTx) *AddressTransactions {
+	txs := make([]*AddressTx, 0, len(addrTxs))
+	for _, addrTx := range addrTxs {
+		txs = append(txs, &AddressTx{
+			TxHash:    addrTx.TxHash.String(),
+			Height:    addrTx.Height,
+			TxIndex:   addrTx.TxIndex,
+			Confirmed: confirmed >= addrTx.Height,
+		})
+	}
+	return &AddressTransactions{
+		Address: address,
+		Total:   total,
+		Start:   start,
+		Txs:     txs,
+	}
+}
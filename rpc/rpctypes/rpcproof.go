@@ -0,0 +1,65 @@
+package rpctypes
+
+import (
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/common/hexutil"
+)
+
+type AccountProof struct {
+	Address   string   `json:"address"`
+	Height    uint64   `json:"height"`
+	StateRoot string   `json:"stateroot"`
+	Account   string   `json:"account"`
+	Proof     []string `json:"proof"`
+}
+
+type ContractProof struct {
+	Address      string   `json:"address"`
+	Height       uint64   `json:"height"`
+	ContractRoot string   `json:"contractroot"`
+	KeyVersion   uint8    `json:"keyversion"`
+	Contract     string   `json:"contract"`
+	Proof        []string `json:"proof"`
+}
+
+func TranslateAccountProof(address string, height uint64, stateRoot hasharry.Hash, account []byte, proof [][]byte) *AccountProof {
+	return &AccountProof{
+		Address:   address,
+		Height:    height,
+		StateRoot: stateRoot.String(),
+		Account:   hexutil.Encode(account),
+		Proof:     encodeProof(proof),
+	}
+}
+
+func TranslateContractProof(address string, height uint64, contractRoot hasharry.Hash, keyVersion uint8, contract []byte, proof [][]byte) *ContractProof {
+	return &ContractProof{
+		Address:      address,
+		Height:       height,
+		ContractRoot: contractRoot.String(),
+		KeyVersion:   keyVersion,
+		Contract:     hexutil.Encode(contract),
+		Proof:        encodeProof(proof),
+	}
+}
+
+// Decode the hex proof nodes returned by rpc
+func DecodeProof(proof []string) ([][]byte, error) {
+	nodes := make([][]byte, 0, len(proof))
+	for _, node := range proof {
+		bytes, err := hexutil.Decode(node)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, bytes)
+	}
+	return nodes, nil
+}
+
+func encodeProof(proof [][]byte) []string {
+	nodes := make([]string, 0, len(proof))
+	for _, node := range proof {
+		nodes = append(nodes, hexutil.Encode(node))
+	}
+	return nodes
+}
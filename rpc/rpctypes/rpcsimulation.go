@@ -0,0 +1,48 @@
+package rpctypes
+
+import (
+	"github.com/uworldao/UWORLD/core/types"
+)
+
+type Simulation struct {
+	TxHash  string                  `json:"txhash"`
+	Height  uint64                  `json:"height"`
+	Fees    float64                 `json:"fees"`
+	State   *types.RpcContractState `json:"state,omitempty"`
+	Changes []*BalanceChange        `json:"changes"`
+	Error   string                  `json:"error"`
+}
+
+type BalanceChange struct {
+	Address   string  `json:"address"`
+	Contract  string  `json:"contract"`
+	Balance   float64 `json:"balance"`
+	LockedIn  float64 `json:"lockedin"`
+	LockedOut float64 `json:"lockedout"`
+	Locked    float64 `json:"locked"`
+}
+
+func TranslateSimulation(simulation *types.Simulation) *Simulation {
+	changes := make([]*BalanceChange, 0, len(simulation.Changes))
+	for _, change := range simulation.Changes {
+		changes = append(changes, &BalanceChange{
+			Address:   change.Address.String(),
+			Contract:  change.Contract,
+			Balance:   types.Amount(change.Balance).ToCoin(),
+			LockedIn:  types.Amount(change.LockedIn).ToCoin(),
+			LockedOut: types.Amount(change.LockedOut).ToCoin(),
+			Locked:    types.Amount(change.Locked).ToCoin(),
+		})
+	}
+	rpcSimulation := &Simulation{
+		TxHash:  simulation.TxHash.String(),
+		Height:  simulation.Height,
+		Fees:    types.Amount(simulation.Fees).ToCoin(),
+		Changes: changes,
+		Error:   simulation.Error,
+	}
+	if simulation.State != nil {
+		rpcSimulation.State = types.TranslateContractV2State(simulation.State)
+	}
+	return rpcSimulation
+}
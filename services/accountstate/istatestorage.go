@@ -3,15 +3,21 @@ package accountstate
 import (
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/database/statedb"
 )
 
 // Storage interface for account balance information
 type IAccountStorage interface {
 	InitTrie(stateRoot hasharry.Hash) error
+	CopyAt(stateRoot hasharry.Hash) (*statedb.StateStorage, error)
+	DumpTrie(stateRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error
+	TrieNode(hash hasharry.Hash) ([]byte, error)
+	SyncTrie(stateRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error
 	GetAccountState(stateKey hasharry.Address) types.IAccount
 	SetAccountState(account types.IAccount)
 	GetAccountBalance(stateKey hasharry.Address, contract string) uint64
 	GetAccountNonce(stateKey hasharry.Address) uint64
+	Prove(stateKey hasharry.Address) ([]byte, [][]byte, error)
 	DeleteAccount(stateKey hasharry.Address)
 	Commit() (hasharry.Hash, error)
 	RootHash() hasharry.Hash
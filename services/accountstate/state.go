@@ -3,6 +3,7 @@ package accountstate
 import (
 	"errors"
 	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/interface"
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/database/statedb"
 	"github.com/uworldao/UWORLD/param"
@@ -35,6 +36,35 @@ func (as *AccountState) InitTrie(stateRoot hasharry.Hash) error {
 	return as.stateDb.InitTrie(stateRoot)
 }
 
+// Open a read-only account state at the state root with the
+// confirmed height of that time, the live trie is not touched
+func (as *AccountState) StateAt(stateRoot hasharry.Hash, confirmedHeight uint64) (_interface.IAccountState, error) {
+	storage, err := as.stateDb.CopyAt(stateRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountState{
+		stateDb:         storage,
+		confirmedHeight: confirmedHeight,
+	}, nil
+}
+
+// Walk the stored nodes of the state trie of the root
+func (as *AccountState) DumpTrie(stateRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error {
+	return as.stateDb.DumpTrie(stateRoot, fn)
+}
+
+// Read the stored node of the state trie
+func (as *AccountState) TrieNode(hash hasharry.Hash) ([]byte, error) {
+	return as.stateDb.TrieNode(hash)
+}
+
+// Store the state trie of the root with the nodes from fn, it
+// does not change the current root
+func (as *AccountState) SyncTrie(stateRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error {
+	return as.stateDb.SyncTrie(stateRoot, fn)
+}
+
 // Get account status, if the account status needs to be updated
 // according to the effective block height, it will be updated,
 // but not stored.
@@ -65,6 +95,14 @@ func (as *AccountState) GetAccountNonce(stateKey hasharry.Address) (uint64, erro
 	return as.stateDb.GetAccountNonce(stateKey), nil
 }
 
+// Get the encoded account and the proof of it against the state root
+func (as *AccountState) GetAccountProof(stateKey hasharry.Address) ([]byte, [][]byte, error) {
+	as.accountMutex.RLock()
+	defer as.accountMutex.RUnlock()
+
+	return as.stateDb.Prove(stateKey)
+}
+
 func (as *AccountState) setAccountState(account types.IAccount) {
 	as.stateDb.SetAccountState(account)
 }
@@ -93,6 +131,41 @@ func (as *AccountState) UpdateContractFrom(tx types.ITransaction, blockHeight ui
 	return nil
 }
 
+// Update the sender of a candidate transaction, it locks or unbonds
+// the bond of the candidate
+func (as *AccountState) UpdateCandidateFrom(tx types.ITransaction, blockHeight uint64) error {
+	as.accountMutex.Lock()
+	defer as.accountMutex.Unlock()
+
+	fromAccount := as.stateDb.GetAccountState(tx.From())
+	err := fromAccount.Update(as.confirmedHeight)
+	if err != nil {
+		return err
+	}
+
+	err = fromAccount.CandidateChangeFrom(tx, blockHeight)
+	if err != nil {
+		return err
+	}
+
+	as.setAccountState(fromAccount)
+	return nil
+}
+
+// SlashBond burns the percentage of the bond of the address
+func (as *AccountState) SlashBond(address hasharry.Address, percent uint64) error {
+	as.accountMutex.Lock()
+	defer as.accountMutex.Unlock()
+
+	account := as.stateDb.GetAccountState(address)
+	if err := account.Update(as.confirmedHeight); err != nil {
+		return err
+	}
+	account.SlashBond(percent)
+	as.setAccountState(account)
+	return nil
+}
+
 // UpdateTransferFrom Update sender account status based on transaction information
 func (as *AccountState) UpdateTransferFrom(tx types.ITransaction, blockHeight uint64) error {
 	if tx.IsCoinBase() {
@@ -163,6 +236,32 @@ func (as *AccountState) UpdateTransferV2To(tx types.ITransaction, blockHeight ui
 	return nil
 }
 
+// Add the coins of a locked transfer to the locks of the receiver
+func (as *AccountState) UpdateLockedTransferTo(tx types.ITransaction, blockHeight uint64) error {
+	body, ok := tx.GetTxBody().(*types.LockedTransferBody)
+	if !ok {
+		return errors.New("wrong transaction body")
+	}
+
+	as.accountMutex.Lock()
+	defer as.accountMutex.Unlock()
+
+	re := body.ToAddress().ReceiverList()[0]
+	toAccount := as.stateDb.GetAccountState(re.Address)
+	err := toAccount.Update(as.confirmedHeight)
+	if err != nil {
+		return err
+	}
+
+	err = toAccount.LockedTransferChangeTo(re, body.Lock(blockHeight))
+	if err != nil {
+		return err
+	}
+
+	as.setAccountState(toAccount)
+	return nil
+}
+
 func (as *AccountState) UpdateTransferTo(tx types.ITransaction, blockHeight uint64) error {
 	as.accountMutex.Lock()
 	defer as.accountMutex.Unlock()
@@ -311,6 +410,50 @@ func (as *AccountState) VerifyState(tx types.ITransaction) error {
 		return as.verifyTxState(tx)
 	}
 }
+
+// Verify a transaction of a block against the sender account as it is
+// after the earlier transactions of the sender in the block, so the
+// nonces of a sender must follow each other. The changed sender accounts
+// are kept in pending, which is empty at the start of the block and is
+// never stored.
+func (as *AccountState) VerifyPendingState(tx types.ITransaction, pending map[hasharry.Address]types.IAccount, blockHeight uint64) error {
+	if tx.GetTime() > uint64(time.Now().Unix()) {
+		return errors.New("incorrect transaction time")
+	}
+	account, ok := pending[tx.From()]
+	if !ok {
+		account = as.GetAccountState(tx.From())
+	}
+	if err := account.VerifyTxState(tx); err != nil {
+		return err
+	}
+
+	var err error
+	switch tx.GetTxType() {
+	case types.Transfer_:
+		if err = account.VerifyNonce(tx.GetNonce()); err == nil {
+			err = account.TransferChangeFrom(tx, blockHeight)
+		}
+	case types.TransferV2_, types.LockedTransfer_:
+		if err = account.VerifyNonce(tx.GetNonce()); err == nil {
+			err = account.TransferV2ChangeFrom(tx, blockHeight)
+		}
+	case types.Contract_, types.ContractV2_, types.DoubleSign_, types.SetCommission_, types.ClaimReward_:
+		if err = account.VerifyNonce(tx.GetNonce()); err == nil {
+			err = account.ContractChangeFrom(tx, blockHeight)
+		}
+	case types.LoginCandidate_, types.VoteToCandidate_, types.LogoutCandidate_:
+		if err = account.VerifyNonce(tx.GetNonce()); err == nil {
+			err = account.CandidateChangeFrom(tx, blockHeight)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	pending[tx.From()] = account
+	return nil
+}
+
 func (as *AccountState) Transfer(from, to, token hasharry.Address, amount uint64, height uint64) error {
 	as.accountMutex.Lock()
 	defer as.accountMutex.Unlock()
@@ -0,0 +1,86 @@
+package accountstate
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+)
+
+var (
+	testSender   = hasharry.StringToAddress("UWDM1qcsk7UUNANMPKSpALJW7AqpDCy7tdoN")
+	testReceiver = hasharry.StringToAddress("UWDR5oWfEjnGcEnQJrknuNW3LxZeWSpZUPJ5")
+)
+
+func newTestTransfer(nonce, amount uint64) types.ITransaction {
+	return &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.Transfer_,
+			From:       testSender,
+			Nonce:      nonce,
+			Fees:       param.Fees,
+			Time:       1600000000,
+			SignScript: &types.SignScript{},
+		},
+		TxBody: &types.TransferBody{
+			Contract: param.Token,
+			To:       testReceiver,
+			Amount:   amount,
+		},
+	}
+}
+
+func TestVerifyPendingState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "accountstate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	as, err := NewAccountState(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer as.Close()
+	if err := as.InitTrie(hasharry.Hash{}); err != nil {
+		t.Fatal(err)
+	}
+	account := types.NewAccount(testSender)
+	account.Coins.Set(&types.CoinAccount{Contract: param.Token.String(), Balance: 10 * param.AtomsPerCoin})
+	as.setAccountState(account)
+
+	pending := make(map[hasharry.Address]types.IAccount)
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		if err := as.VerifyPendingState(newTestTransfer(nonce, 4*param.AtomsPerCoin), pending, 1); err != nil {
+			t.Fatalf("nonce %d: %v", nonce, err)
+		}
+	}
+	if err := as.VerifyPendingState(newTestTransfer(2, 1*param.AtomsPerCoin), pending, 1); err == nil {
+		t.Fatal("a nonce of the block is accepted again")
+	}
+	if err := as.VerifyPendingState(newTestTransfer(4, 1*param.AtomsPerCoin), pending, 1); err == nil {
+		t.Fatal("a transaction after a nonce gap is accepted")
+	}
+	// The earlier transactions of the block spent 8 of the 10 coins
+	if err := as.VerifyPendingState(newTestTransfer(3, 4*param.AtomsPerCoin), pending, 1); err == nil {
+		t.Fatal("the coins spent by the earlier transactions are spent again")
+	}
+	if err := as.VerifyPendingState(newTestTransfer(3, 2*param.AtomsPerCoin), pending, 1); err != nil {
+		t.Fatal(err)
+	}
+	if pending[testSender].GetNonce() != 3 {
+		t.Fatalf("pending nonce %d, want 3", pending[testSender].GetNonce())
+	}
+
+	// The stored account is not changed
+	stored := as.GetAccountState(testSender)
+	if stored.GetNonce() != 0 || stored.GetBalance(param.Token.String()) != 10*param.AtomsPerCoin {
+		t.Fatalf("stored account changed to nonce %d balance %d", stored.GetNonce(), stored.GetBalance(param.Token.String()))
+	}
+	// A new block starts from the stored account
+	if err := as.VerifyPendingState(newTestTransfer(1, 4*param.AtomsPerCoin), make(map[hasharry.Address]types.IAccount), 2); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -153,19 +153,19 @@ func (bm *BlockManager) syncBlockFromStream() error {
 			if err != nil {
 				return err
 			}
-			if err := bm.insertBlocksToChain(blocks, syncPeer.AddrInfo.String()); err != nil {
+			if err := bm.insertBlocksToChain(blocks, syncPeer); err != nil {
 				return err
 			}
 		}
 	}
 }
 
-func (bm *BlockManager) insertBlocksToChain(blocks []*types.Block, peerAddr string) error {
+func (bm *BlockManager) insertBlocksToChain(blocks []*types.Block, syncPeer *p2p.PeerInfo) error {
 	var err error
 	var start, end uint64
 	defer func() {
 		if err == nil {
-			log.Info("Sync blocks", "blocks", fmt.Sprintf("%d-%d", start, end), "peer", peerAddr)
+			log.Info("Sync blocks", "blocks", fmt.Sprintf("%d-%d", start, end), "peer", syncPeer.AddrInfo.String())
 		}
 	}()
 
@@ -179,20 +179,18 @@ func (bm *BlockManager) insertBlocksToChain(blocks []*types.Block, peerAddr stri
 			return nil
 		default:
 			if err = bm.blockChain.InsertChain(block); err != nil {
-				log.Warn("Insert chain failed!", "error", err, "height", block.Height, "hash", block.Hash, "signer", block.Signer)
 				if err == core.ErrDuplicateBlock {
 					continue
 				}
-				if ok, peerId := bm.IsFallBack(block.Header); ok {
-					bm.fallBack()
-					if peerInfo := bm.peerManager.GetPeer(peerId); peerInfo == nil {
-						return err
-					} else {
-						bm.setSyncStream(peerInfo)
-						return nil
-					}
+				// The peer is on another branch, fetch the blocks from
+				// the fork and let the fork choice decide between them
+				if err == core.ErrNoParent {
+					err = bm.insertBranch(syncPeer.StreamCreator, block)
+				}
+				if err != nil {
+					log.Warn("Insert chain failed!", "error", err, "height", block.Height, "hash", block.Hash, "signer", block.Signer)
+					return err
 				}
-				return err
 			}
 		}
 		end = block.Height
@@ -201,6 +199,37 @@ func (bm *BlockManager) insertBlocksToChain(blocks []*types.Block, peerAddr stri
 	return nil
 }
 
+// Get the unknown ancestors of the block from the peer back to a
+// locally known block, then insert the whole branch.
+func (bm *BlockManager) insertBranch(creator *p2p.StreamCreator, block *types.Block) error {
+	confirmedHeight := bm.blockChain.GetConfirmedHeight()
+	branch := []*types.Block{block}
+	parentHash := block.ParentHash
+	for {
+		if _, err := bm.blockChain.GetHeaderByHash(parentHash); err == nil {
+			break
+		}
+		if branch[0].Height <= confirmedHeight+1 {
+			return core.ErrBelowConfirmed
+		}
+		parent, err := bm.network.GetBlockByHash(creator, parentHash)
+		if err != nil {
+			return err
+		}
+		if !parent.Hash.IsEqual(parentHash) {
+			return fmt.Errorf("peer returned block %s instead of %s", parent.HashString(), parentHash.String())
+		}
+		branch = append([]*types.Block{parent}, branch...)
+		parentHash = parent.ParentHash
+	}
+	for _, block := range branch {
+		if err := bm.blockChain.InsertChain(block); err != nil && err != core.ErrDuplicateBlock {
+			return err
+		}
+	}
+	return nil
+}
+
 func (bm *BlockManager) remoteValidation(header *types.Header) bool {
 	var hashCount = 0
 	if header.Height <= bm.consensus.GetConfirmedBlockHeader(bm.blockChain).Height {
@@ -237,49 +266,6 @@ func (bm *BlockManager) remoteValidation(header *types.Header) bool {
 	return false
 }
 
-func (bm *BlockManager) IsFallBack(header *types.Header) (bool, string) {
-	localLast := bm.blockChain.GetLastHeight()
-	var maxLast uint64 = 0
-	var maxPeer = new(peer.ID)
-	ids, err := bm.consensus.GetWinnersPeerID(header.Time)
-	if err != nil {
-		return false, ""
-	}
-	for _, id := range ids {
-		if id != bm.peerManager.LocalPeerInfo().AddrInfo.ID.String() {
-			peerId := new(peer.ID)
-			if err = peerId.UnmarshalText([]byte(id)); err == nil {
-				streamCreator := p2p.StreamCreator{PeerId: *peerId, NewStreamFunc: bm.newStream.CreateStream}
-				height, err := bm.network.GetLastHeight(&streamCreator)
-				log.Info("Remote height!", "height", height, "peer", id)
-				if err == nil {
-					if height > maxLast {
-						maxLast = height
-						maxPeer = peerId
-					}
-				} else if err != nil {
-					log.Error("Get last height failed!", "err", err.Error(), "peer", id)
-				}
-			}
-		}
-	}
-
-	if maxLast > localLast {
-		log.Info("Find the highest node", "remote height", maxLast, "local height", localLast, "remote peer", maxPeer.String())
-		streamCreator := p2p.StreamCreator{PeerId: *maxPeer, NewStreamFunc: bm.newStream.CreateStream}
-		ok, err := bm.network.ValidationBlockHash(&streamCreator, header)
-		if ok {
-			return true, maxPeer.String()
-		} else if err != nil {
-			log.Error("Failed to validation block hash!", "hash", header.Hash, "err", err.Error(), "remote peer", maxPeer.String())
-		} else {
-			return false, ""
-		}
-	}
-
-	return false, ""
-}
-
 // Remotely verify the block, if the block height is less than
 // the effective block height, then discard the block. If the
 // block occupies the majority of the currently started super
@@ -334,11 +320,6 @@ func (bm *BlockManager) remoteValidation1(header *types.Header) bool {
 	return true
 }
 
-// Block chain rolls back to a valid block
-func (bm *BlockManager) fallBack() {
-	bm.blockChain.FallBack()
-}
-
 // Broadcast the block generated by yourself to the super node
 func (bm *BlockManager) broadCastBlock(block *types.Block) {
 	ids, err := bm.consensus.GetWinnersPeerID(block.Time)
@@ -377,34 +358,21 @@ func (bm *BlockManager) handleBlock() {
 	}
 }
 
-// Process blocks received from other super nodes.If the height
-// of the block is greater than the local height, the storage is
-// directly verified. If the height is less than the local height,
-// the remote verification is performed, and the verification is
-// passed back to the local block.
+// Process blocks received from other super nodes. Blocks that extend
+// the local head are verified and stored directly, blocks of another
+// branch above the confirmed height are handed to the fork choice.
 func (bm *BlockManager) dealReceivedBlock(block *types.Block) {
 	localHeight := bm.blockChain.GetLastHeight()
-	if localHeight == block.Height-1 {
-		if err := bm.blockChain.InsertChain(block); err != nil {
+	if block.Height > localHeight+1 || block.Height <= bm.blockChain.GetConfirmedHeight() {
+		return
+	}
+	if err := bm.blockChain.InsertChain(block); err != nil {
+		if err != core.ErrDuplicateBlock {
 			log.Warn("Failed to insert received block", "err", err, "height", block.Height, "singer", block.Signer.String())
-		} else {
-			log.Info("Received block", "height", block.Height, "singer", block.Signer.String())
 		}
-	} /*else if block.Height <= localHeight {
-		if localHeader, err := bm.blockChain.GetHeaderByHeight(block.Height); err == nil {
-			if !localHeader.Hash.IsEqual(block.Hash) {
-				log.Info("Local block is not equal received block hash", "local", localHeader.Hash, "received", block.Hash)
-				if ok, peerId := bm.IsFallBack(block.Header); ok {
-					bm.fallBack()
-					if peerInfo := bm.peerManager.GetPeer(peerId); peerInfo != nil {
-						bm.setSyncStream(peerInfo)
-					}
-				} else {
-					log.Warn("Remote validation failed!", "height", block.Height, "signer", block.Signer.String())
-				}
-			}
-		}
-	}*/
+	} else {
+		log.Info("Received block", "height", block.Height, "singer", block.Signer.String())
+	}
 }
 
 func getMaxCountHash(compareMap map[string][]string) string {
@@ -3,8 +3,11 @@ package contractstate
 import (
 	"fmt"
 	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/interface"
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
 	"github.com/uworldao/UWORLD/database/contractdb"
 	"sync"
 )
@@ -34,6 +37,33 @@ func (cs *ContractState) InitTrie(contractRoot hasharry.Hash) error {
 	return cs.contractDb.InitTrie(contractRoot)
 }
 
+// Open a read-only contract state at the contract root
+func (cs *ContractState) StateAt(contractRoot hasharry.Hash, confirmedHeight uint64) (_interface.IContractState, error) {
+	storage, err := cs.contractDb.CopyAt(contractRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractState{
+		contractDb:      storage,
+		confirmedHeight: confirmedHeight,
+	}, nil
+}
+
+// Walk the stored nodes of the contract trie of the root
+func (cs *ContractState) DumpTrie(contractRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error {
+	return cs.contractDb.DumpTrie(contractRoot, fn)
+}
+
+// Read the stored node of the contract trie
+func (cs *ContractState) TrieNode(hash hasharry.Hash) ([]byte, error) {
+	return cs.contractDb.TrieNode(hash)
+}
+
+// Store the contract trie of the root with the nodes from fn
+func (cs *ContractState) SyncTrie(contractRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error {
+	return cs.contractDb.SyncTrie(contractRoot, fn)
+}
+
 func (cs *ContractState) RootHash() hasharry.Hash {
 	return cs.contractDb.RootHash()
 }
@@ -121,6 +151,103 @@ func (c *ContractState) GetContractV2State(txHash string) *types.ContractV2State
 	return state
 }
 
+// Amount the spender may still transfer from the owner of the token
+func (c *ContractState) GetAllowance(address, owner, spender hasharry.Address) uint64 {
+	c.contractMutex.RLock()
+	defer c.contractMutex.RUnlock()
+
+	return c.contractDb.GetAllowance(address, owner, spender)
+}
+
+func (c *ContractState) SetAllowance(address, owner, spender hasharry.Address, amount uint64) {
+	c.contractMutex.Lock()
+	defer c.contractMutex.Unlock()
+
+	c.contractDb.SetAllowance(address, owner, spender, amount)
+}
+
+func (c *ContractState) GetNFT(collection hasharry.Address, id uint64) *nft.Token {
+	c.contractMutex.RLock()
+	defer c.contractMutex.RUnlock()
+
+	return c.contractDb.GetNFT(collection, id)
+}
+
+func (c *ContractState) SetNFT(token *nft.Token) {
+	c.contractMutex.Lock()
+	defer c.contractMutex.Unlock()
+
+	c.contractDb.SetNFT(token)
+}
+
+func (c *ContractState) DeleteNFT(collection hasharry.Address, id uint64) {
+	c.contractMutex.Lock()
+	defer c.contractMutex.Unlock()
+
+	c.contractDb.DeleteNFT(collection, id)
+}
+
+// Tokens of the collection with ids greater than start
+func (c *ContractState) GetCollectionNFTs(collection hasharry.Address, start, count uint64) []*nft.Token {
+	c.contractMutex.RLock()
+	defer c.contractMutex.RUnlock()
+
+	return c.contractDb.GetCollectionNFTs(collection, start, count)
+}
+
+func (c *ContractState) AddOwnerNFT(owner hasharry.Address, key nft.TokenKey) {
+	c.contractMutex.Lock()
+	defer c.contractMutex.Unlock()
+
+	c.contractDb.AddOwnerNFT(owner, key)
+}
+
+func (c *ContractState) RemoveOwnerNFT(owner hasharry.Address, key nft.TokenKey) {
+	c.contractMutex.Lock()
+	defer c.contractMutex.Unlock()
+
+	c.contractDb.RemoveOwnerNFT(owner, key)
+}
+
+// Number of the non-fungible tokens of the owner
+func (c *ContractState) GetOwnerNFTCount(owner hasharry.Address) uint64 {
+	c.contractMutex.RLock()
+	defer c.contractMutex.RUnlock()
+
+	return c.contractDb.GetOwnerNFTCount(owner)
+}
+
+// Keys of the non-fungible tokens of the owner from the index start
+func (c *ContractState) GetOwnerNFTs(owner hasharry.Address, start, count uint64) []nft.TokenKey {
+	c.contractMutex.RLock()
+	defer c.contractMutex.RUnlock()
+
+	return c.contractDb.GetOwnerNFTs(owner, start, count)
+}
+
+// Addresses of the swaps locked by the hash
+func (c *ContractState) GetHashLockSwaps(hashLock hasharry.Hash) htlc.HashLockSwaps {
+	c.contractMutex.RLock()
+	defer c.contractMutex.RUnlock()
+
+	return c.contractDb.GetHashLockSwaps(hashLock)
+}
+
+func (c *ContractState) SetHashLockSwaps(hashLock hasharry.Hash, swaps htlc.HashLockSwaps) {
+	c.contractMutex.Lock()
+	defer c.contractMutex.Unlock()
+
+	c.contractDb.SetHashLockSwaps(hashLock, swaps)
+}
+
+// Get the value of the key and the proof of it against the contract root
+func (c *ContractState) GetContractProof(key []byte) ([]byte, [][]byte, error) {
+	c.contractMutex.RLock()
+	defer c.contractMutex.RUnlock()
+
+	return c.contractDb.Prove(key)
+}
+
 func (c *ContractState) SetContractV2State(txHash string, contract *types.ContractV2State) {
 	c.contractMutex.RLock()
 	defer c.contractMutex.RUnlock()
@@ -4,6 +4,9 @@ import (
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/types"
 	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/contractv2/htlc"
+	"github.com/uworldao/UWORLD/core/types/contractv2/nft"
+	"github.com/uworldao/UWORLD/database/contractdb"
 )
 
 // Implement storage as contract state
@@ -14,7 +17,24 @@ type IContractStorage interface {
 	SetContractV2(contract *contractv2.ContractV2)
 	SetContractV2State(txHash string, state *types.ContractV2State)
 	GetContractV2State(txHash string) *types.ContractV2State
+	GetAllowance(address, owner, spender hasharry.Address) uint64
+	SetAllowance(address, owner, spender hasharry.Address, amount uint64)
+	GetNFT(collection hasharry.Address, id uint64) *nft.Token
+	SetNFT(token *nft.Token)
+	DeleteNFT(collection hasharry.Address, id uint64)
+	GetCollectionNFTs(collection hasharry.Address, start, count uint64) []*nft.Token
+	AddOwnerNFT(owner hasharry.Address, key nft.TokenKey)
+	RemoveOwnerNFT(owner hasharry.Address, key nft.TokenKey)
+	GetOwnerNFTCount(owner hasharry.Address) uint64
+	GetOwnerNFTs(owner hasharry.Address, start, count uint64) []nft.TokenKey
+	GetHashLockSwaps(hashLock hasharry.Hash) htlc.HashLockSwaps
+	SetHashLockSwaps(hashLock hasharry.Hash, swaps htlc.HashLockSwaps)
+	Prove(key []byte) ([]byte, [][]byte, error)
 	InitTrie(contractRoot hasharry.Hash) error
+	CopyAt(contractRoot hasharry.Hash) (*contractdb.ContractStorage, error)
+	DumpTrie(contractRoot hasharry.Hash, fn func(hash hasharry.Hash, node []byte) error) error
+	TrieNode(hash hasharry.Hash) ([]byte, error)
+	SyncTrie(contractRoot hasharry.Hash, fn func(hash hasharry.Hash) ([]byte, error)) error
 	RootHash() hasharry.Hash
 	Commit() (hasharry.Hash, error)
 	Close() error
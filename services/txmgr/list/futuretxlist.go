@@ -3,6 +3,7 @@ package list
 import (
 	"fmt"
 	"github.com/uworldao/UWORLD/core/types"
+	"strconv"
 )
 
 type FutureTxList struct {
@@ -24,7 +25,7 @@ func (f *FutureTxList) Put(tx types.ITransaction) error {
 	if oldTxHash := f.GetNonceKeyHash(tx.NonceKey()); oldTxHash != "" {
 		oldTx := f.Txs[oldTxHash]
 		if oldTx.GetFees() > tx.GetFees() {
-			return fmt.Errorf("transation nonce %d exist, the fees must biger than before %d", tx.GetNonce, oldTx.GetFees())
+			return fmt.Errorf("transation nonce %d exist, the fees must biger than before %d", tx.GetNonce(), oldTx.GetFees())
 		}
 		f.Remove(oldTx)
 	}
@@ -52,6 +53,15 @@ func (f *FutureTxList) GetNonceKeyHash(nonceKey string) string {
 	return f.nonceKeMap[nonceKey]
 }
 
+// Get the transaction of the address with the nonce
+func (f *FutureTxList) GetByNonce(from string, nonce uint64) types.ITransaction {
+	hash := f.GetNonceKeyHash(from + "_" + strconv.FormatUint(nonce, 10))
+	if hash == "" {
+		return nil
+	}
+	return f.Txs[hash]
+}
+
 func (f *FutureTxList) Len() int {
 	return len(f.Txs)
 }
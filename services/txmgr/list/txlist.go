@@ -2,6 +2,7 @@ package list
 
 import (
 	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/core/interface"
 	"github.com/uworldao/UWORLD/core/types"
 	"sync"
@@ -51,7 +52,9 @@ func (t *TxList) Load() error {
 	t.futureTxs = t.storage.LoadFutureTxs()
 	t.preparedTxs = t.storage.LoadPreparesTxs()
 	timeThreshold := uint64(time.Now().Unix() - TxLifeTime)
-	t.RemoveExpiredTx(timeThreshold)
+	// The height is not known yet, the expired transactions are
+	// removed by the next clearance of the pool
+	t.RemoveExpiredTx(timeThreshold, 0)
 	t.UpdateTxsList()
 	return nil
 }
@@ -69,6 +72,14 @@ func (t *TxList) Len() int {
 	return t.futureTxs.Len() + t.preparedTxs.Len()
 }
 
+// Number of transactions that can be packed
+func (t *TxList) PreparedLen() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.preparedTxs.Len()
+}
+
 // Add a new transaction. If there is already a transaction with
 // the same nonce value, the transaction fee for the new transaction
 // needs to be greater than the transaction fee for the existing
@@ -81,28 +92,50 @@ func (t *TxList) Put(tx types.ITransaction) error {
 
 	from := tx.From().String()
 	nonce, _ := t.state.GetAccountNonce(tx.From())
-	if nonce == tx.GetNonce()-1 {
-		oldTx := t.preparedTxs.GetByAddress(from)
-		if oldTx != nil {
-			if oldTx.GetNonce() == tx.GetNonce() && oldTx.GetFees() < tx.GetFees() {
-				t.preparedTxs.Remove(oldTx)
-			} else if oldTx.GetNonce() < tx.GetNonce() {
-				t.preparedTxs.Remove(oldTx)
-			} else if oldTx.GetNonce() == tx.GetNonce() {
-				return fmt.Errorf("the same nonce %d transaction already exists, so if you want to replace the nonce transaction, add a fee", tx.GetNonce())
-			} else {
-				return types.ErrTxNonceRepeat
-			}
+	if nonce >= tx.GetNonce() {
+		return types.ErrTxNonceRepeat
+	}
+	if oldTx := t.preparedTxs.GetByNonce(from, tx.GetNonce()); oldTx != nil {
+		if oldTx.GetFees() >= tx.GetFees() {
+			return fmt.Errorf("the same nonce %d transaction already exists, so if you want to replace the nonce transaction, add a fee", tx.GetNonce())
 		}
 		t.preparedTxs.Put(tx)
-	} else if nonce >= tx.GetNonce() {
-		return types.ErrTxNonceRepeat
-	} else {
+		return nil
+	}
+	if tx.GetNonce() != t.nextNonce(tx.From()) {
 		return t.futureTxs.Put(tx)
 	}
+	t.preparedTxs.Put(tx)
+	t.promote(tx.From())
 	return nil
 }
 
+// The nonce the next prepared transaction of the address must have,
+// the prepared transactions of an address have consecutive nonces
+// following the nonce of the account
+func (t *TxList) nextNonce(from hasharry.Address) uint64 {
+	nonce, _ := t.state.GetAccountNonce(from)
+	if last, ok := t.preparedTxs.LastNonce(from.String()); ok && last > nonce {
+		return last + 1
+	}
+	return nonce + 1
+}
+
+// Move the future transactions of the address that follow its
+// prepared transactions to the prepared list
+func (t *TxList) promote(from hasharry.Address) {
+	next := t.nextNonce(from)
+	for {
+		tx := t.futureTxs.GetByNonce(from.String(), next)
+		if tx == nil {
+			return
+		}
+		t.futureTxs.Remove(tx)
+		t.preparedTxs.Put(tx)
+		next++
+	}
+}
+
 //
 func (t *TxList) RemoveMinFeeTx(newTx types.ITransaction) {
 	t.mutex.Lock()
@@ -161,31 +194,38 @@ func (t *TxList) GetTransaction(hash string) (types.ITransaction, error) {
 }
 
 func (t *TxList) UpdateTxsList() {
-	t.mutex.RLock()
-	defer t.mutex.RUnlock()
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
 
 	t.preparedTxs.RemoveExecuted(t.state)
+	for _, tx := range t.preparedTxs.RemoveGapped(t.state) {
+		t.futureTxs.Put(tx)
+	}
 
+	senders := make(map[string]hasharry.Address)
 	for _, tx := range t.futureTxs.Txs {
 		nonce, _ := t.state.GetAccountNonce(tx.From())
-		if nonce < tx.GetNonce()-1 {
+		if nonce >= tx.GetNonce() {
+			t.futureTxs.Remove(tx)
 			continue
 		}
-		if nonce == tx.GetNonce()-1 {
-			t.preparedTxs.Put(tx)
-		}
-		t.futureTxs.Remove(tx)
+		senders[tx.From().String()] = tx.From()
+	}
+	for _, from := range senders {
+		t.promote(from)
 	}
 }
 
-func (t *TxList) RemoveExpiredTx(timeThreshold uint64) {
+// Remove the transactions received before the time threshold and the
+// transactions that can not be in the block of the height
+func (t *TxList) RemoveExpiredTx(timeThreshold, height uint64) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
-	t.preparedTxs.RemoveExpiredTx(timeThreshold)
+	t.preparedTxs.RemoveExpiredTx(timeThreshold, height)
 
 	for _, tx := range t.futureTxs.Txs {
-		if tx.GetTime() <= timeThreshold {
+		if tx.GetTime() <= timeThreshold || tx.Expired(height) {
 			t.futureTxs.Remove(tx)
 		}
 	}
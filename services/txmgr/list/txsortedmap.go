@@ -4,32 +4,39 @@ import (
 	"container/heap"
 	"github.com/uworldao/UWORLD/core/interface"
 	"github.com/uworldao/UWORLD/core/types"
+	"sort"
 )
 
 type TxSortedMap struct {
-	txs   map[string]types.ITransaction
+	// Transactions of each address in nonce order
+	txs   map[string]types.Transactions
 	cache map[string]types.ITransaction
 	index *txInfoList
 }
 
 func NewTxSortedMap() *TxSortedMap {
 	return &TxSortedMap{
-		txs:   make(map[string]types.ITransaction),
+		txs:   make(map[string]types.Transactions),
 		cache: make(map[string]types.ITransaction),
 		index: new(txInfoList),
 	}
 }
 
+// Add a transaction, a transaction of the address with the
+// same nonce is replaced
 func (t *TxSortedMap) Put(tx types.ITransaction) {
-	t.txs[tx.From().String()] = tx
+	from := tx.From().String()
+	if old := t.GetByNonce(from, tx.GetNonce()); old != nil {
+		t.Remove(old)
+	}
+	txs := t.txs[from]
+	i := sort.Search(len(txs), func(i int) bool { return txs[i].GetNonce() > tx.GetNonce() })
+	txs = append(txs, nil)
+	copy(txs[i+1:], txs[i:])
+	txs[i] = tx
+	t.txs[from] = txs
 	t.cache[tx.GetTxHead().TxHash.String()] = tx
-	heap.Push(t.index, &txInfo{
-		address: tx.From().String(),
-		txHash:  tx.Hash().String(),
-		fees:    tx.GetFees(),
-		nonce:   tx.GetNonce(),
-		time:    tx.GetTime(),
-	})
+	heap.Push(t.index, newTxInfo(tx))
 }
 
 func (t *TxSortedMap) GetAll() types.Transactions {
@@ -40,15 +47,27 @@ func (t *TxSortedMap) GetAll() types.Transactions {
 	return all
 }
 
+// Get the transactions with the highest fees, the transactions of
+// an address are returned in nonce order and stop at a missing nonce
 func (t *TxSortedMap) Gets(count int) types.Transactions {
 	var txs types.Transactions
-	rIndex := t.index.CopySelf()
-
-	for rIndex.Len() > 0 && count > 0 {
-		ti := heap.Pop(rIndex).(*txInfo)
-		tx := t.txs[ti.address]
-		txs = append(txs, tx)
+	heads := new(txInfoList)
+	for _, addrTxs := range t.txs {
+		heap.Push(heads, newTxInfo(addrTxs[0]))
+	}
+	next := make(map[string]int)
+	for heads.Len() > 0 && count > 0 {
+		ti := heap.Pop(heads).(*txInfo)
+		addrTxs := t.txs[ti.address]
+		i := next[ti.address]
+		txs = append(txs, addrTxs[i])
 		count--
+
+		i++
+		next[ti.address] = i
+		if i < len(addrTxs) && addrTxs[i].GetNonce() == addrTxs[i-1].GetNonce()+1 {
+			heap.Push(heads, newTxInfo(addrTxs[i]))
+		}
 	}
 	return txs
 }
@@ -63,25 +82,42 @@ func (t *TxSortedMap) GetStuck(sec uint64) types.Transactions {
 	return txs
 }
 
-func (t *TxSortedMap) GetByAddress(addr string) types.ITransaction {
-	return t.txs[addr]
+func (t *TxSortedMap) GetByNonce(addr string, nonce uint64) types.ITransaction {
+	for _, tx := range t.txs[addr] {
+		if tx.GetNonce() == nonce {
+			return tx
+		}
+	}
+	return nil
 }
 
-// If the transaction pool is full, delete the transaction with a small fee
+// Get the largest nonce of the address
+func (t *TxSortedMap) LastNonce(addr string) (uint64, bool) {
+	txs := t.txs[addr]
+	if len(txs) == 0 {
+		return 0, false
+	}
+	return txs[len(txs)-1].GetNonce(), true
+}
+
+// If the transaction pool is full, delete the transaction with a small fee.
+// Only the last transaction of an address is taken, removing an earlier
+// one would leave a gap in its nonces.
 func (t *TxSortedMap) PopMin(fees uint64) types.ITransaction {
-	if t.Len() > 0 {
-		if (*t.index)[0].fees <= fees {
-			ti := heap.Remove(t.index, 0).(*txInfo)
-			tx := t.txs[ti.address]
-			delete(t.txs, ti.address)
-			delete(t.cache, ti.txHash)
-			return tx
+	var min types.ITransaction
+	for _, txs := range t.txs {
+		if last := txs[len(txs)-1]; min == nil || last.GetFees() < min.GetFees() {
+			min = last
 		}
 	}
+	if min != nil && min.GetFees() <= fees {
+		t.Remove(min)
+		return min
+	}
 	return nil
 }
 
-func (t *TxSortedMap) Len() int { return len(t.txs) }
+func (t *TxSortedMap) Len() int { return len(t.cache) }
 
 func (t *TxSortedMap) IsExist(txHash string) bool {
 	_, ok := t.cache[txHash]
@@ -97,13 +133,29 @@ func (t *TxSortedMap) Remove(tx types.ITransaction) {
 	for i, ti := range *(t.index) {
 		if ti.txHash == tx.Hash().String() {
 			heap.Remove(t.index, i)
-			delete(t.txs, tx.From().String())
+			t.removeFromAddress(tx)
 			delete(t.cache, tx.GetTxHead().TxHash.String())
 			return
 		}
 	}
 }
 
+func (t *TxSortedMap) removeFromAddress(tx types.ITransaction) {
+	from := tx.From().String()
+	txs := t.txs[from]
+	for i, addrTx := range txs {
+		if addrTx.Hash().IsEqual(tx.Hash()) {
+			txs = append(txs[:i], txs[i+1:]...)
+			break
+		}
+	}
+	if len(txs) == 0 {
+		delete(t.txs, from)
+	} else {
+		t.txs[from] = txs
+	}
+}
+
 // Delete already packed transactions
 func (t *TxSortedMap) RemoveExecuted(state _interface.IAccountState) {
 	for _, tx := range t.cache {
@@ -113,10 +165,29 @@ func (t *TxSortedMap) RemoveExecuted(state _interface.IAccountState) {
 	}
 }
 
+// Remove the transactions that no longer follow the nonce of their
+// account, they are returned to wait for the missing nonce again
+func (t *TxSortedMap) RemoveGapped(state _interface.IAccountState) types.Transactions {
+	var gapped types.Transactions
+	for _, txs := range t.txs {
+		nonce, _ := state.GetAccountNonce(txs[0].From())
+		for i, tx := range txs {
+			if tx.GetNonce() != nonce+uint64(i)+1 {
+				gapped = append(gapped, txs[i:]...)
+				break
+			}
+		}
+	}
+	for _, tx := range gapped {
+		t.Remove(tx)
+	}
+	return gapped
+}
+
 // Delete expired transactions
-func (t *TxSortedMap) RemoveExpiredTx(timeThreshold uint64) {
+func (t *TxSortedMap) RemoveExpiredTx(timeThreshold, height uint64) {
 	for _, tx := range t.cache {
-		if tx.GetTime() <= timeThreshold {
+		if tx.GetTime() <= timeThreshold || tx.Expired(height) {
 			t.Remove(tx)
 		}
 	}
@@ -124,6 +195,16 @@ func (t *TxSortedMap) RemoveExpiredTx(timeThreshold uint64) {
 
 type txInfoList []*txInfo
 
+func newTxInfo(tx types.ITransaction) *txInfo {
+	return &txInfo{
+		address: tx.From().String(),
+		txHash:  tx.Hash().String(),
+		fees:    tx.GetFees(),
+		nonce:   tx.GetNonce(),
+		time:    tx.GetTime(),
+	}
+}
+
 type txInfo struct {
 	address string
 	txHash  string
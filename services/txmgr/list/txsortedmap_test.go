@@ -0,0 +1,150 @@
+package list
+
+import (
+	"testing"
+
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/interface"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+)
+
+var (
+	testAddressA = hasharry.StringToAddress("UWDM1qcsk7UUNANMPKSpALJW7AqpDCy7tdoN")
+	testAddressB = hasharry.StringToAddress("UWDR5oWfEjnGcEnQJrknuNW3LxZeWSpZUPJ5")
+	testAddressC = hasharry.StringToAddress("UWDP1EbJ1mpT4sDD1p6TWhvNXBjxRiqLjW7F")
+)
+
+func newTestTx(t *testing.T, from hasharry.Address, nonce, fees uint64) types.ITransaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.Transfer_,
+			From:       from,
+			Nonce:      nonce,
+			Fees:       fees,
+			Time:       1600000000,
+			SignScript: &types.SignScript{},
+		},
+		TxBody: &types.TransferBody{
+			Contract: param.Token,
+			To:       testAddressC,
+			Amount:   1 * param.AtomsPerCoin,
+		},
+	}
+	if err := tx.SetHash(); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+// The account nonces after a block, nothing else of the state is used
+type testState struct {
+	_interface.IAccountState
+	nonces map[hasharry.Address]uint64
+}
+
+func (s *testState) GetAccountNonce(address hasharry.Address) (uint64, error) {
+	return s.nonces[address], nil
+}
+
+func checkTxs(t *testing.T, txs types.Transactions, want ...types.ITransaction) {
+	t.Helper()
+	if len(txs) != len(want) {
+		t.Fatalf("%d transactions, want %d", len(txs), len(want))
+	}
+	for i := range want {
+		if txs[i].Hash() != want[i].Hash() {
+			t.Fatalf("transaction %d is %s nonce %d, want %s nonce %d", i, txs[i].From().String(),
+				txs[i].GetNonce(), want[i].From().String(), want[i].GetNonce())
+		}
+	}
+}
+
+func TestGetsInNonceOrder(t *testing.T) {
+	m := NewTxSortedMap()
+	a1, a2, a3 := newTestTx(t, testAddressA, 1, 10), newTestTx(t, testAddressA, 2, 100), newTestTx(t, testAddressA, 3, 40)
+	b1 := newTestTx(t, testAddressB, 1, 50)
+	for _, tx := range []types.ITransaction{a3, b1, a2, a1} {
+		m.Put(tx)
+	}
+	// The high fees of a2 do not pass a1, a3 waits for a2
+	checkTxs(t, m.Gets(10), b1, a1, a2, a3)
+	checkTxs(t, m.Gets(2), b1, a1)
+	if nonce, ok := m.LastNonce(testAddressA.String()); !ok || nonce != 3 {
+		t.Fatalf("last nonce %d, want 3", nonce)
+	}
+}
+
+func TestGetsStopsAtGap(t *testing.T) {
+	m := NewTxSortedMap()
+	a1, a3 := newTestTx(t, testAddressA, 1, 10), newTestTx(t, testAddressA, 3, 100)
+	b1, b2 := newTestTx(t, testAddressB, 1, 20), newTestTx(t, testAddressB, 2, 20)
+	for _, tx := range []types.ITransaction{a1, a3, b1, b2} {
+		m.Put(tx)
+	}
+	checkTxs(t, m.Gets(10), b1, b2, a1)
+}
+
+func TestPutReplacesNonce(t *testing.T) {
+	m := NewTxSortedMap()
+	a1, a2 := newTestTx(t, testAddressA, 1, 10), newTestTx(t, testAddressA, 2, 10)
+	m.Put(a1)
+	m.Put(a2)
+	replaced := newTestTx(t, testAddressA, 1, 30)
+	m.Put(replaced)
+	if m.Len() != 2 || m.IsExist(a1.Hash().String()) {
+		t.Fatal("the replaced transaction is kept")
+	}
+	if tx := m.GetByNonce(testAddressA.String(), 1); tx == nil || tx.Hash() != replaced.Hash() {
+		t.Fatal("the new transaction does not have the nonce")
+	}
+	checkTxs(t, m.Gets(10), replaced, a2)
+	// The replaced transaction is out of the fee index too
+	m.Remove(replaced)
+	m.Remove(a2)
+	if m.Len() != 0 || m.PopMin(1000) != nil {
+		t.Fatal("transactions are left")
+	}
+}
+
+func TestPopMinKeepsNonces(t *testing.T) {
+	m := NewTxSortedMap()
+	a1, a2 := newTestTx(t, testAddressA, 1, 10), newTestTx(t, testAddressA, 2, 30)
+	b1 := newTestTx(t, testAddressB, 1, 20)
+	for _, tx := range []types.ITransaction{a1, a2, b1} {
+		m.Put(tx)
+	}
+	if tx := m.PopMin(5); tx != nil {
+		t.Fatal("a transaction with more fees than the new one is removed")
+	}
+	// a1 has the lowest fees but a2 follows it
+	checkTxs(t, types.Transactions{m.PopMin(100)}, b1)
+	checkTxs(t, types.Transactions{m.PopMin(100)}, a2)
+	checkTxs(t, types.Transactions{m.PopMin(100)}, a1)
+	if m.Len() != 0 {
+		t.Fatalf("%d transactions left", m.Len())
+	}
+}
+
+func TestRemoveGappedAfterBlock(t *testing.T) {
+	m := NewTxSortedMap()
+	a1, a2, a3 := newTestTx(t, testAddressA, 1, 10), newTestTx(t, testAddressA, 2, 10), newTestTx(t, testAddressA, 3, 10)
+	b1, b2, b3 := newTestTx(t, testAddressB, 1, 10), newTestTx(t, testAddressB, 2, 10), newTestTx(t, testAddressB, 3, 10)
+	for _, tx := range []types.ITransaction{a1, a2, a3, b1, b2, b3} {
+		m.Put(tx)
+	}
+	// The block packs a1 and a2 and b1 is dropped, b2 and b3 have to
+	// wait for a nonce 1 of b again
+	m.Remove(a1)
+	m.Remove(a2)
+	m.Remove(b1)
+	state := &testState{nonces: map[hasharry.Address]uint64{testAddressA: 2}}
+	gapped := m.RemoveGapped(state)
+	if len(gapped) != 2 || m.IsExist(b2.Hash().String()) || m.IsExist(b3.Hash().String()) {
+		t.Fatal("the transactions after the gap are kept")
+	}
+	checkTxs(t, m.Gets(10), a3)
+	if gapped := m.RemoveGapped(state); len(gapped) != 0 {
+		t.Fatal("transactions that follow the account nonce are removed")
+	}
+}
@@ -44,6 +44,7 @@ type TxPool struct {
 	txChan        chan types.ITransaction
 	recTx         chan types.ITransaction
 	removeTxsCh   chan types.Transactions
+	revertedTxsCh chan types.Transactions
 	stateUpdateCh chan struct{}
 	stop          chan bool
 	lastHeightFunc
@@ -51,7 +52,7 @@ type TxPool struct {
 
 func NewTxPool(config *config.Config, accountState _interface.IAccountState, contractState _interface.IContractState,
 	consensus consensus.IConsensus, peerManager p2p.IPeerManager, network blkmgr.Network, runner *runner2.ContractRunner,
-	recTx chan types.ITransaction, stateUpdateCh chan struct{}, removeTxsCh chan types.Transactions,
+	recTx chan types.ITransaction, stateUpdateCh chan struct{}, removeTxsCh, revertedTxsCh chan types.Transactions,
 	newStream blkmgr.ICreateStream, lastHeightFunc lastHeightFunc) *TxPool {
 
 	return &TxPool{
@@ -64,6 +65,7 @@ func NewTxPool(config *config.Config, accountState _interface.IAccountState, con
 		network:        network,
 		recTx:          recTx,
 		removeTxsCh:    removeTxsCh,
+		revertedTxsCh:  revertedTxsCh,
 		stateUpdateCh:  stateUpdateCh,
 		newStream:      newStream,
 		txChan:         make(chan types.ITransaction, txChanLength),
@@ -129,6 +131,8 @@ func (tp *TxPool) dealTx() {
 			go tp.Add(tx, true)
 		case txs := <-tp.removeTxsCh:
 			go tp.Remove(txs)
+		case txs := <-tp.revertedTxsCh:
+			go tp.addReverted(txs)
 		case _ = <-tp.stateUpdateCh:
 			go tp.txs.UpdateTxsList()
 		}
@@ -153,6 +157,16 @@ func (tp *TxPool) Add(tx types.ITransaction, isPeer bool) error {
 	return tp.AddTransaction(tx, isPeer)
 }
 
+// Put the transactions of the reverted blocks back into the pool,
+// the ones that are no longer valid are dropped
+func (tp *TxPool) addReverted(txs types.Transactions) {
+	for _, tx := range txs {
+		if err := tp.AddTransaction(tx, false); err != nil {
+			log.Warn("Drop reverted transaction", "hash", tx.Hash(), "error", err)
+		}
+	}
+}
+
 // Verify adding transactions to the transaction pool
 func (tp *TxPool) AddTransaction(tx types.ITransaction, isPeer bool) error {
 	log.Info("TxPool receive transaction", "hash", tx.Hash())
@@ -178,23 +192,56 @@ func (tp *TxPool) AddTransaction(tx types.ITransaction, isPeer bool) error {
 	return nil
 }
 
-// Get transactions from the transaction pool
+// Get transactions from the transaction pool. The transactions of a
+// sender are verified in nonce order against its account changed by
+// the ones before, after a failed one the rest of the sender is left
+// in the pool.
 func (tp *TxPool) Gets(count int, maxSize uint64) types.Transactions {
 	prepare := make(types.Transactions, 0)
 	txs := tp.txs.Gets(count)
 	failed := types.Transactions{}
+	pending := make(map[hasharry.Address]types.IAccount)
+	skipped := make(map[hasharry.Address]bool)
+	height := tp.lastHeightFunc() + 1
+	once := make(map[types.TransactionType]bool)
+	senders := make(map[types.TransactionType]map[hasharry.Address]bool)
 	var txBytes uint64
 	for _, tx := range txs {
-		if err := tp.verifyTx(tx); err != nil {
+		if skipped[tx.From()] {
+			continue
+		}
+		// A block has at most one candidate logout and one evidence, the
+		// others wait for the next blocks with the later transactions of
+		// the sender
+		if once[tx.GetTxType()] {
+			skipped[tx.From()] = true
+			continue
+		}
+		// A sender claims its rewards or logs in once in a block
+		if senders[tx.GetTxType()][tx.From()] {
+			skipped[tx.From()] = true
+			continue
+		}
+		if err := tp.verifyPendingTx(tx, pending, height); err != nil {
 			failed = append(failed, tx)
+			skipped[tx.From()] = true
 		} else {
 			bytes, _ := tx.EncodeToBytes()
 			txLength := uint64(len(bytes))
 			if txBytes+txLength > maxSize {
-				return txs
+				break
 			}
 			txBytes += uint64(len(bytes))
 			prepare = append(prepare, tx)
+			if types.OncePerBlock(tx.GetTxType()) {
+				once[tx.GetTxType()] = true
+			}
+			if types.OncePerSender(tx.GetTxType()) {
+				if senders[tx.GetTxType()] == nil {
+					senders[tx.GetTxType()] = make(map[hasharry.Address]bool)
+				}
+				senders[tx.GetTxType()][tx.From()] = true
+			}
 		}
 	}
 	tp.Remove(failed)
@@ -207,6 +254,11 @@ func (tp *TxPool) GetAll() (types.Transactions, types.Transactions) {
 	return prepareTxs, futureTxs
 }
 
+// Number of transactions that can be packed
+func (tp *TxPool) PreparedLen() int {
+	return tp.txs.PreparedLen()
+}
+
 func (tp *TxPool) Get() types.ITransaction {
 	panic("implement me")
 }
@@ -232,11 +284,13 @@ func (tp *TxPool) GetTransaction(hash hasharry.Hash) (types.ITransaction, error)
 
 // Verify the transaction is legal
 func (tp *TxPool) verifyTx(tx types.ITransaction) error {
-	if err := tx.VerifyTx(); err != nil {
+	// The transaction is verified for the next block
+	height := tp.lastHeightFunc() + 1
+	if err := tx.VerifyTx(height); err != nil {
 		return err
 	}
 
-	if err := tp.consensus.VerifyTx(tx); err != nil {
+	if err := tp.consensus.VerifyTx(tx, height); err != nil {
 		return err
 	}
 
@@ -255,7 +309,33 @@ func (tp *TxPool) verifyTx(tx types.ITransaction) error {
 	return nil
 }
 
+// Verify the transaction as a transaction of the block at the height,
+// pending holds the senders changed by the earlier transactions
+func (tp *TxPool) verifyPendingTx(tx types.ITransaction, pending map[hasharry.Address]types.IAccount, height uint64) error {
+	if err := tx.VerifyTx(height); err != nil {
+		return err
+	}
+
+	if err := tp.consensus.VerifyTx(tx, height); err != nil {
+		return err
+	}
+
+	if err := tp.accountState.VerifyPendingState(tx, pending, height); err != nil {
+		return err
+	}
+
+	if err := tp.contractState.VerifyState(tx); err != nil {
+		return err
+	}
+
+	if err := tp.runner.Verify(tx, height-1); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (tp *TxPool) clearExpiredTx() {
 	timeThreshold := time.Now().Unix() - list.TxLifeTime
-	tp.txs.RemoveExpiredTx(uint64(timeThreshold))
+	tp.txs.RemoveExpiredTx(uint64(timeThreshold), tp.lastHeightFunc()+1)
 }
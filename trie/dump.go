@@ -0,0 +1,82 @@
+package trie
+
+import (
+	"errors"
+	"fmt"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	cryptohash "github.com/uworldao/UWORLD/crypto/hash"
+)
+
+// Number of nodes requested from the source in one round of SyncTrie
+const syncBatch = 256
+
+// DumpTrie calls fn with the hash and the encoding of every node the
+// trie of the root stores in the database.
+func DumpTrie(root hasharry.Hash, db Database, fn func(hash hasharry.Hash, node []byte) error) error {
+	if root == (hasharry.Hash{}) || root == emptyRoot {
+		return nil
+	}
+	t, err := New(root, db)
+	if err != nil {
+		return err
+	}
+	it := t.NodeIterator(nil)
+	for it.Next(true) {
+		hash := it.Hash()
+		if hash == (hasharry.Hash{}) {
+			continue
+		}
+		node, err := db.Get(hash.Bytes())
+		if err != nil {
+			return fmt.Errorf("trie node %s missing: %v", hash.String(), err)
+		}
+		if err := fn(hash, node); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// SyncTrie stores the trie of the root into the database with the nodes
+// returned by fn. Every node is checked against its hash before it is
+// used, so the source does not have to be trusted. Nodes the database
+// already has are written again, which also repairs a damaged trie.
+func SyncTrie(root hasharry.Hash, db Database, fn func(hash hasharry.Hash) ([]byte, error)) error {
+	if root == (hasharry.Hash{}) || root == emptyRoot {
+		return nil
+	}
+	sched := NewTrieSync(root, emptyReader{}, nil)
+	for sched.Pending() > 0 {
+		hashes := sched.Missing(syncBatch)
+		results := make([]SyncResult, len(hashes))
+		for i, hash := range hashes {
+			node, err := fn(hash)
+			if err != nil {
+				return err
+			}
+			if cryptohash.Hash(node) != hash {
+				return fmt.Errorf("trie node %s does not match its hash", hash.String())
+			}
+			results[i] = SyncResult{Hash: hash, Data: node}
+		}
+		if _, index, err := sched.Process(results); err != nil {
+			return fmt.Errorf("process trie node %s failed: %v", hashes[index].String(), err)
+		}
+		if _, err := sched.Commit(db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reader of a database without nodes, it makes TrieSync request every
+// node of the trie
+type emptyReader struct{}
+
+func (emptyReader) Get(key []byte) ([]byte, error) {
+	return nil, errors.New("not found")
+}
+
+func (emptyReader) Has(key []byte) (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,38 @@
+package trie
+
+import (
+	"github.com/uworldao/UWORLD/common/hasharry"
+	cryptohash "github.com/uworldao/UWORLD/crypto/hash"
+)
+
+// ProofList collects the nodes written by Prove in path order
+type ProofList [][]byte
+
+func (p *ProofList) Put(key []byte, value []byte) error {
+	node := make([]byte, len(value))
+	copy(node, value)
+	*p = append(*p, node)
+	return nil
+}
+
+// VerifyProofList checks the proof nodes of the key against the root
+// hash and returns the proven value, nil if the key does not exist.
+func VerifyProofList(rootHash hasharry.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	proofDb := make(proofReader, len(proof))
+	for _, node := range proof {
+		proofDb[string(cryptohash.Hash(node).Bytes())] = node
+	}
+	value, err, _ := VerifyProof(rootHash, key, proofDb)
+	return value, err
+}
+
+type proofReader map[string][]byte
+
+func (p proofReader) Get(key []byte) ([]byte, error) {
+	return p[string(key)], nil
+}
+
+func (p proofReader) Has(key []byte) (bool, error) {
+	_, ok := p[string(key)]
+	return ok, nil
+}
@@ -2,6 +2,7 @@ package ut
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"github.com/uworldao/UWORLD/common/hasharry"
 	"github.com/uworldao/UWORLD/crypto/base58"
@@ -60,6 +61,47 @@ func GenerateUWDAddress(version string, key *secp256k1.PublicKey) string {
 	return base58.Encode(hashedCheck1)
 }
 
+// Generate the address of a multi-signature account. The address is
+// the hash of the signature type, the number of required signatures
+// and the compressed public keys in order, it has the same form as a
+// single key address.
+func GenerateMultiSigAddress(version string, sigType uint8, m uint32, pubKeys [][]byte) (string, error) {
+	ver := []byte{}
+	switch version {
+	case param.MainNet:
+		ver = append(ver, param.MainPubKeyHashAddrID[0:]...)
+	case param.TestNet:
+		ver = append(ver, param.TestPubKeyHashAddrID[0:]...)
+	default:
+		return "", errors.New("wrong network")
+	}
+	if m == 0 || int(m) > len(pubKeys) {
+		return "", errors.New("wrong number of required signatures")
+	}
+	script := []byte{sigType}
+	mBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(mBytes, m)
+	script = append(script, mBytes...)
+	for _, pubKey := range pubKeys {
+		if len(pubKey) != secp256k1.PubKeyBytesLenCompressed {
+			return "", errors.New("public key must be compressed")
+		}
+		script = append(script, pubKey...)
+	}
+
+	hashed1 := hash.Hash(script)
+	hashed2, err := hash.Hash160(hashed1.Bytes())
+	if err != nil {
+		return "", err
+	}
+	addVersion := append(ver, hashed2...)
+	addVersionHashed1 := hash.Hash(addVersion)
+	addVersionHashed2 := hash.Hash(addVersionHashed1.Bytes())
+	checkSum := addVersionHashed2[0:4]
+	hashedCheck1 := append(addVersion, checkSum...)
+	return base58.Encode(hashedCheck1), nil
+}
+
 // Verify UWD address
 func CheckUWDAddress(version string, addr string) bool {
 	ver := []byte{}
@@ -15,6 +15,27 @@ func TestGenerateUWDAddress(t *testing.T) {
 	}
 }
 
+func TestGenerateMultiSigAddress(t *testing.T) {
+	key1, _ := secp256k1.GeneratePrivateKey()
+	key2, _ := secp256k1.GeneratePrivateKey()
+	pubKeys := [][]byte{key1.PubKey().SerializeCompressed(), key2.PubKey().SerializeCompressed()}
+
+	address, err := GenerateMultiSigAddress(param.Net, 0, 2, pubKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !CheckUWDAddress(param.Net, address) {
+		t.Fatal("failed")
+	}
+	other, _ := GenerateMultiSigAddress(param.Net, 0, 1, pubKeys)
+	if other == address {
+		t.Fatal("same address for different m")
+	}
+	if _, err := GenerateMultiSigAddress(param.Net, 0, 3, pubKeys); err == nil {
+		t.Fatal("m greater than the number of keys")
+	}
+}
+
 func TestCheckCoinName(t *testing.T) {
 	type args struct {
 		coinName string
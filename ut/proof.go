@@ -0,0 +1,54 @@
+package ut
+
+import (
+	"bytes"
+	"errors"
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/trie"
+)
+
+// Contract trie key versions, token contracts are keyed by the
+// address string and contracts v2 by the address bytes
+const (
+	ContractKeyV1 = 1
+	ContractKeyV2 = 2
+)
+
+// Get the key of a contract in the contract trie
+func ContractProofKey(address string, keyVersion uint8) ([]byte, error) {
+	switch keyVersion {
+	case ContractKeyV1:
+		return []byte(address), nil
+	case ContractKeyV2:
+		return hasharry.StringToAddress(address).Bytes(), nil
+	}
+	return nil, errors.New("wrong contract key version")
+}
+
+// Verify that the value is proven for the key under a trusted root,
+// an empty value means the proof must show the key does not exist
+func VerifyProof(root hasharry.Hash, key []byte, value []byte, proof [][]byte) error {
+	proven, err := trie.VerifyProofList(root, key, proof)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(proven, value) {
+		return errors.New("value does not match the proof")
+	}
+	return nil
+}
+
+// Verify the encoded account of the address against a trusted state
+// root, so the balance can be checked without trusting the node
+func VerifyAccountProof(stateRoot hasharry.Hash, address string, account []byte, proof [][]byte) error {
+	return VerifyProof(stateRoot, hasharry.StringToAddress(address).Bytes(), account, proof)
+}
+
+// Verify the encoded contract against a trusted contract root
+func VerifyContractProof(contractRoot hasharry.Hash, address string, keyVersion uint8, contract []byte, proof [][]byte) error {
+	key, err := ContractProofKey(address, keyVersion)
+	if err != nil {
+		return err
+	}
+	return VerifyProof(contractRoot, key, contract, proof)
+}
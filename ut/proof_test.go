@@ -0,0 +1,45 @@
+package ut
+
+import (
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/trie"
+	"testing"
+)
+
+func TestVerifyAccountProof(t *testing.T) {
+	accounts := map[string][]byte{
+		"3ajDJUnMYDyzXLwefRfNp7yLcdmg3ULb9ndQ": []byte("account one"),
+		"3ajPAQyobsVaDVAwhpeLo8vouirRrEJvDqZ2": []byte("account two"),
+		"3ajF4MdbBYE2UPESEyhQbdUj2Y28CNwGDCWA": []byte("account three"),
+	}
+	tr, _ := trie.New(hasharry.Hash{}, nil)
+	for address, account := range accounts {
+		tr.Update(hasharry.StringToAddress(address).Bytes(), account)
+	}
+	root := tr.Hash()
+
+	prove := func(address string) [][]byte {
+		var proof trie.ProofList
+		if err := tr.Prove(hasharry.StringToAddress(address).Bytes(), 0, &proof); err != nil {
+			t.Fatalf("Prove() error = %v", err)
+		}
+		return proof
+	}
+	for address, account := range accounts {
+		proof := prove(address)
+		if err := VerifyAccountProof(root, address, account, proof); err != nil {
+			t.Errorf("VerifyAccountProof() %s error = %v", address, err)
+		}
+		if err := VerifyAccountProof(root, address, []byte("forged account"), proof); err == nil {
+			t.Errorf("VerifyAccountProof() %s accepted a forged account", address)
+		}
+		if err := VerifyAccountProof(hasharry.Hash{1}, address, account, proof); err == nil {
+			t.Errorf("VerifyAccountProof() %s accepted a wrong root", address)
+		}
+	}
+
+	absent := "3ajNkh7yVYkETL9JKvGx3aL2YVNrqksjCUUE"
+	if err := VerifyAccountProof(root, absent, nil, prove(absent)); err != nil {
+		t.Errorf("VerifyAccountProof() absent account error = %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package transaction
+
+import (
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/param"
+	"time"
+)
+
+func NewLoginCandidate(from, peerId string, nonce uint64, note string) *types.Transaction {
+	body := &types.LoginTransactionBody{}
+	copy(body.PeerId[:], peerId)
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.LoginCandidate_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: body,
+	}
+	tx.SetHash()
+	return tx
+}
+
+// The balance of the sender is counted as the votes of the candidate,
+// a new vote replaces the previous one
+func NewVoteToCandidate(from, to string, nonce uint64, note string) *types.Transaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.VoteToCandidate_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.VoteTransactionBody{
+			To: hasharry.StringToAddress(to),
+		},
+	}
+	tx.SetHash()
+	return tx
+}
+
+func NewLogoutCandidate(from string, nonce uint64, note string) *types.Transaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.LogoutCandidate_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.LogoutTransactionBody{},
+	}
+	tx.SetHash()
+	return tx
+}
+
+// Evidence of the signer of the two headers for the same height and slot
+func NewDoubleSign(from string, header1, header2 *types.Header, nonce uint64, note string) *types.Transaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.DoubleSign_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.DoubleSignBody{
+			Header1: header1,
+			Header2: header2,
+		},
+	}
+	tx.SetHash()
+	return tx
+}
+
+// The rate in percent applies to the blocks of the candidate from
+// the next term
+func NewSetCommission(from string, rate, nonce uint64, note string) *types.Transaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.SetCommission_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.CommissionBody{
+			Rate: rate,
+		},
+	}
+	tx.SetHash()
+	return tx
+}
+
+func NewClaimReward(from string, amount, nonce uint64, note string) *types.Transaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ClaimReward_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.ClaimRewardBody{
+			Amount: amount,
+		},
+	}
+	tx.SetHash()
+	return tx
+}
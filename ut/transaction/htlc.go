@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/runner/htlc_runner"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/functionbody/htlc_func"
+	"github.com/uworldao/UWORLD/param"
+	"time"
+)
+
+func NewHTLC(net, from, recipient, token string, amount uint64, hashLock hasharry.Hash, timeLock, nonce uint64, note string) (*types.Transaction, error) {
+	contract, err := htlc_runner.HTLCAddress(net, from, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(contract),
+			Type:         contractv2.HTLC_,
+			FunctionType: contractv2.HTLC_Create,
+			Function: &htlc_func.HTLCCreateBody{
+				Recipient: hasharry.StringToAddress(recipient),
+				Token:     hasharry.StringToAddress(token),
+				Amount:    amount,
+				HashLock:  hashLock,
+				TimeLock:  timeLock,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewHTLCClaim(from, swap string, preimage []byte, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(swap),
+			Type:         contractv2.HTLC_,
+			FunctionType: contractv2.HTLC_Claim,
+			Function: &htlc_func.HTLCClaim{
+				Preimage: preimage,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewHTLCRefund(from, swap string, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(swap),
+			Type:         contractv2.HTLC_,
+			FunctionType: contractv2.HTLC_Refund,
+			Function:     &htlc_func.HTLCRefund{},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
@@ -0,0 +1,146 @@
+package transaction
+
+import (
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/runner/nft_runner"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/functionbody/nft_func"
+	"github.com/uworldao/UWORLD/param"
+	"time"
+)
+
+func NewNFTCollection(net, from, name, symbol string, nonce uint64, note string) (*types.Transaction, error) {
+	contract, err := nft_runner.CollectionAddress(net, from, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(contract),
+			Type:         contractv2.NFT_,
+			FunctionType: contractv2.NFT_Create,
+			Function: &nft_func.NFTCreateBody{
+				Name:   name,
+				Symbol: symbol,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewNFTMint(from, collection, to, uri string, metadataHash hasharry.Hash, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(collection),
+			Type:         contractv2.NFT_,
+			FunctionType: contractv2.NFT_Mint,
+			Function: &nft_func.NFTMint{
+				To:           hasharry.StringToAddress(to),
+				URI:          uri,
+				MetadataHash: metadataHash,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewNFTTransfer(from, collection string, id uint64, to string, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(collection),
+			Type:         contractv2.NFT_,
+			FunctionType: contractv2.NFT_Transfer,
+			Function: &nft_func.NFTTransfer{
+				Id: id,
+				To: hasharry.StringToAddress(to),
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewNFTApprove(from, collection string, id uint64, spender string, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(collection),
+			Type:         contractv2.NFT_,
+			FunctionType: contractv2.NFT_Approve,
+			Function: &nft_func.NFTApprove{
+				Id:      id,
+				Spender: hasharry.StringToAddress(spender),
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewNFTBurn(from, collection string, id, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(collection),
+			Type:         contractv2.NFT_,
+			FunctionType: contractv2.NFT_Burn,
+			Function: &nft_func.NFTBurn{
+				Id: id,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
@@ -0,0 +1,150 @@
+package transaction
+
+import (
+	"github.com/uworldao/UWORLD/common/hasharry"
+	"github.com/uworldao/UWORLD/core/runner/token_runner"
+	"github.com/uworldao/UWORLD/core/types"
+	"github.com/uworldao/UWORLD/core/types/contractv2"
+	"github.com/uworldao/UWORLD/core/types/functionbody/token_func"
+	"github.com/uworldao/UWORLD/param"
+	"time"
+)
+
+func NewToken(net, from, name, symbol string, decimals uint8, amount uint64, receiver string, mintable bool, nonce uint64, note string) (*types.Transaction, error) {
+	contract, err := token_runner.TokenAddress(net, from, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(contract),
+			Type:         contractv2.Token_,
+			FunctionType: contractv2.Token_Init,
+			Function: &token_func.TokenInitBody{
+				Name:     name,
+				Symbol:   symbol,
+				Decimals: decimals,
+				Amount:   amount,
+				Receiver: hasharry.StringToAddress(receiver),
+				Mintable: mintable,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewTokenApprove(from, token, spender string, amount, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(token),
+			Type:         contractv2.Token_,
+			FunctionType: contractv2.Token_Approve,
+			Function: &token_func.TokenApprove{
+				Spender: hasharry.StringToAddress(spender),
+				Amount:  amount,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewTokenTransferFrom(from, token, owner, to string, amount, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(token),
+			Type:         contractv2.Token_,
+			FunctionType: contractv2.Token_TransferFrom,
+			Function: &token_func.TokenTransferFrom{
+				Owner:  hasharry.StringToAddress(owner),
+				To:     hasharry.StringToAddress(to),
+				Amount: amount,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewTokenBurn(from, token string, amount, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(token),
+			Type:         contractv2.Token_,
+			FunctionType: contractv2.Token_Burn,
+			Function: &token_func.TokenBurn{
+				Amount: amount,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
+
+func NewTokenMint(from, token, to string, amount, nonce uint64, note string) (*types.Transaction, error) {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.ContractV2_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.TxContractV2Body{
+			Contract:     hasharry.StringToAddress(token),
+			Type:         contractv2.Token_,
+			FunctionType: contractv2.Token_Mint,
+			Function: &token_func.TokenMint{
+				To:     hasharry.StringToAddress(to),
+				Amount: amount,
+			},
+		},
+	}
+	tx.SetHash()
+	return tx, nil
+}
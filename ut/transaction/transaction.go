@@ -56,6 +56,32 @@ func NewTransactionV2(from string, toMap []map[string]uint64, token string, note
 	return tx
 }
 
+// A transfer that is locked in the account of the receiver until the
+// start height and released linearly until the end height
+func NewLockedTransaction(from, to, token string, note string, amount, start, end, nonce uint64) *types.Transaction {
+	tx := &types.Transaction{
+		TxHead: &types.TransactionHead{
+			TxType:     types.LockedTransfer_,
+			TxHash:     hasharry.Hash{},
+			From:       hasharry.StringToAddress(from),
+			Nonce:      nonce,
+			Time:       uint64(time.Now().Unix()),
+			Note:       note,
+			SignScript: &types.SignScript{},
+			Fees:       param.Fees,
+		},
+		TxBody: &types.LockedTransferBody{
+			Contract: hasharry.StringToAddress(token),
+			To:       hasharry.StringToAddress(to),
+			Amount:   amount,
+			Start:    start,
+			End:      end,
+		},
+	}
+	tx.SetHash()
+	return tx
+}
+
 func NewContract(from, to, contract string, note string, amount, nonce uint64, name, abbr string, increase bool, description string) *types.Transaction {
 	tx := &types.Transaction{
 		TxHead: &types.TransactionHead{